@@ -10,11 +10,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	handler_v1 "github.com/chekist32/goipay/internal/handler/v1"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
 	"github.com/chekist32/goipay/internal/processor"
+	"github.com/chekist32/goipay/internal/rate"
 	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/internal/webhook"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
@@ -33,6 +37,14 @@ type CliOpts struct {
 
 type TlsMode string
 
+type RateProviderType string
+
+const (
+	NONE_RATE_PROVIDER   RateProviderType = "none"
+	STATIC_RATE_PROVIDER RateProviderType = "static"
+	HTTP_RATE_PROVIDER   RateProviderType = "http"
+)
+
 const (
 	NONE_TLS_MODE TlsMode = "none"
 	TLS_TLS_MODE  TlsMode = "tls"
@@ -43,6 +55,9 @@ type AppConfigDaemon struct {
 	Url  string `yaml:"url"`
 	User string `yaml:"user"`
 	Pass string `yaml:"pass"`
+
+	ZmqPubRawTx     string `yaml:"zmqpubrawtx"`
+	ZmqPubHashBlock string `yaml:"zmqpubhashblock"`
 }
 
 type AppConfigTls struct {
@@ -52,6 +67,36 @@ type AppConfigTls struct {
 	Key  string `yaml:"key"`
 }
 
+type AppConfigRate struct {
+	Provider string `yaml:"provider"`
+	Static   struct {
+		File  string                        `yaml:"file"`
+		Rates map[string]map[string]float64 `yaml:"rates"`
+	} `yaml:"static"`
+	Http struct {
+		Url string `yaml:"url"`
+	} `yaml:"http"`
+}
+
+type AppConfigToken struct {
+	Coin     string `yaml:"coin"`
+	Symbol   string `yaml:"symbol"`
+	Chain    string `yaml:"chain"`
+	ChainId  uint64 `yaml:"chainId"`
+	Contract string `yaml:"contract"`
+	Decimals int32  `yaml:"decimals"`
+	Enabled  *bool  `yaml:"enabled"`
+}
+
+type AppConfigEvmChain struct {
+	Name      string           `yaml:"name"`
+	ChainId   uint64           `yaml:"chainId"`
+	Symbol    string           `yaml:"symbol"`
+	BlockTime string           `yaml:"blockTime"`
+	Daemon    AppConfigDaemon  `yaml:"daemon"`
+	Tokens    []AppConfigToken `yaml:"tokens"`
+}
+
 type AppConfig struct {
 	Server struct {
 		Host string       `yaml:"host"`
@@ -83,7 +128,20 @@ type AppConfig struct {
 		Bnb struct {
 			Daemon AppConfigDaemon `yaml:"daemon"`
 		} `yaml:"bnb"`
+		Evm []AppConfigEvmChain `yaml:"evm"`
 	} `yaml:"coin"`
+
+	Rate AppConfigRate `yaml:"rate"`
+
+	Token struct {
+		File   string           `yaml:"file"`
+		Tokens []AppConfigToken `yaml:"tokens"`
+	} `yaml:"token"`
+
+	Invoice struct {
+		LatePaymentWindow    string `yaml:"latePaymentWindow"`
+		AddressReuseCooldown string `yaml:"addressReuseCooldown"`
+	} `yaml:"invoice"`
 }
 
 func NewAppConfig(path string) (*AppConfig, error) {
@@ -118,10 +176,14 @@ func NewAppConfig(path string) (*AppConfig, error) {
 	conf.Coin.Btc.Daemon.Url = os.ExpandEnv(conf.Coin.Btc.Daemon.Url)
 	conf.Coin.Btc.Daemon.User = os.ExpandEnv(conf.Coin.Btc.Daemon.User)
 	conf.Coin.Btc.Daemon.Pass = os.ExpandEnv(conf.Coin.Btc.Daemon.Pass)
+	conf.Coin.Btc.Daemon.ZmqPubRawTx = os.ExpandEnv(conf.Coin.Btc.Daemon.ZmqPubRawTx)
+	conf.Coin.Btc.Daemon.ZmqPubHashBlock = os.ExpandEnv(conf.Coin.Btc.Daemon.ZmqPubHashBlock)
 
 	conf.Coin.Ltc.Daemon.Url = os.ExpandEnv(conf.Coin.Ltc.Daemon.Url)
 	conf.Coin.Ltc.Daemon.User = os.ExpandEnv(conf.Coin.Ltc.Daemon.User)
 	conf.Coin.Ltc.Daemon.Pass = os.ExpandEnv(conf.Coin.Ltc.Daemon.Pass)
+	conf.Coin.Ltc.Daemon.ZmqPubRawTx = os.ExpandEnv(conf.Coin.Ltc.Daemon.ZmqPubRawTx)
+	conf.Coin.Ltc.Daemon.ZmqPubHashBlock = os.ExpandEnv(conf.Coin.Ltc.Daemon.ZmqPubHashBlock)
 
 	conf.Coin.Eth.Daemon.Url = os.ExpandEnv(conf.Coin.Eth.Daemon.Url)
 	conf.Coin.Eth.Daemon.User = os.ExpandEnv(conf.Coin.Eth.Daemon.User)
@@ -131,6 +193,22 @@ func NewAppConfig(path string) (*AppConfig, error) {
 	conf.Coin.Bnb.Daemon.User = os.ExpandEnv(conf.Coin.Bnb.Daemon.User)
 	conf.Coin.Bnb.Daemon.Pass = os.ExpandEnv(conf.Coin.Bnb.Daemon.Pass)
 
+	for i := 0; i < len(conf.Coin.Evm); i++ {
+		conf.Coin.Evm[i].Name = os.ExpandEnv(conf.Coin.Evm[i].Name)
+		conf.Coin.Evm[i].Symbol = os.ExpandEnv(conf.Coin.Evm[i].Symbol)
+		conf.Coin.Evm[i].BlockTime = os.ExpandEnv(conf.Coin.Evm[i].BlockTime)
+		conf.Coin.Evm[i].Daemon.Url = os.ExpandEnv(conf.Coin.Evm[i].Daemon.Url)
+	}
+
+	conf.Rate.Provider = os.ExpandEnv(conf.Rate.Provider)
+	conf.Rate.Static.File = os.ExpandEnv(conf.Rate.Static.File)
+	conf.Rate.Http.Url = os.ExpandEnv(conf.Rate.Http.Url)
+
+	conf.Token.File = os.ExpandEnv(conf.Token.File)
+
+	conf.Invoice.LatePaymentWindow = os.ExpandEnv(conf.Invoice.LatePaymentWindow)
+	conf.Invoice.AddressReuseCooldown = os.ExpandEnv(conf.Invoice.AddressReuseCooldown)
+
 	return &conf, nil
 }
 
@@ -294,9 +372,11 @@ func getGrpcCrednetials(log *zerolog.Logger, c *AppConfig, opts *CliOpts) (grpc.
 func appConfigToDaemonsConfig(c *AppConfig) *dto.DaemonsConfig {
 	acdTodc := func(c *AppConfigDaemon) *dto.DaemonConfig {
 		return &dto.DaemonConfig{
-			Url:  c.Url,
-			User: c.User,
-			Pass: c.Pass,
+			Url:             c.Url,
+			User:            c.User,
+			Pass:            c.Pass,
+			ZmqPubRawTx:     c.ZmqPubRawTx,
+			ZmqPubHashBlock: c.ZmqPubHashBlock,
 		}
 	}
 
@@ -309,6 +389,172 @@ func appConfigToDaemonsConfig(c *AppConfig) *dto.DaemonsConfig {
 	}
 }
 
+// parseOptionalDuration returns 0, which disables the feature, when the value isn't set.
+func parseOptionalDuration(log *zerolog.Logger, name string, value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		log.Fatal().Err(err).Msgf("Invalid %v: %v. It must be a non-negative duration (e.g. 1h, 30m).", name, value)
+	}
+
+	return d
+}
+
+func getInvoiceConfig(log *zerolog.Logger, c *AppConfig) *dto.InvoiceConfig {
+	return &dto.InvoiceConfig{
+		LatePaymentWindow:    parseOptionalDuration(log, "late payment window", c.Invoice.LatePaymentWindow),
+		AddressReuseCooldown: parseOptionalDuration(log, "address reuse cooldown", c.Invoice.AddressReuseCooldown),
+	}
+}
+
+func getRateProvider(log *zerolog.Logger, c *AppConfig) rate.RateProvider {
+	switch RateProviderType(c.Rate.Provider) {
+	case "", NONE_RATE_PROVIDER:
+		return nil
+	case STATIC_RATE_PROVIDER:
+		if c.Rate.Static.File != "" {
+			p, err := rate.NewStaticRateProviderFromFile(c.Rate.Static.File)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to load static exchange rates.")
+			}
+			return p
+		}
+
+		p, err := rate.NewStaticRateProvider(c.Rate.Static.Rates)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load static exchange rates.")
+		}
+		return p
+	case HTTP_RATE_PROVIDER:
+		p, err := rate.NewHttpRateProvider(c.Rate.Http.Url)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to create http exchange rate provider.")
+		}
+		return p
+	default:
+		log.Fatal().Msgf("Invalid rate provider: %v. It must be one of: none, static, http.", c.Rate.Provider)
+	}
+
+	return nil
+}
+
+func getEvmChainsConfig(log *zerolog.Logger, c *AppConfig) []dto.EVMChainConfig {
+	res := make([]dto.EVMChainConfig, 0, len(c.Coin.Evm))
+	for _, chain := range c.Coin.Evm {
+		coin, err := util.CoinIdToDbCoin(chain.Name)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Invalid EVM chain name: %v.", chain.Name)
+		}
+		// The built-in coins have their own processors.
+		if _, err := util.DbCoinToPbCoin(coin); err == nil {
+			log.Fatal().Msgf("Invalid EVM chain name: %v. It's reserved for a built-in coin.", chain.Name)
+		}
+		if chain.ChainId == 0 {
+			log.Fatal().Msgf("Missing chain id of the EVM chain %v.", coin)
+		}
+		if chain.Daemon.Url == "" {
+			log.Fatal().Msgf("Missing daemon url of the EVM chain %v.", coin)
+		}
+
+		var blockTime time.Duration
+		if chain.BlockTime != "" {
+			blockTime, err = time.ParseDuration(chain.BlockTime)
+			if err != nil || blockTime <= 0 {
+				log.Fatal().Err(err).Msgf("Invalid block time of the EVM chain %v: %v. It must be a positive duration (e.g. 2s, 250ms).", coin, chain.BlockTime)
+			}
+		}
+
+		symbol := strings.ToUpper(strings.TrimSpace(chain.Symbol))
+		if symbol == "" {
+			symbol = string(coin)
+		}
+
+		res = append(res, dto.EVMChainConfig{
+			Coin:      coin,
+			Symbol:    symbol,
+			ChainId:   chain.ChainId,
+			BlockTime: blockTime,
+			Url:       chain.Daemon.Url,
+		})
+	}
+
+	return res
+}
+
+// getTokensConfig returns the tokens of the registry file, the token list and the EVM chains.
+func getTokensConfig(log *zerolog.Logger, c *AppConfig, evmChains []dto.EVMChainConfig) []dto.TokenConfig {
+	chains := map[db.CoinType]bool{db.CoinTypeETH: true, db.CoinTypeBNB: true}
+	for i := 0; i < len(evmChains); i++ {
+		chains[evmChains[i].Coin] = true
+	}
+
+	tokens := c.Token.Tokens
+	for i := 0; i < len(c.Coin.Evm); i++ {
+		for _, t := range c.Coin.Evm[i].Tokens {
+			t.Chain, t.ChainId = string(evmChains[i].Coin), evmChains[i].ChainId
+			tokens = append(tokens, t)
+		}
+	}
+	if c.Token.File != "" {
+		data, err := os.ReadFile(c.Token.File)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Failed to load the token registry.")
+		}
+
+		var fileTokens []AppConfigToken
+		if err := yaml.Unmarshal(data, &fileTokens); err != nil {
+			log.Fatal().Err(err).Msg("Failed to load the token registry.")
+		}
+		tokens = append(fileTokens, tokens...)
+	}
+
+	res := make([]dto.TokenConfig, 0, len(tokens))
+	for _, t := range tokens {
+		coin, err := util.CoinIdToDbCoin(t.Coin)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Invalid token coin: %v.", t.Coin)
+		}
+
+		chain := db.CoinType(strings.ToUpper(strings.TrimSpace(t.Chain)))
+		if !chains[chain] {
+			log.Fatal().Msgf("Invalid chain of the token %v: %v. It must be ETH, BNB or one of the EVM chains.", coin, t.Chain)
+		}
+		if t.ChainId == 0 {
+			log.Fatal().Msgf("Missing chain id of the token %v.", coin)
+		}
+		if !common.IsHexAddress(t.Contract) {
+			log.Fatal().Msgf("Invalid contract address of the token %v: %v.", coin, t.Contract)
+		}
+		if t.Decimals < 0 || t.Decimals > 77 {
+			log.Fatal().Msgf("Invalid decimals of the token %v: %v. They must be between 0 and 77.", coin, t.Decimals)
+		}
+
+		symbol := t.Symbol
+		if symbol == "" {
+			symbol = strings.SplitN(string(coin), "_", 2)[0]
+		}
+		enabled := true
+		if t.Enabled != nil {
+			enabled = *t.Enabled
+		}
+
+		res = append(res, dto.TokenConfig{
+			Coin:            coin,
+			Symbol:          symbol,
+			Chain:           chain,
+			ChainId:         t.ChainId,
+			ContractAddress: t.Contract,
+			Decimals:        t.Decimals,
+			Enabled:         enabled,
+		})
+	}
+
+	return res
+}
+
 func getLogger() *zerolog.Logger {
 	logger := zerolog.New(zerolog.NewConsoleWriter()).With().Timestamp().Caller().Logger()
 	return &logger
@@ -329,11 +575,17 @@ func NewApp(opts CliOpts) *App {
 		log.Fatal().Err(err).Msg("")
 	}
 
-	pp, err := processor.NewPaymentProcessor(ctx, connPool, appConfigToDaemonsConfig(conf), log)
+	daemonsConf := appConfigToDaemonsConfig(conf)
+	daemonsConf.Evm = getEvmChainsConfig(log, conf)
+	daemonsConf.Tokens = getTokensConfig(log, conf, daemonsConf.Evm)
+
+	pp, err := processor.NewPaymentProcessor(ctx, connPool, daemonsConf, getInvoiceConfig(log, conf), getRateProvider(log, conf), log)
 	if err != nil {
 		log.Fatal().Err(err).Msg("")
 	}
 
+	webhook.NewDispatcher(ctx, connPool, log).Start()
+
 	return &App{
 		log:              log,
 		ctxCancel:        cancel,
@@ -13,7 +13,7 @@ import (
 
 const createCryptoAddress = `-- name: CreateCryptoAddress :one
 INSERT INTO crypto_addresses(address, coin, is_occupied, user_id) VALUES ($1, $2, $3, $4)
-RETURNING id, address, coin, is_occupied, user_id
+RETURNING id, address, coin, is_occupied, user_id, available_at
 `
 
 type CreateCryptoAddressParams struct {
@@ -37,6 +37,7 @@ func (q *Queries) CreateCryptoAddress(ctx context.Context, arg CreateCryptoAddre
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.AvailableAt,
 	)
 	return i, err
 }
@@ -44,7 +45,7 @@ func (q *Queries) CreateCryptoAddress(ctx context.Context, arg CreateCryptoAddre
 const deleteAllCryptoAddressByUserIdAndCoin = `-- name: DeleteAllCryptoAddressByUserIdAndCoin :many
 DELETE FROM crypto_addresses 
 WHERE user_id = $1 AND coin = $2
-RETURNING id, address, coin, is_occupied, user_id
+RETURNING id, address, coin, is_occupied, user_id, available_at
 `
 
 type DeleteAllCryptoAddressByUserIdAndCoinParams struct {
@@ -67,6 +68,7 @@ func (q *Queries) DeleteAllCryptoAddressByUserIdAndCoin(ctx context.Context, arg
 			&i.Coin,
 			&i.IsOccupied,
 			&i.UserID,
+			&i.AvailableAt,
 		); err != nil {
 			return nil, err
 		}
@@ -82,11 +84,12 @@ const findNonOccupiedCryptoAddressAndLockByUserIdAndCoin = `-- name: FindNonOccu
 UPDATE crypto_addresses SET is_occupied = true
 WHERE address = (
     SELECT address FROM crypto_addresses AS ca
-    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false 
+    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false
+        AND (ca.available_at IS NULL OR ca.available_at <= timezone('UTC', now()))
     FOR UPDATE SKIP LOCKED
     LIMIT 1
 )
-RETURNING id, address, coin, is_occupied, user_id
+RETURNING id, address, coin, is_occupied, user_id, available_at
 `
 
 type FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams struct {
@@ -103,6 +106,65 @@ func (q *Queries) FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx context
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.AvailableAt,
+	)
+	return i, err
+}
+
+const occupyCryptoAddressNotHeldByOtherInvoice = `-- name: OccupyCryptoAddressNotHeldByOtherInvoice :one
+UPDATE crypto_addresses
+SET is_occupied = true
+WHERE address = $1
+    AND NOT EXISTS (
+        SELECT 1 FROM invoices
+        WHERE crypto_address = $1 AND id <> $2
+            AND status IN ('PENDING', 'PENDING_MEMPOOL', 'PARTIALLY_PAID')
+    )
+RETURNING id, address, coin, is_occupied, user_id, available_at
+`
+
+type OccupyCryptoAddressNotHeldByOtherInvoiceParams struct {
+	Address   string
+	InvoiceID pgtype.UUID
+}
+
+func (q *Queries) OccupyCryptoAddressNotHeldByOtherInvoice(ctx context.Context, arg OccupyCryptoAddressNotHeldByOtherInvoiceParams) (CryptoAddress, error) {
+	row := q.db.QueryRow(ctx, occupyCryptoAddressNotHeldByOtherInvoice, arg.Address, arg.InvoiceID)
+	var i CryptoAddress
+	err := row.Scan(
+		&i.ID,
+		&i.Address,
+		&i.Coin,
+		&i.IsOccupied,
+		&i.UserID,
+		&i.AvailableAt,
+	)
+	return i, err
+}
+
+const releaseCryptoAddress = `-- name: ReleaseCryptoAddress :one
+UPDATE crypto_addresses
+SET is_occupied = false,
+    available_at = $2
+WHERE address = $1
+RETURNING id, address, coin, is_occupied, user_id, available_at
+`
+
+type ReleaseCryptoAddressParams struct {
+	Address     string
+	AvailableAt pgtype.Timestamptz
+}
+
+func (q *Queries) ReleaseCryptoAddress(ctx context.Context, arg ReleaseCryptoAddressParams) (CryptoAddress, error) {
+	row := q.db.QueryRow(ctx, releaseCryptoAddress, arg.Address, arg.AvailableAt)
+	var i CryptoAddress
+	err := row.Scan(
+		&i.ID,
+		&i.Address,
+		&i.Coin,
+		&i.IsOccupied,
+		&i.UserID,
+		&i.AvailableAt,
 	)
 	return i, err
 }
@@ -111,7 +173,7 @@ const updateIsOccupiedByCryptoAddress = `-- name: UpdateIsOccupiedByCryptoAddres
 UPDATE crypto_addresses 
 SET is_occupied = $2
 WHERE address = $1
-RETURNING id, address, coin, is_occupied, user_id
+RETURNING id, address, coin, is_occupied, user_id, available_at
 `
 
 type UpdateIsOccupiedByCryptoAddressParams struct {
@@ -128,6 +190,7 @@ func (q *Queries) UpdateIsOccupiedByCryptoAddress(ctx context.Context, arg Updat
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.AvailableAt,
 	)
 	return i, err
 }
@@ -242,6 +242,38 @@ func (q *Queries) FindKeysAndIncrementedIndicesETHCryptoDataById(ctx context.Con
 	return i, err
 }
 
+const findKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain = `-- name: FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain :one
+UPDATE evm_crypto_data
+SET last_minor_index = CASE 
+        WHEN last_minor_index >= 2147483647 THEN 0
+        ELSE last_minor_index + 1
+    END,
+    last_major_index = CASE 
+        WHEN last_minor_index >= 2147483647 THEN last_major_index + 1
+        ELSE last_major_index
+    END
+WHERE user_id = $1 AND chain = $2
+RETURNING master_pub_key, last_major_index, last_minor_index
+`
+
+type FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainParams struct {
+	UserID pgtype.UUID
+	Chain  CoinType
+}
+
+type FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainRow struct {
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
+func (q *Queries) FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain(ctx context.Context, arg FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainParams) (FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainRow, error) {
+	row := q.db.QueryRow(ctx, findKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain, arg.UserID, arg.Chain)
+	var i FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainRow
+	err := row.Scan(&i.MasterPubKey, &i.LastMajorIndex, &i.LastMinorIndex)
+	return i, err
+}
+
 const findKeysAndIncrementedIndicesLTCCryptoDataById = `-- name: FindKeysAndIncrementedIndicesLTCCryptoDataById :one
 UPDATE ltc_crypto_data
 SET last_minor_index = CASE 
@@ -582,3 +614,32 @@ func (q *Queries) UpdateKeysXMRCryptoDataById(ctx context.Context, arg UpdateKey
 	)
 	return i, err
 }
+
+const upsertKeysEVMCryptoData = `-- name: UpsertKeysEVMCryptoData :one
+INSERT INTO evm_crypto_data(user_id, chain, master_pub_key) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, chain) DO UPDATE
+SET master_pub_key = EXCLUDED.master_pub_key,
+    last_major_index = 0,
+    last_minor_index = 0
+RETURNING user_id, chain, master_pub_key, last_major_index, last_minor_index
+`
+
+type UpsertKeysEVMCryptoDataParams struct {
+	UserID       pgtype.UUID
+	Chain        CoinType
+	MasterPubKey string
+}
+
+// EVM
+func (q *Queries) UpsertKeysEVMCryptoData(ctx context.Context, arg UpsertKeysEVMCryptoDataParams) (EvmCryptoDatum, error) {
+	row := q.db.QueryRow(ctx, upsertKeysEVMCryptoData, arg.UserID, arg.Chain, arg.MasterPubKey)
+	var i EvmCryptoDatum
+	err := row.Scan(
+		&i.UserID,
+		&i.Chain,
+		&i.MasterPubKey,
+		&i.LastMajorIndex,
+		&i.LastMinorIndex,
+	)
+	return i, err
+}
@@ -11,12 +11,54 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelInvoiceById = `-- name: CancelInvoiceById :one
+UPDATE invoices
+SET status = 'CANCELLED'
+WHERE id = $1 AND status = 'PENDING'
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
+`
+
+func (q *Queries) CancelInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, cancelInvoiceById, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
 const confirmInvoiceById = `-- name: ConfirmInvoiceById :one
 UPDATE invoices
 SET status = 'CONFIRMED',
     confirmed_at = timezone('UTC', now())
 WHERE id = $1
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
 `
 
 func (q *Queries) ConfirmInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
@@ -35,6 +77,21 @@ func (q *Queries) ConfirmInvoiceById(ctx context.Context, id pgtype.UUID) (Invoi
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
 	)
 	return i, err
 }
@@ -43,19 +100,29 @@ const confirmInvoiceStatusMempoolById = `-- name: ConfirmInvoiceStatusMempoolByI
 UPDATE invoices
 SET actual_amount = $2,
     status = 'PENDING_MEMPOOL',
-    tx_id = $3
-WHERE id = $1
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+    tx_id = $3,
+    payment_outcome = $4,
+    payment_outcome_amount = $5
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
 `
 
 type ConfirmInvoiceStatusMempoolByIdParams struct {
-	ID           pgtype.UUID
-	ActualAmount pgtype.Float8
-	TxID         pgtype.Text
+	ID                   pgtype.UUID
+	ActualAmount         pgtype.Numeric
+	TxID                 pgtype.Text
+	PaymentOutcome       NullPaymentOutcomeType
+	PaymentOutcomeAmount pgtype.Numeric
 }
 
 func (q *Queries) ConfirmInvoiceStatusMempoolById(ctx context.Context, arg ConfirmInvoiceStatusMempoolByIdParams) (Invoice, error) {
-	row := q.db.QueryRow(ctx, confirmInvoiceStatusMempoolById, arg.ID, arg.ActualAmount, arg.TxID)
+	row := q.db.QueryRow(ctx, confirmInvoiceStatusMempoolById,
+		arg.ID,
+		arg.ActualAmount,
+		arg.TxID,
+		arg.PaymentOutcome,
+		arg.PaymentOutcomeAmount,
+	)
 	var i Invoice
 	err := row.Scan(
 		&i.ID,
@@ -70,6 +137,21 @@ func (q *Queries) ConfirmInvoiceStatusMempoolById(ctx context.Context, arg Confi
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
 	)
 	return i, err
 }
@@ -81,18 +163,60 @@ INSERT INTO invoices(
     required_amount, 
     confirmations_required,
     expires_at,
-    user_id) 
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+    user_id,
+    idempotency_key,
+    idempotency_fingerprint,
+    external_id,
+    description,
+    metadata,
+    fiat_amount,
+    fiat_currency,
+    exchange_rate,
+    rate_source,
+    tolerance_percent,
+    tolerance_amount,
+    payment_request_id) 
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    COALESCE($11::jsonb, '{}'::jsonb),
+    $12,
+    $13,
+    $14,
+    $15,
+    $16,
+    $17,
+    $18)
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
 `
 
 type CreateInvoiceParams struct {
-	CryptoAddress         string
-	Coin                  CoinType
-	RequiredAmount        float64
-	ConfirmationsRequired int16
-	ExpiresAt             pgtype.Timestamptz
-	UserID                pgtype.UUID
+	CryptoAddress          string
+	Coin                   CoinType
+	RequiredAmount         pgtype.Numeric
+	ConfirmationsRequired  int16
+	ExpiresAt              pgtype.Timestamptz
+	UserID                 pgtype.UUID
+	IdempotencyKey         pgtype.Text
+	IdempotencyFingerprint pgtype.Text
+	ExternalID             pgtype.Text
+	Description            pgtype.Text
+	Metadata               []byte
+	FiatAmount             pgtype.Float8
+	FiatCurrency           pgtype.Text
+	ExchangeRate           pgtype.Float8
+	RateSource             pgtype.Text
+	TolerancePercent       pgtype.Float8
+	ToleranceAmount        pgtype.Numeric
+	PaymentRequestID       pgtype.UUID
 }
 
 func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (Invoice, error) {
@@ -103,6 +227,18 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		arg.ConfirmationsRequired,
 		arg.ExpiresAt,
 		arg.UserID,
+		arg.IdempotencyKey,
+		arg.IdempotencyFingerprint,
+		arg.ExternalID,
+		arg.Description,
+		arg.Metadata,
+		arg.FiatAmount,
+		arg.FiatCurrency,
+		arg.ExchangeRate,
+		arg.RateSource,
+		arg.TolerancePercent,
+		arg.ToleranceAmount,
+		arg.PaymentRequestID,
 	)
 	var i Invoice
 	err := row.Scan(
@@ -118,15 +254,32 @@ func (q *Queries) CreateInvoice(ctx context.Context, arg CreateInvoiceParams) (I
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
 	)
 	return i, err
 }
 
 const expireInvoiceById = `-- name: ExpireInvoiceById :one
 UPDATE invoices
-SET status = 'EXPIRED'
+SET status = 'EXPIRED',
+    payment_outcome = CASE WHEN status = 'PARTIALLY_PAID' THEN 'UNDERPAID' ELSE payment_outcome END,
+    payment_outcome_amount = CASE WHEN status = 'PARTIALLY_PAID' THEN required_amount - actual_amount ELSE payment_outcome_amount END
 WHERE id = $1
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
 `
 
 func (q *Queries) ExpireInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
@@ -145,13 +298,28 @@ func (q *Queries) ExpireInvoiceById(ctx context.Context, id pgtype.UUID) (Invoic
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
 	)
 	return i, err
 }
 
 const findAllPendingInvoices = `-- name: FindAllPendingInvoices :many
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL')
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL')
 `
 
 func (q *Queries) FindAllPendingInvoices(ctx context.Context) ([]Invoice, error) {
@@ -176,6 +344,315 @@ func (q *Queries) FindAllPendingInvoices(ctx context.Context) ([]Invoice, error)
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findExpiredInvoicesSince = `-- name: FindExpiredInvoicesSince :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE status IN ('EXPIRED', 'PAID_AFTER_EXPIRY') AND expires_at > $1
+`
+
+func (q *Queries) FindExpiredInvoicesSince(ctx context.Context, expiresAt pgtype.Timestamptz) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findExpiredInvoicesSince, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInvoiceById = `-- name: FindInvoiceById :one
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE id = $1
+`
+
+func (q *Queries) FindInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, findInvoiceById, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
+const findInvoiceByIdForUpdate = `-- name: FindInvoiceByIdForUpdate :one
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) FindInvoiceByIdForUpdate(ctx context.Context, id pgtype.UUID) (Invoice, error) {
+	row := q.db.QueryRow(ctx, findInvoiceByIdForUpdate, id)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
+const findInvoiceByUserIdAndIdempotencyKey = `-- name: FindInvoiceByUserIdAndIdempotencyKey :one
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE user_id = $1 AND idempotency_key = $2
+`
+
+type FindInvoiceByUserIdAndIdempotencyKeyParams struct {
+	UserID         pgtype.UUID
+	IdempotencyKey pgtype.Text
+}
+
+func (q *Queries) FindInvoiceByUserIdAndIdempotencyKey(ctx context.Context, arg FindInvoiceByUserIdAndIdempotencyKeyParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, findInvoiceByUserIdAndIdempotencyKey, arg.UserID, arg.IdempotencyKey)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
+const findInvoicesByPaymentRequestId = `-- name: FindInvoicesByPaymentRequestId :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE payment_request_id = $1
+ORDER BY created_at, id
+`
+
+func (q *Queries) FindInvoicesByPaymentRequestId(ctx context.Context, paymentRequestID pgtype.UUID) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findInvoicesByPaymentRequestId, paymentRequestID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInvoicesByPaymentTxIdAndLock = `-- name: FindInvoicesByPaymentTxIdAndLock :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE coin = $1
+    AND status IN ('PENDING_MEMPOOL', 'PARTIALLY_PAID')
+    AND id IN (SELECT invoice_id FROM invoice_payments WHERE tx_id = $2::text)
+FOR UPDATE
+`
+
+type FindInvoicesByPaymentTxIdAndLockParams struct {
+	Coin CoinType
+	TxID string
+}
+
+func (q *Queries) FindInvoicesByPaymentTxIdAndLock(ctx context.Context, arg FindInvoicesByPaymentTxIdAndLockParams) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findInvoicesByPaymentTxIdAndLock, arg.Coin, arg.TxID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
 		); err != nil {
 			return nil, err
 		}
@@ -187,11 +664,274 @@ func (q *Queries) FindAllPendingInvoices(ctx context.Context) ([]Invoice, error)
 	return items, nil
 }
 
+const findInvoicesFiltered = `-- name: FindInvoicesFiltered :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE ($1::uuid IS NULL OR user_id = $1)
+    AND ($2::text IS NULL OR coin = $2)
+    AND ($3::invoice_status_type IS NULL OR status = $3)
+    AND ($4::timestamptz IS NULL OR created_at >= $4)
+    AND ($5::timestamptz IS NULL OR created_at <= $5)
+    AND ($6::timestamptz IS NULL OR expires_at >= $6)
+    AND ($7::timestamptz IS NULL OR expires_at <= $7)
+    AND ($8::text IS NULL OR tx_id = $8 OR EXISTS (
+        SELECT 1 FROM invoice_payments p WHERE p.invoice_id = invoices.id AND p.tx_id = $8
+    ))
+    AND ($9::text IS NULL OR external_id = $9)
+    AND ($10::text IS NULL OR (
+        metadata ? $10
+        AND ($11::text IS NULL OR metadata ->> $10 = $11)
+    ))
+ORDER BY created_at DESC, id
+LIMIT $12::int OFFSET $13::int
+`
+
+type FindInvoicesFilteredParams struct {
+	UserID        pgtype.UUID
+	Coin          pgtype.Text
+	Status        NullInvoiceStatusType
+	CreatedFrom   pgtype.Timestamptz
+	CreatedTo     pgtype.Timestamptz
+	ExpiresFrom   pgtype.Timestamptz
+	ExpiresTo     pgtype.Timestamptz
+	TxID          pgtype.Text
+	ExternalID    pgtype.Text
+	MetadataKey   pgtype.Text
+	MetadataValue pgtype.Text
+	Limit         int32
+	Offset        int32
+}
+
+func (q *Queries) FindInvoicesFiltered(ctx context.Context, arg FindInvoicesFilteredParams) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findInvoicesFiltered,
+		arg.UserID,
+		arg.Coin,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.ExpiresFrom,
+		arg.ExpiresTo,
+		arg.TxID,
+		arg.ExternalID,
+		arg.MetadataKey,
+		arg.MetadataValue,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInvoicesWithPaymentsAboveBlockHeightAndLock = `-- name: FindInvoicesWithPaymentsAboveBlockHeightAndLock :many
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
+WHERE coin = $1
+    AND status IN ('PARTIALLY_PAID', 'PENDING_MEMPOOL', 'CONFIRMED')
+    AND id IN (SELECT invoice_id FROM invoice_payments WHERE block_height > $2::bigint)
+FOR UPDATE
+`
+
+type FindInvoicesWithPaymentsAboveBlockHeightAndLockParams struct {
+	Coin        CoinType
+	BlockHeight int64
+}
+
+func (q *Queries) FindInvoicesWithPaymentsAboveBlockHeightAndLock(ctx context.Context, arg FindInvoicesWithPaymentsAboveBlockHeightAndLockParams) ([]Invoice, error) {
+	rows, err := q.db.Query(ctx, findInvoicesWithPaymentsAboveBlockHeightAndLock, arg.Coin, arg.BlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(
+			&i.ID,
+			&i.CryptoAddress,
+			&i.Coin,
+			&i.RequiredAmount,
+			&i.ActualAmount,
+			&i.ConfirmationsRequired,
+			&i.CreatedAt,
+			&i.ConfirmedAt,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.TxID,
+			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revertConfirmedInvoiceById = `-- name: RevertConfirmedInvoiceById :one
+UPDATE invoices
+SET status = 'PENDING_MEMPOOL',
+    confirmations = 0,
+    confirmed_at = NULL,
+    expires_at = GREATEST(expires_at, $1::timestamptz)
+WHERE id = $2 AND status = 'CONFIRMED'
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
+`
+
+type RevertConfirmedInvoiceByIdParams struct {
+	ExpiresAt pgtype.Timestamptz
+	ID        pgtype.UUID
+}
+
+func (q *Queries) RevertConfirmedInvoiceById(ctx context.Context, arg RevertConfirmedInvoiceByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, revertConfirmedInvoiceById, arg.ExpiresAt, arg.ID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
+const revertInvoiceToPendingById = `-- name: RevertInvoiceToPendingById :one
+UPDATE invoices
+SET actual_amount = $1,
+    status = $2,
+    tx_id = NULL,
+    payment_outcome = NULL,
+    payment_outcome_amount = NULL,
+    confirmations = 0
+WHERE id = $3 AND status IN ('PENDING_MEMPOOL', 'PARTIALLY_PAID')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
+`
+
+type RevertInvoiceToPendingByIdParams struct {
+	ActualAmount pgtype.Numeric
+	Status       InvoiceStatusType
+	ID           pgtype.UUID
+}
+
+func (q *Queries) RevertInvoiceToPendingById(ctx context.Context, arg RevertInvoiceToPendingByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, revertInvoiceToPendingById, arg.ActualAmount, arg.Status, arg.ID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
 const shiftExpiresAtForNonConfirmedInvoices = `-- name: ShiftExpiresAtForNonConfirmedInvoices :many
 UPDATE invoices
 SET expires_at = timezone('UTC', now()) + INTERVAL '5 minute'
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
-RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
 `
 
 func (q *Queries) ShiftExpiresAtForNonConfirmedInvoices(ctx context.Context) ([]Invoice, error) {
@@ -216,6 +956,21 @@ func (q *Queries) ShiftExpiresAtForNonConfirmedInvoices(ctx context.Context) ([]
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
 		); err != nil {
 			return nil, err
 		}
@@ -226,3 +981,155 @@ func (q *Queries) ShiftExpiresAtForNonConfirmedInvoices(ctx context.Context) ([]
 	}
 	return items, nil
 }
+
+const updateInvoiceConfirmationsById = `-- name: UpdateInvoiceConfirmationsById :one
+UPDATE invoices
+SET confirmations = $2
+WHERE id = $1
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
+`
+
+type UpdateInvoiceConfirmationsByIdParams struct {
+	ID            pgtype.UUID
+	Confirmations int32
+}
+
+func (q *Queries) UpdateInvoiceConfirmationsById(ctx context.Context, arg UpdateInvoiceConfirmationsByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, updateInvoiceConfirmationsById, arg.ID, arg.Confirmations)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
+const updateInvoiceStatusPaidAfterExpiryById = `-- name: UpdateInvoiceStatusPaidAfterExpiryById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PAID_AFTER_EXPIRY',
+    payment_outcome = $3,
+    payment_outcome_amount = $4
+WHERE id = $1 AND status IN ('EXPIRED', 'PAID_AFTER_EXPIRY')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
+`
+
+type UpdateInvoiceStatusPaidAfterExpiryByIdParams struct {
+	ID                   pgtype.UUID
+	ActualAmount         pgtype.Numeric
+	PaymentOutcome       NullPaymentOutcomeType
+	PaymentOutcomeAmount pgtype.Numeric
+}
+
+func (q *Queries) UpdateInvoiceStatusPaidAfterExpiryById(ctx context.Context, arg UpdateInvoiceStatusPaidAfterExpiryByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, updateInvoiceStatusPaidAfterExpiryById,
+		arg.ID,
+		arg.ActualAmount,
+		arg.PaymentOutcome,
+		arg.PaymentOutcomeAmount,
+	)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
+
+const updateInvoiceStatusPartiallyPaidById = `-- name: UpdateInvoiceStatusPartiallyPaidById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PARTIALLY_PAID'
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID')
+RETURNING id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations
+`
+
+type UpdateInvoiceStatusPartiallyPaidByIdParams struct {
+	ID           pgtype.UUID
+	ActualAmount pgtype.Numeric
+}
+
+func (q *Queries) UpdateInvoiceStatusPartiallyPaidById(ctx context.Context, arg UpdateInvoiceStatusPartiallyPaidByIdParams) (Invoice, error) {
+	row := q.db.QueryRow(ctx, updateInvoiceStatusPartiallyPaidById, arg.ID, arg.ActualAmount)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.CryptoAddress,
+		&i.Coin,
+		&i.RequiredAmount,
+		&i.ActualAmount,
+		&i.ConfirmationsRequired,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.TxID,
+		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
+	)
+	return i, err
+}
@@ -93,13 +93,59 @@ func (ns NullCoinType) Value() (driver.Value, error) {
 	return string(ns.CoinType), nil
 }
 
+type InvoiceEventType string
+
+const (
+	InvoiceEventTypeSTATUSCHANGED        InvoiceEventType = "STATUS_CHANGED"
+	InvoiceEventTypeCONFIRMATIONPROGRESS InvoiceEventType = "CONFIRMATION_PROGRESS"
+	InvoiceEventTypeMEMPOOLDROPPED       InvoiceEventType = "MEMPOOL_DROPPED"
+)
+
+func (e *InvoiceEventType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = InvoiceEventType(s)
+	case string:
+		*e = InvoiceEventType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for InvoiceEventType: %T", src)
+	}
+	return nil
+}
+
+type NullInvoiceEventType struct {
+	InvoiceEventType InvoiceEventType
+	Valid            bool // Valid is true if InvoiceEventType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullInvoiceEventType) Scan(value interface{}) error {
+	if value == nil {
+		ns.InvoiceEventType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.InvoiceEventType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullInvoiceEventType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.InvoiceEventType), nil
+}
+
 type InvoiceStatusType string
 
 const (
-	InvoiceStatusTypePENDING        InvoiceStatusType = "PENDING"
-	InvoiceStatusTypePENDINGMEMPOOL InvoiceStatusType = "PENDING_MEMPOOL"
-	InvoiceStatusTypeEXPIRED        InvoiceStatusType = "EXPIRED"
-	InvoiceStatusTypeCONFIRMED      InvoiceStatusType = "CONFIRMED"
+	InvoiceStatusTypePENDING         InvoiceStatusType = "PENDING"
+	InvoiceStatusTypePENDINGMEMPOOL  InvoiceStatusType = "PENDING_MEMPOOL"
+	InvoiceStatusTypeEXPIRED         InvoiceStatusType = "EXPIRED"
+	InvoiceStatusTypeCONFIRMED       InvoiceStatusType = "CONFIRMED"
+	InvoiceStatusTypeCANCELLED       InvoiceStatusType = "CANCELLED"
+	InvoiceStatusTypePARTIALLYPAID   InvoiceStatusType = "PARTIALLY_PAID"
+	InvoiceStatusTypePAIDAFTEREXPIRY InvoiceStatusType = "PAID_AFTER_EXPIRY"
 )
 
 func (e *InvoiceStatusType) Scan(src interface{}) error {
@@ -137,6 +183,134 @@ func (ns NullInvoiceStatusType) Value() (driver.Value, error) {
 	return string(ns.InvoiceStatusType), nil
 }
 
+type PaymentOutcomeType string
+
+const (
+	PaymentOutcomeTypeOVERPAID  PaymentOutcomeType = "OVERPAID"
+	PaymentOutcomeTypeUNDERPAID PaymentOutcomeType = "UNDERPAID"
+)
+
+func (e *PaymentOutcomeType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentOutcomeType(s)
+	case string:
+		*e = PaymentOutcomeType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentOutcomeType: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentOutcomeType struct {
+	PaymentOutcomeType PaymentOutcomeType
+	Valid              bool // Valid is true if PaymentOutcomeType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentOutcomeType) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentOutcomeType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentOutcomeType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentOutcomeType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentOutcomeType), nil
+}
+
+type PaymentRequestStatusType string
+
+const (
+	PaymentRequestStatusTypePENDING PaymentRequestStatusType = "PENDING"
+	PaymentRequestStatusTypePAID    PaymentRequestStatusType = "PAID"
+	PaymentRequestStatusTypeEXPIRED PaymentRequestStatusType = "EXPIRED"
+)
+
+func (e *PaymentRequestStatusType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentRequestStatusType(s)
+	case string:
+		*e = PaymentRequestStatusType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentRequestStatusType: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentRequestStatusType struct {
+	PaymentRequestStatusType PaymentRequestStatusType
+	Valid                    bool // Valid is true if PaymentRequestStatusType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentRequestStatusType) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentRequestStatusType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentRequestStatusType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentRequestStatusType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentRequestStatusType), nil
+}
+
+type WebhookDeliveryStatusType string
+
+const (
+	WebhookDeliveryStatusTypePENDING   WebhookDeliveryStatusType = "PENDING"
+	WebhookDeliveryStatusTypeDELIVERED WebhookDeliveryStatusType = "DELIVERED"
+	WebhookDeliveryStatusTypeDEAD      WebhookDeliveryStatusType = "DEAD"
+)
+
+func (e *WebhookDeliveryStatusType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatusType(s)
+	case string:
+		*e = WebhookDeliveryStatusType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatusType: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatusType struct {
+	WebhookDeliveryStatusType WebhookDeliveryStatusType
+	Valid                     bool // Valid is true if WebhookDeliveryStatusType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatusType) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatusType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatusType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatusType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatusType), nil
+}
+
 type BnbCryptoDatum struct {
 	ID             pgtype.UUID
 	MasterPubKey   string
@@ -152,11 +326,18 @@ type BtcCryptoDatum struct {
 }
 
 type CryptoAddress struct {
-	ID         pgtype.UUID
-	Address    string
-	Coin       CoinType
-	IsOccupied bool
-	UserID     pgtype.UUID
+	ID          pgtype.UUID
+	Address     string
+	Coin        CoinType
+	IsOccupied  bool
+	UserID      pgtype.UUID
+	AvailableAt pgtype.Timestamptz
+}
+
+type CryptoBlockHash struct {
+	Coin   CoinType
+	Height int64
+	Hash   string
 }
 
 type CryptoCache struct {
@@ -181,19 +362,63 @@ type EthCryptoDatum struct {
 	LastMinorIndex int32
 }
 
+type EvmCryptoDatum struct {
+	UserID         pgtype.UUID
+	Chain          CoinType
+	MasterPubKey   string
+	LastMajorIndex int32
+	LastMinorIndex int32
+}
+
 type Invoice struct {
-	ID                    pgtype.UUID
-	CryptoAddress         string
-	Coin                  CoinType
-	RequiredAmount        float64
-	ActualAmount          pgtype.Float8
-	ConfirmationsRequired int16
-	CreatedAt             pgtype.Timestamptz
-	ConfirmedAt           pgtype.Timestamptz
-	Status                InvoiceStatusType
-	ExpiresAt             pgtype.Timestamptz
-	TxID                  pgtype.Text
-	UserID                pgtype.UUID
+	ID                     pgtype.UUID
+	CryptoAddress          string
+	Coin                   CoinType
+	RequiredAmount         pgtype.Numeric
+	ActualAmount           pgtype.Numeric
+	ConfirmationsRequired  int16
+	CreatedAt              pgtype.Timestamptz
+	ConfirmedAt            pgtype.Timestamptz
+	Status                 InvoiceStatusType
+	ExpiresAt              pgtype.Timestamptz
+	TxID                   pgtype.Text
+	UserID                 pgtype.UUID
+	IdempotencyKey         pgtype.Text
+	IdempotencyFingerprint pgtype.Text
+	ExternalID             pgtype.Text
+	Description            pgtype.Text
+	Metadata               []byte
+	FiatAmount             pgtype.Float8
+	FiatCurrency           pgtype.Text
+	ExchangeRate           pgtype.Float8
+	RateSource             pgtype.Text
+	TolerancePercent       pgtype.Float8
+	ToleranceAmount        pgtype.Numeric
+	PaymentOutcome         NullPaymentOutcomeType
+	PaymentOutcomeAmount   pgtype.Numeric
+	PaymentRequestID       pgtype.UUID
+	Confirmations          int32
+}
+
+type InvoiceEvent struct {
+	Sequence          int64
+	InvoiceID         pgtype.UUID
+	Status            InvoiceStatusType
+	Payload           []byte
+	CreatedAt         pgtype.Timestamptz
+	PublishedAt       pgtype.Timestamptz
+	Type              InvoiceEventType
+	PublishedSequence pgtype.Int8
+}
+
+type InvoicePayment struct {
+	ID            pgtype.UUID
+	InvoiceID     pgtype.UUID
+	TxID          string
+	Amount        pgtype.Numeric
+	Confirmations int64
+	BlockHeight   pgtype.Int8
+	CreatedAt     pgtype.Timestamptz
 }
 
 type LtcCryptoDatum struct {
@@ -203,8 +428,50 @@ type LtcCryptoDatum struct {
 	LastMinorIndex int32
 }
 
+type PaymentRequest struct {
+	ID            pgtype.UUID
+	UserID        pgtype.UUID
+	Status        PaymentRequestStatusType
+	PaidInvoiceID pgtype.UUID
+	CreatedAt     pgtype.Timestamptz
+	PaidAt        pgtype.Timestamptz
+}
+
+type Token struct {
+	Coin            CoinType
+	Symbol          string
+	Chain           CoinType
+	ChainID         int64
+	ContractAddress string
+	Decimals        int32
+	Enabled         bool
+}
+
 type User struct {
-	ID pgtype.UUID
+	ID               pgtype.UUID
+	TolerancePercent pgtype.Float8
+}
+
+type Webhook struct {
+	ID        pgtype.UUID
+	Url       string
+	Secret    string
+	CreatedAt pgtype.Timestamptz
+	UserID    pgtype.UUID
+}
+
+type WebhookDelivery struct {
+	ID               pgtype.UUID
+	WebhookID        pgtype.UUID
+	InvoiceID        pgtype.UUID
+	Payload          []byte
+	Status           WebhookDeliveryStatusType
+	Attempts         int32
+	NextAttemptAt    pgtype.Timestamptz
+	LastResponseCode pgtype.Int4
+	LastError        pgtype.Text
+	CreatedAt        pgtype.Timestamptz
+	DeliveredAt      pgtype.Timestamptz
 }
 
 type XmrCryptoDatum struct {
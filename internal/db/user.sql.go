@@ -34,6 +34,37 @@ func (q *Queries) CreateUserWithId(ctx context.Context, id pgtype.UUID) (pgtype.
 	return id, err
 }
 
+const findTolerancePercentByUserId = `-- name: FindTolerancePercentByUserId :one
+SELECT tolerance_percent FROM users
+WHERE id = $1
+`
+
+func (q *Queries) FindTolerancePercentByUserId(ctx context.Context, id pgtype.UUID) (pgtype.Float8, error) {
+	row := q.db.QueryRow(ctx, findTolerancePercentByUserId, id)
+	var tolerance_percent pgtype.Float8
+	err := row.Scan(&tolerance_percent)
+	return tolerance_percent, err
+}
+
+const updateTolerancePercentByUserId = `-- name: UpdateTolerancePercentByUserId :one
+UPDATE users
+SET tolerance_percent = $2
+WHERE id = $1
+RETURNING tolerance_percent
+`
+
+type UpdateTolerancePercentByUserIdParams struct {
+	ID               pgtype.UUID
+	TolerancePercent pgtype.Float8
+}
+
+func (q *Queries) UpdateTolerancePercentByUserId(ctx context.Context, arg UpdateTolerancePercentByUserIdParams) (pgtype.Float8, error) {
+	row := q.db.QueryRow(ctx, updateTolerancePercentByUserId, arg.ID, arg.TolerancePercent)
+	var tolerance_percent pgtype.Float8
+	err := row.Scan(&tolerance_percent)
+	return tolerance_percent, err
+}
+
 const userExistsById = `-- name: UserExistsById :one
 SELECT EXISTS (
     SELECT 1
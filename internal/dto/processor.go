@@ -1,19 +1,93 @@
 package dto
 
-import "github.com/chekist32/goipay/internal/db"
+import (
+	"math/big"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/rate"
+)
 
 type NewInvoiceRequest struct {
 	UserId        string
 	Coin          db.CoinType
-	Amount        float64
+	Amount        *big.Int
 	Timeout       uint64
 	Confirmations uint32
+
+	IdempotencyKey string
+
+	ExternalId  string
+	Description string
+	Metadata    map[string]string
+
+	FiatAmount   float64
+	FiatCurrency string
+
+	// Tolerance overrides the user's default tolerance if set.
+	Tolerance *PaymentTolerance
+
+	// Conversion is filled in by the payment processor for fiat-denominated invoices.
+	Conversion *FiatConversion
+
+	// PaymentRequestId links the invoice to the payment request it's a coin option of.
+	PaymentRequestId string
+}
+
+// NewPaymentRequest reserves an invoice in each coin. Coins without an amount are converted from the fiat amount.
+type NewPaymentRequest struct {
+	UserId        string
+	Coins         []PaymentRequestCoin
+	Timeout       uint64
+	Confirmations uint32
+
+	ExternalId  string
+	Description string
+	Metadata    map[string]string
+
+	FiatAmount   float64
+	FiatCurrency string
+}
+
+type PaymentRequestCoin struct {
+	Coin   db.CoinType
+	Amount *big.Int
+}
+
+// PaymentTolerance is the shortfall which is still accepted as a full payment.
+// Amount (in atomic units) takes precedence over Percent.
+type PaymentTolerance struct {
+	Percent float64
+	Amount  *big.Int
+}
+
+type FiatConversion struct {
+	CoinAmount *big.Int
+	Rate       rate.Rate
+}
+
+type InvoiceEvent struct {
+	// Sequence is assigned when the event is published, so it follows the commit order and is the cursor of the streams.
+	Sequence int64
+	Type     db.InvoiceEventType
+	Invoice  db.Invoice
+}
+
+type InvoiceConfig struct {
+	// LatePaymentWindow is how long the address of an expired invoice is still watched for payments.
+	LatePaymentWindow time.Duration
+	// AddressReuseCooldown is how long a released address isn't handed to another invoice.
+	AddressReuseCooldown time.Duration
 }
 
 type DaemonConfig struct {
 	Url  string
 	User string
 	Pass string
+
+	// ZmqPubRawTx and ZmqPubHashBlock are the optional ZMQ endpoints of the BTC and LTC daemons.
+	ZmqPubRawTx     string
+	ZmqPubHashBlock string
 }
 type XMRDaemonConfig DaemonConfig
 type BTCDaemonConfig DaemonConfig
@@ -21,10 +95,34 @@ type LTCDaemonConfig DaemonConfig
 type ETHDaemonConfig DaemonConfig
 type BNBDaemonConfig ETHDaemonConfig
 
+// TokenConfig declares a token of the chain with the given id. It's upserted into the token registry at startup.
+type TokenConfig struct {
+	Coin            db.CoinType
+	Symbol          string
+	Chain           db.CoinType
+	ChainId         uint64
+	ContractAddress string
+	Decimals        int32
+	Enabled         bool
+}
+
+// EVMChainConfig declares an EVM chain whose native coin and tokens are accepted the same way as on ETH.
+// The coin is the identifier of the chain used by the API and the invoices, e.g. POLYGON.
+type EVMChainConfig struct {
+	Coin      db.CoinType
+	Symbol    string
+	ChainId   uint64
+	BlockTime time.Duration
+	Url       string
+}
+
 type DaemonsConfig struct {
 	Xmr XMRDaemonConfig
 	Btc BTCDaemonConfig
 	Ltc LTCDaemonConfig
 	Eth ETHDaemonConfig
 	Bnb BNBDaemonConfig
+	Evm []EVMChainConfig
+
+	Tokens []TokenConfig
 }
@@ -34,3 +34,7 @@ func checkIfUserExistsUUID(ctx context.Context, log *zerolog.Logger, q *db.Queri
 
 	return nil
 }
+
+func isValidTolerancePercent(percent float64) bool {
+	return percent >= 0 && percent < util.TOLERANCE_PERCENT_MAX
+}
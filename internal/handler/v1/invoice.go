@@ -2,16 +2,95 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"math"
 
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
 	"github.com/chekist32/goipay/internal/processor"
+	"github.com/chekist32/goipay/internal/qr"
+	"github.com/chekist32/goipay/internal/rate"
 	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+type invoiceStatusStreamFilter struct {
+	userIds    map[pgtype.UUID]bool
+	invoiceIds map[pgtype.UUID]bool
+	coins      map[db.CoinType]bool
+	statuses   map[db.InvoiceStatusType]bool
+}
+
+func (f *invoiceStatusStreamFilter) matches(invoice *db.Invoice) bool {
+	if len(f.userIds) > 0 && !f.userIds[invoice.UserID] {
+		return false
+	}
+	if len(f.invoiceIds) > 0 && !f.invoiceIds[invoice.ID] {
+		return false
+	}
+	if len(f.coins) > 0 && !f.coins[invoice.Coin] {
+		return false
+	}
+	if len(f.statuses) > 0 && !f.statuses[invoice.Status] {
+		return false
+	}
+
+	return true
+}
+
+func newInvoiceStatusStreamFilter(req *pb_v1.InvoiceStatusStreamRequest) (*invoiceStatusStreamFilter, error) {
+	f := &invoiceStatusStreamFilter{
+		userIds:    make(map[pgtype.UUID]bool, len(req.UserIds)),
+		invoiceIds: make(map[pgtype.UUID]bool, len(req.InvoiceIds)),
+		coins:      make(map[db.CoinType]bool, len(req.Coins)+len(req.CoinIds)),
+		statuses:   make(map[db.InvoiceStatusType]bool, len(req.Statuses)),
+	}
+
+	for i := 0; i < len(req.UserIds); i++ {
+		userId, err := util.StringToPgUUID(req.UserIds[i])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+		}
+		f.userIds[*userId] = true
+	}
+	for i := 0; i < len(req.InvoiceIds); i++ {
+		invoiceId, err := util.StringToPgUUID(req.InvoiceIds[i])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg)
+		}
+		f.invoiceIds[*invoiceId] = true
+	}
+	for i := 0; i < len(req.Coins); i++ {
+		coin, err := util.PbCoinToDbCoin(req.Coins[i])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+		}
+		f.coins[coin] = true
+	}
+	for i := 0; i < len(req.CoinIds); i++ {
+		coin, err := util.PbCoinIdToDbCoin(0, &req.CoinIds[i])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+		}
+		f.coins[coin] = true
+	}
+	for i := 0; i < len(req.Statuses); i++ {
+		invoiceStatus, err := util.PbInvoiceStatusToDbInvoiceStatus(req.Statuses[i])
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceStatusTypeMsg)
+		}
+		f.statuses[invoiceStatus] = true
+	}
+
+	return f, nil
+}
+
 type InvoiceGrpc struct {
 	dbConnPool       *pgxpool.Pool
 	log              *zerolog.Logger
@@ -19,6 +98,68 @@ type InvoiceGrpc struct {
 	pb_v1.UnimplementedInvoiceServiceServer
 }
 
+func validateMerchantMetadata(req *pb_v1.CreateInvoiceRequest) error {
+	if len(req.GetExternalId()) > util.EXTERNAL_ID_MAX_LENGTH {
+		return status.Error(codes.InvalidArgument, util.InvalidExternalIdMsg)
+	}
+	if len(req.GetDescription()) > util.DESCRIPTION_MAX_LENGTH {
+		return status.Error(codes.InvalidArgument, util.InvalidDescriptionMsg)
+	}
+
+	if len(req.Metadata) > util.METADATA_MAX_ENTRIES {
+		return status.Error(codes.InvalidArgument, util.InvalidMetadataMsg)
+	}
+	for k, v := range req.Metadata {
+		if k == "" || len(k) > util.METADATA_KEY_MAX_LENGTH || len(v) > util.METADATA_VALUE_MAX_LENGTH {
+			return status.Error(codes.InvalidArgument, util.InvalidMetadataMsg)
+		}
+	}
+
+	return nil
+}
+
+func validateInvoiceAmount(req *pb_v1.CreateInvoiceRequest) error {
+	if req.Fiat == nil {
+		amount, err := util.StringToBigInt(req.Amount)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, util.InvalidInvoiceAmountMsg)
+		}
+		if amount.Sign() < 0 {
+			return status.Error(codes.InvalidArgument, util.InvoiceAmountBelow0ErrorMsg)
+		}
+
+		return nil
+	}
+
+	if req.Amount != "" {
+		return status.Error(codes.InvalidArgument, util.InvoiceAmountWithFiatMsg)
+	}
+	if req.Fiat.Amount <= 0 {
+		return status.Error(codes.InvalidArgument, util.InvalidFiatAmountMsg)
+	}
+	if rate.NormalizeCurrency(req.Fiat.Currency) == "" {
+		return status.Error(codes.InvalidArgument, util.InvalidFiatCurrencyMsg)
+	}
+
+	return nil
+}
+
+func validatePaymentTolerance(tolerance *pb_v1.PaymentTolerance) error {
+	switch v := tolerance.GetValue().(type) {
+	case *pb_v1.PaymentTolerance_Percent:
+		if !isValidTolerancePercent(v.Percent) {
+			return status.Error(codes.InvalidArgument, util.InvalidTolerancePercentMsg)
+		}
+	case *pb_v1.PaymentTolerance_Amount:
+		amount, err := util.StringToBigInt(v.Amount)
+		if err != nil || amount.Sign() < 0 {
+			return status.Error(codes.InvalidArgument, util.InvalidToleranceAmountMsg)
+		}
+	}
+
+	return nil
+}
+
 func (i *InvoiceGrpc) CreateInvoice(ctx context.Context, req *pb_v1.CreateInvoiceRequest) (*pb_v1.CreateInvoiceResponse, error) {
 	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
 	if err != nil {
@@ -27,33 +168,359 @@ func (i *InvoiceGrpc) CreateInvoice(ctx context.Context, req *pb_v1.CreateInvoic
 	}
 	defer tx.Rollback(ctx)
 
-	if req.Amount < 0 {
-		return nil, status.Error(codes.InvalidArgument, util.InvoiceAmountBelow0ErrorMsg)
+	if err := validateInvoiceAmount(req); err != nil {
+		return nil, err
+	}
+	if _, err := util.PbCoinIdToDbCoin(req.Coin, req.CoinId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
 	}
 	if err := checkIfUserExistsString(ctx, i.log, q, req.UserId); err != nil {
 		return nil, err
 	}
 
+	if len(req.GetIdempotencyKey()) > util.IDEMPOTENCY_KEY_MAX_LENGTH {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidIdempotencyKeyMsg)
+	}
+	if err := validateMerchantMetadata(req); err != nil {
+		return nil, err
+	}
+	if err := validatePaymentTolerance(req.Tolerance); err != nil {
+		return nil, err
+	}
+
 	invoice, err := i.paymentProcessor.HandleNewInvoice(util.PbNewInvoiceToProcessorNewInvoice(req))
 	if err != nil {
+		if errors.Is(err, processor.IdempotencyKeyConflictErr) {
+			return nil, status.Error(codes.AlreadyExists, util.IdempotencyKeyConflictMsg)
+		}
+		if errors.Is(err, processor.RateProviderNotConfiguredErr) {
+			return nil, status.Error(codes.FailedPrecondition, util.RateProviderNotConfiguredMsg)
+		}
+		if errors.Is(err, rate.UnsupportedPairErr) {
+			return nil, status.Error(codes.InvalidArgument, util.UnsupportedFiatCurrencyMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.InvoiceErrorWhileHandlingMsg)
+		return nil, status.Error(codes.Internal, util.InvoiceErrorWhileHandlingMsg)
+	}
+
+	// The invoice is created anyway, so a missing URI is not an error for the client.
+	paymentUri, err := i.paymentProcessor.PaymentUri(invoice)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("An error occurred while building the payment URI.")
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.CreateInvoiceResponse{PaymentId: util.PgUUIDToString(invoice.ID), Address: invoice.CryptoAddress, PaymentUri: paymentUri}, nil
+}
+
+func (i *InvoiceGrpc) findInvoiceByIdString(ctx context.Context, q *db.Queries, id string) (*db.Invoice, error) {
+	invoiceId, err := util.StringToPgUUID(id)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceIdInvalidUUIDMsg)
+	}
+
+	invoice, err := q.FindInvoiceById(ctx, *invoiceId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.InvalidInvoiceIdInvoiceDoesNotExistMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return &invoice, nil
+}
+
+func (i *InvoiceGrpc) GetInvoice(ctx context.Context, req *pb_v1.GetInvoiceRequest) (*pb_v1.GetInvoiceResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	invoice, err := i.findInvoiceByIdString(ctx, q, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.GetInvoiceResponse{Invoice: util.DbInvoiceToPbInvoice(invoice)}, nil
+}
+
+type qrCodeOptions struct {
+	format qr.Format
+	level  qr.Level
+	size   int
+}
+
+func newQrCodeOptions(req *pb_v1.GetInvoiceQrCodeRequest) (*qrCodeOptions, error) {
+	format, err := util.PbQrCodeFormatToQrFormat(req.Format)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidQrCodeFormatMsg)
+	}
+
+	level, err := util.PbQrCodeErrorCorrectionToQrLevel(req.ErrorCorrection)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidQrCodeErrorCorrectionMsg)
+	}
+
+	size := util.QR_CODE_DEFAULT_SIZE
+	if req.Size != nil {
+		size = req.GetSize()
+	}
+	if size < util.QR_CODE_MIN_SIZE || size > util.QR_CODE_MAX_SIZE {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidQrCodeSizeMsg)
+	}
+
+	return &qrCodeOptions{format: format, level: level, size: int(size)}, nil
+}
+
+func (i *InvoiceGrpc) GetInvoiceQrCode(ctx context.Context, req *pb_v1.GetInvoiceQrCodeRequest) (*pb_v1.GetInvoiceQrCodeResponse, error) {
+	opts, err := newQrCodeOptions(req)
+	if err != nil {
+		return nil, err
+	}
+
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	invoice, err := i.findInvoiceByIdString(ctx, q, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Commit(ctx)
+
+	paymentUri, err := i.paymentProcessor.PaymentUri(invoice)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.QrCodeRenderingErrorMsg)
+		return nil, status.Error(codes.Internal, util.QrCodeRenderingErrorMsg)
+	}
+
+	image, err := qr.Render(paymentUri, opts.format, opts.size, opts.level)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.QrCodeRenderingErrorMsg)
+		return nil, status.Error(codes.Internal, util.QrCodeRenderingErrorMsg)
+	}
+
+	return &pb_v1.GetInvoiceQrCodeResponse{Image: image, ContentType: qr.ContentType(opts.format), PaymentUri: paymentUri}, nil
+}
+
+func (i *InvoiceGrpc) CancelInvoice(ctx context.Context, req *pb_v1.CancelInvoiceRequest) (*pb_v1.CancelInvoiceResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	invoice, err := i.findInvoiceByIdString(ctx, q, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.Commit(ctx)
+
+	if invoice.Status != db.InvoiceStatusTypePENDING {
+		return nil, status.Error(codes.FailedPrecondition, util.InvoiceNotCancellableMsg)
+	}
+
+	cancelledInvoice, err := i.paymentProcessor.CancelInvoice(invoice)
+	if err != nil {
+		if errors.Is(err, processor.InvoiceNotCancellableErr) {
+			return nil, status.Error(codes.FailedPrecondition, util.InvoiceNotCancellableMsg)
+		}
+
 		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.InvoiceErrorWhileHandlingMsg)
 		return nil, status.Error(codes.Internal, util.InvoiceErrorWhileHandlingMsg)
 	}
 
+	return &pb_v1.CancelInvoiceResponse{Invoice: util.DbInvoiceToPbInvoice(cancelledInvoice)}, nil
+}
+
+func (i *InvoiceGrpc) listInvoicesReqToParams(ctx context.Context, req *pb_v1.ListInvoicesRequest) (*db.FindInvoicesFilteredParams, error) {
+	params := &db.FindInvoicesFilteredParams{
+		CreatedFrom: util.PbTimestampToPgTimestamptz(req.CreatedFrom),
+		CreatedTo:   util.PbTimestampToPgTimestamptz(req.CreatedTo),
+		ExpiresFrom: util.PbTimestampToPgTimestamptz(req.ExpiresFrom),
+		ExpiresTo:   util.PbTimestampToPgTimestamptz(req.ExpiresTo),
+	}
+
+	if req.UserId != nil {
+		userId, err := util.StringToPgUUID(*req.UserId)
+		if err != nil {
+			i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+			return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+		}
+		params.UserID = *userId
+	}
+	if req.Coin != nil || req.CoinId != nil {
+		coin, err := util.PbCoinIdToDbCoin(req.GetCoin(), req.CoinId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+		}
+		params.Coin = pgtype.Text{String: string(coin), Valid: true}
+	}
+	if req.Status != nil {
+		invoiceStatus, err := util.PbInvoiceStatusToDbInvoiceStatus(*req.Status)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidInvoiceStatusTypeMsg)
+		}
+		params.Status = db.NullInvoiceStatusType{InvoiceStatusType: invoiceStatus, Valid: true}
+	}
+	if req.TxId != nil {
+		params.TxID = pgtype.Text{String: *req.TxId, Valid: true}
+	}
+	if req.ExternalId != nil {
+		params.ExternalID = pgtype.Text{String: *req.ExternalId, Valid: true}
+	}
+	if req.MetadataKey != nil {
+		params.MetadataKey = pgtype.Text{String: *req.MetadataKey, Valid: true}
+	}
+	if req.MetadataValue != nil {
+		if req.MetadataKey == nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidMetadataValueFilterMsg)
+		}
+		params.MetadataValue = pgtype.Text{String: *req.MetadataValue, Valid: true}
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = util.LIST_INVOICES_DEFAULT_LIMIT
+	}
+	if limit > util.LIST_INVOICES_MAX_LIMIT {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidListInvoicesLimitMsg)
+	}
+	if req.Offset > math.MaxInt32 {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidListInvoicesOffsetMsg)
+	}
+	params.Limit = int32(limit)
+	params.Offset = int32(req.Offset)
+
+	return params, nil
+}
+
+func (i *InvoiceGrpc) ListInvoices(ctx context.Context, req *pb_v1.ListInvoicesRequest) (*pb_v1.ListInvoicesResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	params, err := i.listInvoicesReqToParams(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices, err := q.FindInvoicesFiltered(ctx, *params)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoicesFiltered").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
 	tx.Commit(ctx)
 
-	return &pb_v1.CreateInvoiceResponse{PaymentId: util.PgUUIDToString(invoice.ID), Address: invoice.CryptoAddress}, nil
+	res := &pb_v1.ListInvoicesResponse{Invoices: make([]*pb_v1.Invoice, 0, len(invoices))}
+	for j := 0; j < len(invoices); j++ {
+		res.Invoices = append(res.Invoices, util.DbInvoiceToPbInvoice(&invoices[j]))
+	}
+
+	return res, nil
+}
+
+func (i *InvoiceGrpc) sendInvoiceEvent(stream pb_v1.InvoiceService_InvoiceStatusStreamServer, filter *invoiceStatusStreamFilter, event *dto.InvoiceEvent) error {
+	if !filter.matches(&event.Invoice) {
+		return nil
+	}
+
+	if err := stream.Send(util.DtoInvoiceEventToPbInvoiceStatusStreamResponse(event)); err != nil {
+		i.log.Err(err).Msg(util.InvoiceStreamSendingDataErrorMsg)
+		return status.Error(codes.Canceled, util.InvoiceStreamSendingDataErrorMsg)
+	}
+
+	return nil
+}
+
+// replayInvoiceEvents sends all the published events after fromSequence and returns the sequence of the last one.
+func (i *InvoiceGrpc) replayInvoiceEvents(stream pb_v1.InvoiceService_InvoiceStatusStreamServer, filter *invoiceStatusStreamFilter, fromSequence int64) (int64, error) {
+	ctx := stream.Context()
+	q := db.New(i.dbConnPool)
+
+	lastSequence := fromSequence
+	for {
+		events, err := q.FindInvoiceEventsAfterSequence(ctx, db.FindInvoiceEventsAfterSequenceParams{Sequence: lastSequence, Limit: util.INVOICE_EVENTS_REPLAY_BATCH_SIZE})
+		if err != nil {
+			i.log.Err(err).Str("queryName", "FindInvoiceEventsAfterSequence").Msg(util.DefaultFailedSqlQueryMsg)
+			return 0, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+		}
+		if len(events) == 0 {
+			return lastSequence, nil
+		}
+
+		for j := 0; j < len(events); j++ {
+			event, err := util.DbInvoiceEventToDtoInvoiceEvent(&events[j])
+			if err != nil {
+				i.log.Err(err).Int64("sequence", events[j].Sequence).Msg(util.InvoiceEventDeserializationErrorMsg)
+				return 0, status.Error(codes.Internal, util.InvoiceEventDeserializationErrorMsg)
+			}
+
+			if err := i.sendInvoiceEvent(stream, filter, event); err != nil {
+				return 0, err
+			}
+			lastSequence = event.Sequence
+		}
+	}
 }
 
 func (i *InvoiceGrpc) InvoiceStatusStream(req *pb_v1.InvoiceStatusStreamRequest, stream pb_v1.InvoiceService_InvoiceStatusStreamServer) error {
-	invoiceCn := i.paymentProcessor.NewInvoicesChan()
+	filter, err := newInvoiceStatusStreamFilter(req)
+	if err != nil {
+		return err
+	}
+
+	lastSequence := int64(-1)
+	if req.FromSequence != nil {
+		if *req.FromSequence > math.MaxInt64 {
+			return status.Error(codes.InvalidArgument, util.InvalidFromSequenceMsg)
+		}
+
+		if lastSequence, err = i.replayInvoiceEvents(stream, filter, int64(*req.FromSequence)); err != nil {
+			return err
+		}
+	}
+
+	invoiceCn, unsubscribe := i.paymentProcessor.NewInvoicesChan()
+	defer unsubscribe()
+
+	// Catch up on the events persisted between the replay and the subscription.
+	if req.FromSequence != nil {
+		if lastSequence, err = i.replayInvoiceEvents(stream, filter, lastSequence); err != nil {
+			return err
+		}
+	}
 
 	for {
 		select {
-		case invoice := <-invoiceCn:
-			if err := stream.Send(&pb_v1.InvoiceStatusStreamResponse{Invoice: util.DbInvoiceToPbInvoice(&invoice)}); err != nil {
-				i.log.Err(err).Msg(util.InvoiceStreamSendingDataErrorMsg)
-				return status.Error(codes.Canceled, util.InvoiceStreamSendingDataErrorMsg)
+		case event, ok := <-invoiceCn:
+			if !ok {
+				return status.Error(codes.Unavailable, util.InvoiceStreamLaggedErrorMsg)
+			}
+			if event.Sequence <= lastSequence {
+				continue
+			}
+			if err := i.sendInvoiceEvent(stream, filter, &event); err != nil {
+				return err
 			}
 		case <-stream.Context().Done():
 			return status.Error(codes.Canceled, util.InvoiceStreamClosedErrorMsg)
@@ -62,6 +529,17 @@ func (i *InvoiceGrpc) InvoiceStatusStream(req *pb_v1.InvoiceStatusStreamRequest,
 
 }
 
+func (i *InvoiceGrpc) ListTokens(ctx context.Context, req *pb_v1.ListTokensRequest) (*pb_v1.ListTokensResponse, error) {
+	tokens := i.paymentProcessor.Tokens()
+
+	res := &pb_v1.ListTokensResponse{Tokens: make([]*pb_v1.Token, 0, len(tokens))}
+	for j := 0; j < len(tokens); j++ {
+		res.Tokens = append(res.Tokens, util.DbTokenToPbToken(&tokens[j]))
+	}
+
+	return res, nil
+}
+
 func NewInvoiceGrpc(dbConnPool *pgxpool.Pool, paymentProcessor *processor.PaymentProcessor, log *zerolog.Logger) *InvoiceGrpc {
 	return &InvoiceGrpc{dbConnPool: dbConnPool, paymentProcessor: paymentProcessor, log: log}
 }
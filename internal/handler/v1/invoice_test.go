@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"log"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/chekist32/goipay/internal/qr"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewInvoiceStatusStreamFilter(t *testing.T) {
+	t.Run("Should Return Error (invalid userId)", func(t *testing.T) {
+		_, err := newInvoiceStatusStreamFilter(&pb_v1.InvoiceStatusStreamRequest{UserIds: []string{"invalid"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Should Return Error (invalid invoiceId)", func(t *testing.T) {
+		_, err := newInvoiceStatusStreamFilter(&pb_v1.InvoiceStatusStreamRequest{InvoiceIds: []string{"invalid"}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Should Return Error (invalid coin)", func(t *testing.T) {
+		_, err := newInvoiceStatusStreamFilter(&pb_v1.InvoiceStatusStreamRequest{Coins: []pb_v1.CoinType{math.MaxInt32}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Should Return Error (invalid status)", func(t *testing.T) {
+		_, err := newInvoiceStatusStreamFilter(&pb_v1.InvoiceStatusStreamRequest{Statuses: []pb_v1.InvoiceStatusType{math.MaxInt32}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestInvoiceStatusStreamFilterMatches(t *testing.T) {
+	userId, err := util.StringToPgUUID(uuid.NewString())
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoiceId, err := util.StringToPgUUID(uuid.NewString())
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoice := db.Invoice{ID: *invoiceId, UserID: *userId, Coin: db.CoinTypeBTC, Status: db.InvoiceStatusTypeCONFIRMED}
+
+	t.Run("Should Match Everything (empty filter)", func(t *testing.T) {
+		f, err := newInvoiceStatusStreamFilter(&pb_v1.InvoiceStatusStreamRequest{})
+		assert.NoError(t, err)
+		assert.True(t, f.matches(&invoice))
+	})
+
+	t.Run("Should Match", func(t *testing.T) {
+		f, err := newInvoiceStatusStreamFilter(&pb_v1.InvoiceStatusStreamRequest{
+			UserIds:    []string{util.PgUUIDToString(*userId), uuid.NewString()},
+			InvoiceIds: []string{util.PgUUIDToString(*invoiceId)},
+			Coins:      []pb_v1.CoinType{pb_v1.CoinType_BTC, pb_v1.CoinType_XMR},
+			Statuses:   []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_CONFIRMED},
+		})
+		assert.NoError(t, err)
+		assert.True(t, f.matches(&invoice))
+	})
+
+	t.Run("Should Not Match", func(t *testing.T) {
+		reqs := []*pb_v1.InvoiceStatusStreamRequest{
+			{UserIds: []string{uuid.NewString()}},
+			{InvoiceIds: []string{uuid.NewString()}},
+			{Coins: []pb_v1.CoinType{pb_v1.CoinType_XMR}},
+			{Statuses: []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_PENDING, pb_v1.InvoiceStatusType_EXPIRED}},
+		}
+
+		for i := 0; i < len(reqs); i++ {
+			f, err := newInvoiceStatusStreamFilter(reqs[i])
+			assert.NoError(t, err)
+			assert.False(t, f.matches(&invoice))
+		}
+	})
+}
+
+func TestValidateMerchantMetadata(t *testing.T) {
+	t.Run("Should Accept Valid Metadata", func(t *testing.T) {
+		externalId := "order-1"
+		err := validateMerchantMetadata(&pb_v1.CreateInvoiceRequest{ExternalId: &externalId, Metadata: map[string]string{"customer": "42"}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should Return Error (too long externalId)", func(t *testing.T) {
+		externalId := strings.Repeat("a", util.EXTERNAL_ID_MAX_LENGTH+1)
+		err := validateMerchantMetadata(&pb_v1.CreateInvoiceRequest{ExternalId: &externalId})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Should Return Error (too long description)", func(t *testing.T) {
+		description := strings.Repeat("a", util.DESCRIPTION_MAX_LENGTH+1)
+		err := validateMerchantMetadata(&pb_v1.CreateInvoiceRequest{Description: &description})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Should Return Error (invalid metadata)", func(t *testing.T) {
+		tooManyEntries := make(map[string]string)
+		for i := 0; i <= util.METADATA_MAX_ENTRIES; i++ {
+			tooManyEntries[uuid.NewString()] = ""
+		}
+
+		metadatas := []map[string]string{
+			{"": "value"},
+			{strings.Repeat("a", util.METADATA_KEY_MAX_LENGTH+1): "value"},
+			{"key": strings.Repeat("a", util.METADATA_VALUE_MAX_LENGTH+1)},
+			tooManyEntries,
+		}
+		for i := 0; i < len(metadatas); i++ {
+			err := validateMerchantMetadata(&pb_v1.CreateInvoiceRequest{Metadata: metadatas[i]})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func TestValidateInvoiceAmount(t *testing.T) {
+	t.Run("Should Accept Valid Amount", func(t *testing.T) {
+		assert.NoError(t, validateInvoiceAmount(&pb_v1.CreateInvoiceRequest{Amount: "1"}))
+		// 100 ETH in wei doesn't fit into uint64
+		assert.NoError(t, validateInvoiceAmount(&pb_v1.CreateInvoiceRequest{Amount: "100000000000000000000"}))
+	})
+
+	t.Run("Should Accept Valid Fiat Amount", func(t *testing.T) {
+		assert.NoError(t, validateInvoiceAmount(&pb_v1.CreateInvoiceRequest{Fiat: &pb_v1.FiatAmount{Amount: 25, Currency: "usd"}}))
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		reqs := []*pb_v1.CreateInvoiceRequest{
+			{Amount: ""},
+			{Amount: "0.5"},
+			{Amount: "-1"},
+			{Amount: "1", Fiat: &pb_v1.FiatAmount{Amount: 25, Currency: "USD"}},
+			{Fiat: &pb_v1.FiatAmount{Amount: 0, Currency: "USD"}},
+			{Fiat: &pb_v1.FiatAmount{Amount: 25, Currency: " "}},
+		}
+
+		for i := 0; i < len(reqs); i++ {
+			assert.Equal(t, codes.InvalidArgument, status.Code(validateInvoiceAmount(reqs[i])))
+		}
+	})
+}
+
+func TestNewQrCodeOptions(t *testing.T) {
+	t.Run("Should Return Default Options", func(t *testing.T) {
+		opts, err := newQrCodeOptions(&pb_v1.GetInvoiceQrCodeRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, &qrCodeOptions{format: qr.PNG, level: qr.MediumLevel, size: int(util.QR_CODE_DEFAULT_SIZE)}, opts)
+	})
+
+	t.Run("Should Return Requested Options", func(t *testing.T) {
+		size := uint32(512)
+		opts, err := newQrCodeOptions(&pb_v1.GetInvoiceQrCodeRequest{Format: pb_v1.QrCodeFormat_SVG, Size: &size, ErrorCorrection: pb_v1.QrCodeErrorCorrection_HIGH})
+		assert.NoError(t, err)
+		assert.Equal(t, &qrCodeOptions{format: qr.SVG, level: qr.HighLevel, size: 512}, opts)
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		tooSmall := util.QR_CODE_MIN_SIZE - 1
+		tooLarge := util.QR_CODE_MAX_SIZE + 1
+		reqs := []*pb_v1.GetInvoiceQrCodeRequest{
+			{Format: math.MaxInt32},
+			{ErrorCorrection: math.MaxInt32},
+			{Size: &tooSmall},
+			{Size: &tooLarge},
+		}
+
+		for i := 0; i < len(reqs); i++ {
+			_, err := newQrCodeOptions(reqs[i])
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+func TestValidatePaymentTolerance(t *testing.T) {
+	t.Run("Should Accept Valid Tolerance", func(t *testing.T) {
+		tolerances := []*pb_v1.PaymentTolerance{
+			nil,
+			{Value: &pb_v1.PaymentTolerance_Percent{Percent: 0.5}},
+			{Value: &pb_v1.PaymentTolerance_Amount{Amount: "1000"}},
+		}
+
+		for i := 0; i < len(tolerances); i++ {
+			assert.NoError(t, validatePaymentTolerance(tolerances[i]))
+		}
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		tolerances := []*pb_v1.PaymentTolerance{
+			{Value: &pb_v1.PaymentTolerance_Percent{Percent: -1}},
+			{Value: &pb_v1.PaymentTolerance_Percent{Percent: util.TOLERANCE_PERCENT_MAX}},
+			{Value: &pb_v1.PaymentTolerance_Amount{Amount: "-1"}},
+			{Value: &pb_v1.PaymentTolerance_Amount{Amount: "0.5"}},
+		}
+
+		for i := 0; i < len(tolerances); i++ {
+			assert.Equal(t, codes.InvalidArgument, status.Code(validatePaymentTolerance(tolerances[i])))
+		}
+	})
+}
@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chekist32/goipay/internal/db"
+	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/chekist32/goipay/internal/processor"
+	"github.com/chekist32/goipay/internal/rate"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func validatePaymentRequestCoins(req *pb_v1.CreatePaymentRequestRequest) error {
+	if len(req.Coins) == 0 || len(req.Coins) > util.PAYMENT_REQUEST_MAX_COINS {
+		return status.Error(codes.InvalidArgument, util.InvalidPaymentRequestCoinsMsg)
+	}
+
+	if req.Fiat != nil {
+		if req.Fiat.Amount <= 0 {
+			return status.Error(codes.InvalidArgument, util.InvalidFiatAmountMsg)
+		}
+		if rate.NormalizeCurrency(req.Fiat.Currency) == "" {
+			return status.Error(codes.InvalidArgument, util.InvalidFiatCurrencyMsg)
+		}
+	}
+
+	coins := make(map[db.CoinType]bool, len(req.Coins))
+	for i := 0; i < len(req.Coins); i++ {
+		coin, err := util.PbCoinIdToDbCoin(req.Coins[i].Coin, req.Coins[i].CoinId)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, util.InvalidCoinTypeMsg)
+		}
+		if coins[coin] {
+			return status.Error(codes.InvalidArgument, util.InvalidPaymentRequestCoinsMsg)
+		}
+		coins[coin] = true
+
+		if req.Coins[i].Amount == nil {
+			if req.Fiat == nil {
+				return status.Error(codes.InvalidArgument, util.PaymentRequestCoinAmountMissingMsg)
+			}
+			continue
+		}
+
+		amount, err := util.StringToBigInt(req.Coins[i].GetAmount())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, util.InvalidInvoiceAmountMsg)
+		}
+		if amount.Sign() < 0 {
+			return status.Error(codes.InvalidArgument, util.InvoiceAmountBelow0ErrorMsg)
+		}
+	}
+
+	return nil
+}
+
+func (i *InvoiceGrpc) CreatePaymentRequest(ctx context.Context, req *pb_v1.CreatePaymentRequestRequest) (*pb_v1.CreatePaymentRequestResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := validatePaymentRequestCoins(req); err != nil {
+		return nil, err
+	}
+	if err := checkIfUserExistsString(ctx, i.log, q, req.UserId); err != nil {
+		return nil, err
+	}
+	if err := validateMerchantMetadata(&pb_v1.CreateInvoiceRequest{ExternalId: req.ExternalId, Description: req.Description, Metadata: req.Metadata}); err != nil {
+		return nil, err
+	}
+
+	tx.Commit(ctx)
+
+	paymentRequest, invoices, err := i.paymentProcessor.HandleNewPaymentRequest(util.PbNewPaymentRequestToProcessorNewPaymentRequest(req))
+	if err != nil {
+		if errors.Is(err, processor.RateProviderNotConfiguredErr) {
+			return nil, status.Error(codes.FailedPrecondition, util.RateProviderNotConfiguredMsg)
+		}
+		if errors.Is(err, rate.UnsupportedPairErr) {
+			return nil, status.Error(codes.InvalidArgument, util.UnsupportedFiatCurrencyMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.InvoiceErrorWhileHandlingMsg)
+		return nil, status.Error(codes.Internal, util.InvoiceErrorWhileHandlingMsg)
+	}
+
+	return &pb_v1.CreatePaymentRequestResponse{PaymentRequest: util.DbPaymentRequestToPbPaymentRequest(paymentRequest, invoices)}, nil
+}
+
+func (i *InvoiceGrpc) GetPaymentRequest(ctx context.Context, req *pb_v1.GetPaymentRequestRequest) (*pb_v1.GetPaymentRequestResponse, error) {
+	paymentRequestId, err := util.StringToPgUUID(req.Id)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidPaymentRequestIdInvalidUUIDMsg)
+	}
+
+	q, tx, err := util.InitDbQueriesWithTx(ctx, i.dbConnPool)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	paymentRequest, err := q.FindPaymentRequestById(ctx, *paymentRequestId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.InvalidPaymentRequestIdDoesNotExistMsg)
+		}
+
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindPaymentRequestById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	invoices, err := q.FindInvoicesByPaymentRequestId(ctx, paymentRequest.ID)
+	if err != nil {
+		i.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindInvoicesByPaymentRequestId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.GetPaymentRequestResponse{PaymentRequest: util.DbPaymentRequestToPbPaymentRequest(&paymentRequest, invoices)}, nil
+}
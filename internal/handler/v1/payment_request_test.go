@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"math"
+	"testing"
+
+	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidatePaymentRequestCoins(t *testing.T) {
+	amount := "1000"
+	invalidAmount := "0.5"
+	fiat := &pb_v1.FiatAmount{Amount: 25, Currency: "USD"}
+
+	t.Run("Should Accept Valid Coins", func(t *testing.T) {
+		reqs := []*pb_v1.CreatePaymentRequestRequest{
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: pb_v1.CoinType_BTC, Amount: &amount}, {Coin: pb_v1.CoinType_XMR, Amount: &amount}}},
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: pb_v1.CoinType_BTC}, {Coin: pb_v1.CoinType_USDT_ERC20}}, Fiat: fiat},
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: pb_v1.CoinType_BTC, Amount: &amount}, {Coin: pb_v1.CoinType_XMR}}, Fiat: fiat},
+		}
+
+		for i := 0; i < len(reqs); i++ {
+			assert.NoError(t, validatePaymentRequestCoins(reqs[i]))
+		}
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		tooManyCoins := make([]*pb_v1.PaymentRequestCoin, 0)
+		for i := 0; i <= 10; i++ {
+			tooManyCoins = append(tooManyCoins, &pb_v1.PaymentRequestCoin{Coin: pb_v1.CoinType(i), Amount: &amount})
+		}
+
+		reqs := []*pb_v1.CreatePaymentRequestRequest{
+			{},
+			{Coins: tooManyCoins},
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: pb_v1.CoinType_BTC, Amount: &amount}, {Coin: pb_v1.CoinType_BTC, Amount: &amount}}},
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: math.MaxInt32, Amount: &amount}}},
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: pb_v1.CoinType_BTC}}},
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: pb_v1.CoinType_BTC, Amount: &invalidAmount}}},
+			{Coins: []*pb_v1.PaymentRequestCoin{{Coin: pb_v1.CoinType_BTC}}, Fiat: &pb_v1.FiatAmount{Amount: 0, Currency: "USD"}},
+		}
+
+		for i := 0; i < len(reqs); i++ {
+			assert.Equal(t, codes.InvalidArgument, status.Code(validatePaymentRequestCoins(reqs[i])))
+		}
+	})
+}
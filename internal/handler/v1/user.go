@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"net/url"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/chekist32/go-monero/utils"
 	"github.com/chekist32/goipay/internal/db"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
 	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
@@ -222,6 +225,34 @@ func (u *UserGrpc) handleHDKeysCryptoDataUpdate(ctx context.Context, q *db.Queri
 	return nil
 }
 
+func (u *UserGrpc) handleEvmCryptoDataUpdate(ctx context.Context, q *db.Queries, in *pb_v1.EvmKeysUpdateRequest, cryptData *db.CryptoDatum) error {
+	chain, err := util.CoinIdToDbCoin(in.Chain)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, util.InvalidEvmChainMsg)
+	}
+	// The built-in coins and tokens have their own keys.
+	if _, err := util.DbCoinToPbCoin(chain); err == nil {
+		return status.Error(codes.InvalidArgument, util.InvalidEvmChainMsg)
+	}
+
+	if _, err := hdkeychain.NewKeyFromString(in.MasterPubKey); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(fmt.Sprintf("An error occurred while creating the %v master public key.", chain))
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid %v master public key.", chain))
+	}
+
+	if _, err := q.DeleteAllCryptoAddressByUserIdAndCoin(ctx, db.DeleteAllCryptoAddressByUserIdAndCoinParams{Coin: chain, UserID: cryptData.UserID}); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "DeleteAllCryptoAddressByUserIdAndCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	if _, err := q.UpsertKeysEVMCryptoData(ctx, db.UpsertKeysEVMCryptoDataParams{UserID: cryptData.UserID, Chain: chain, MasterPubKey: in.MasterPubKey}); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "UpsertKeysEVMCryptoData").Msg(util.DefaultFailedSqlQueryMsg)
+		return status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	return nil
+}
+
 func (u *UserGrpc) UpdateCryptoKeys(ctx context.Context, in *pb_v1.UpdateCryptoKeysRequest) (*pb_v1.UpdateCryptoKeysResponse, error) {
 	q, tx, err := util.InitDbQueriesWithTx(ctx, u.dbConnPool)
 	if err != nil {
@@ -276,12 +307,177 @@ func (u *UserGrpc) UpdateCryptoKeys(ctx context.Context, in *pb_v1.UpdateCryptoK
 			return nil, err
 		}
 	}
+	for i := 0; i < len(in.EvmReqs); i++ {
+		if err := u.handleEvmCryptoDataUpdate(ctx, q, in.EvmReqs[i], &cryptData); err != nil {
+			u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg("")
+			return nil, err
+		}
+	}
 
 	tx.Commit(ctx)
 
 	return &pb_v1.UpdateCryptoKeysResponse{}, nil
 }
 
+func (u *UserGrpc) RegisterWebhook(ctx context.Context, in *pb_v1.RegisterWebhookRequest) (*pb_v1.RegisterWebhookResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, u.dbConnPool)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	userId, err := util.StringToPgUUID(in.UserId)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+
+	if err := checkIfUserExistsUUID(ctx, u.log, q, *userId); err != nil {
+		return nil, err
+	}
+
+	webhookUrl, err := url.Parse(in.Url)
+	if err != nil || (webhookUrl.Scheme != "http" && webhookUrl.Scheme != "https") || webhookUrl.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidWebhookUrlMsg)
+	}
+	if in.Secret == "" {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidWebhookSecretMsg)
+	}
+
+	webhook, err := q.CreateWebhook(ctx, db.CreateWebhookParams{Url: webhookUrl.String(), Secret: in.Secret, UserID: *userId})
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "CreateWebhook").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.RegisterWebhookResponse{WebhookId: util.PgUUIDToString(webhook.ID)}, nil
+}
+
+func (u *UserGrpc) ListWebhookDeliveries(ctx context.Context, in *pb_v1.ListWebhookDeliveriesRequest) (*pb_v1.ListWebhookDeliveriesResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, u.dbConnPool)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	userId, err := util.StringToPgUUID(in.UserId)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+
+	params := db.FindWebhookDeliveriesByUserIdParams{UserID: *userId}
+	if in.Status != nil {
+		deliveryStatus, err := util.PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(*in.Status)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidWebhookDeliveryStatusTypeMsg)
+		}
+		params.Status = db.NullWebhookDeliveryStatusType{WebhookDeliveryStatusType: deliveryStatus, Valid: true}
+	}
+
+	limit := in.Limit
+	if limit == 0 {
+		limit = util.LIST_WEBHOOK_DELIVERIES_DEFAULT_LIMIT
+	}
+	if limit > util.LIST_WEBHOOK_DELIVERIES_MAX_LIMIT {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidListWebhookDeliveriesLimitMsg)
+	}
+	if in.Offset > math.MaxInt32 {
+		return nil, status.Error(codes.InvalidArgument, util.InvalidListWebhookDeliveriesOffsetMsg)
+	}
+	params.Limit = int32(limit)
+	params.Offset = int32(in.Offset)
+
+	deliveries, err := q.FindWebhookDeliveriesByUserId(ctx, params)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "FindWebhookDeliveriesByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	res := &pb_v1.ListWebhookDeliveriesResponse{Deliveries: make([]*pb_v1.WebhookDelivery, 0, len(deliveries))}
+	for i := 0; i < len(deliveries); i++ {
+		res.Deliveries = append(res.Deliveries, util.DbWebhookDeliveryToPbWebhookDelivery(&deliveries[i]))
+	}
+
+	return res, nil
+}
+
+func (u *UserGrpc) RedeliverWebhookDelivery(ctx context.Context, in *pb_v1.RedeliverWebhookDeliveryRequest) (*pb_v1.RedeliverWebhookDeliveryResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, u.dbConnPool)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	userId, err := util.StringToPgUUID(in.UserId)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+	deliveryId, err := util.StringToPgUUID(in.DeliveryId)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidWebhookDeliveryIdInvalidUUIDMsg)
+	}
+
+	delivery, err := q.RedeliverWebhookDeliveryByIdAndUserId(ctx, db.RedeliverWebhookDeliveryByIdAndUserIdParams{ID: *deliveryId, UserID: *userId})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, util.InvalidWebhookDeliveryIdDoesNotExistMsg)
+		}
+
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "RedeliverWebhookDeliveryByIdAndUserId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.RedeliverWebhookDeliveryResponse{Delivery: util.DbWebhookDeliveryToPbWebhookDelivery(&delivery)}, nil
+}
+
+func (u *UserGrpc) UpdatePaymentTolerance(ctx context.Context, in *pb_v1.UpdatePaymentToleranceRequest) (*pb_v1.UpdatePaymentToleranceResponse, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, u.dbConnPool)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlTxInitMsg)
+	}
+	defer tx.Rollback(ctx)
+
+	userId, err := util.StringToPgUUID(in.UserId)
+	if err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Msg(util.FailedStringToPgUUIDMappingMsg)
+		return nil, status.Error(codes.InvalidArgument, util.InvalidUserIdInvalidUUIDMsg)
+	}
+
+	if err := checkIfUserExistsUUID(ctx, u.log, q, *userId); err != nil {
+		return nil, err
+	}
+
+	var tolerancePercent pgtype.Float8
+	if in.TolerancePercent != nil {
+		if !isValidTolerancePercent(*in.TolerancePercent) {
+			return nil, status.Error(codes.InvalidArgument, util.InvalidTolerancePercentMsg)
+		}
+		tolerancePercent = pgtype.Float8{Float64: *in.TolerancePercent, Valid: true}
+	}
+
+	if _, err := q.UpdateTolerancePercentByUserId(ctx, db.UpdateTolerancePercentByUserIdParams{ID: *userId, TolerancePercent: tolerancePercent}); err != nil {
+		u.log.Err(err).Str(util.RequestIdLogKey, util.GetRequestIdOrEmptyString(ctx)).Str("queryName", "UpdateTolerancePercentByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, status.Error(codes.Internal, util.DefaultFailedSqlQueryMsg)
+	}
+
+	tx.Commit(ctx)
+
+	return &pb_v1.UpdatePaymentToleranceResponse{}, nil
+}
+
 func NewUserGrpc(dbConnPool *pgxpool.Pool, log *zerolog.Logger) *UserGrpc {
 	return &UserGrpc{dbConnPool: dbConnPool, log: log}
 }
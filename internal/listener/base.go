@@ -1,7 +1,11 @@
 package listener
 
 import (
+	"cmp"
 	"context"
+	"maps"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -36,24 +40,196 @@ const (
 	MainnetBNB
 	TestnetBNB
 	PrivateBNB
+
+	// EVM is the network of the EVM chains declared in the config. Their chain id tells them apart.
+	EVM
 )
 
+// max_reorg_depth is the number of recent block hashes kept to find the fork point of a reorg.
+const max_reorg_depth uint64 = 100
+
+// max_pool_tx_age is how long a tx reported by a TxPoolFetcher is assumed to stay in the mempool unless it gets mined.
+// The watched txs older than that are looked up to find out if they were evicted.
+const max_pool_tx_age time.Duration = 3 * time.Hour
+
+type watchedTx struct {
+	outpoints []string
+}
+
 type transactionPoolSync struct {
-	txs map[string]bool
+	mu sync.Mutex
+	// txs are the txs known to be in the mempool along with the time they were seen.
+	txs        map[string]time.Time
+	watchedTxs map[string]watchedTx
+}
+
+// replace sets the txs in the mempool to txIds and returns the ones which weren't there before.
+func (s *transactionPoolSync) replace(txIds []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	newTxIds := make([]string, 0)
+	txs := make(map[string]time.Time, len(txIds))
+	for i := 0; i < len(txIds); i++ {
+		seenAt, ok := s.txs[txIds[i]]
+		if !ok {
+			seenAt = now
+			newTxIds = append(newTxIds, txIds[i])
+		}
+		txs[txIds[i]] = seenAt
+	}
+	s.txs = txs
+
+	return newTxIds
+}
+
+// add marks the tx as seen in the mempool now. It returns false if the tx was already there.
+func (s *transactionPoolSync) add(txId string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.txs[txId]
+	s.txs[txId] = time.Now()
+	return !ok
+}
+
+func (s *transactionPoolSync) remove(txIds []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < len(txIds); i++ {
+		delete(s.txs, txIds[i])
+	}
+}
+
+func (s *transactionPoolSync) removeOlderThan(age time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	maps.DeleteFunc(s.txs, func(_ string, seenAt time.Time) bool { return time.Since(seenAt) > age })
+}
+
+func (s *transactionPoolSync) contains(txId string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.txs[txId]
+	return ok
+}
+
+func (s *transactionPoolSync) watch(txId string, tx watchedTx) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.watchedTxs[txId] = tx
+}
+
+func (s *transactionPoolSync) unwatch(txId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.watchedTxs, txId)
+}
+
+func (s *transactionPoolSync) watched() map[string]watchedTx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.watchedTxs)
 }
 
 type blockSync struct {
 	lastBlockHeight atomic.Uint64
+
+	mu     sync.Mutex
+	hashes map[uint64]string
+}
+
+func (s *blockSync) hash(height uint64) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash, ok := s.hashes[height]
+	return hash, ok
+}
+
+func (s *blockSync) storeHash(height uint64, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hashes[height] = hash
+	for h := range s.hashes {
+		if h+max_reorg_depth <= height {
+			delete(s.hashes, h)
+		}
+	}
+}
+
+// rollback forgets the hashes of the blocks above forkHeight.
+func (s *blockSync) rollback(forkHeight uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for h := range s.hashes {
+		if h > forkHeight {
+			delete(s.hashes, h)
+		}
+	}
+}
+
+func (s *blockSync) recentHashes() []BlockHash {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hashes := make([]BlockHash, 0, len(s.hashes))
+	for h, hash := range s.hashes {
+		hashes = append(hashes, BlockHash{Height: h, Hash: hash})
+	}
+	slices.SortFunc(hashes, func(a, b BlockHash) int {
+		return cmp.Compare(a.Height, b.Height)
+	})
+
+	return hashes
+}
+
+// BlockHash is the hash of a synced block.
+type BlockHash struct {
+	Height uint64
+	Hash   string
+}
+
+// DroppedTx is emitted when a watched tx leaves the mempool without being mined.
+// ReplacedBy is set if the tx was replaced by a conflicting one (e.g. BIP125 RBF).
+type DroppedTx struct {
+	TxId       string
+	ReplacedBy string
+}
+
+// Reorg is emitted when synced blocks are no longer part of the main chain.
+// The blocks above ForkHeight are orphaned and get synced again.
+type Reorg struct {
+	ForkHeight uint64
+	Depth      uint64
 }
 
 type SharedTx interface {
 	GetTxId() string
 	GetConfirmations() uint64
 	IsDoubleSpendSeen() bool
+	// GetBlockHeight returns the height of the block the tx is mined in. It returns false for an unconfirmed tx.
+	GetBlockHeight() (uint64, bool)
+}
+
+// ConflictingTx is implemented by the txs of the UTXO based coins. Two txs conflict if they spend the same outpoint.
+type ConflictingTx interface {
+	GetSpentOutpoints() []string
 }
 
 type SharedBlock interface {
 	GetTxHashes() []string
+	GetHash() string
+	GetParentHash() string
 }
 
 type SharedDaemonRpcClient[T SharedTx, B SharedBlock] interface {
@@ -65,12 +241,60 @@ type SharedDaemonRpcClient[T SharedTx, B SharedBlock] interface {
 	GetCoinType() db.CoinType
 }
 
+type Subscription interface {
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// BlockSubscriber is implemented by the clients which can be notified about new blocks instead of polling the chain tip.
+type BlockSubscriber interface {
+	SupportsBlockSubscription() bool
+	// SubscribeNewBlocks sends the height of every new block to cn (0 if the notification doesn't carry it).
+	// The error channel of the subscription is closed or receives an error once the subscription drops.
+	SubscribeNewBlocks(ctx context.Context, cn chan<- uint64) (Subscription, error)
+}
+
+// TxSubscriber is implemented by the clients which get the txs entering the mempool pushed by the daemon instead of polling it.
+type TxSubscriber[T SharedTx] interface {
+	SupportsTxSubscription() bool
+	// SubscribeNewTxs sends every tx entering the mempool to cn. The error channel of the subscription
+	// is closed or receives an error once the subscription drops.
+	SubscribeNewTxs(ctx context.Context, cn chan<- T) (Subscription, error)
+}
+
+// TxPoolFetcher is implemented by the clients whose daemon reports only the txs which entered the mempool since
+// the previous call, but together with their bodies. The executor keeps track of the mempool itself then.
+type TxPoolFetcher[T SharedTx] interface {
+	GetNewPoolTransactions() ([]T, error)
+}
+
+// BlockTxFilterer is implemented by the clients which can look up the txs of a block paying the watched addresses
+// without fetching every tx of the block.
+type BlockTxFilterer[T SharedTx, B SharedBlock] interface {
+	// GetBlockTransactionsTo returns the txs of the block sending the native coin or one of the tokens to the addresses.
+	GetBlockTransactionsTo(block B, addresses []string, tokenContracts []string) ([]T, error)
+}
+
+// TokenDecimalsGetter is implemented by the clients of the chains whose tokens report their decimals.
+type TokenDecimalsGetter interface {
+	GetTokenDecimals(contractAddress string) (uint8, error)
+}
+
+// BlockTimeGetter is implemented by the clients of the chains whose block time differs from the default polling interval.
+type BlockTimeGetter interface {
+	GetBlockTime() time.Duration
+}
+
 type DaemonRpcClientExecutor[T SharedTx, B SharedBlock] interface {
-	Start(startBlock uint64)
+	Start(startBlock uint64, blockHashes []BlockHash)
 	Stop()
 	NewBlockChan() <-chan B
 	NewTxPoolChan() <-chan T
+	NewReorgChan() <-chan Reorg
+	NewDroppedTxChan() <-chan DroppedTx
+	WatchTx(tx T)
 	LastSyncedBlockHeight() uint64
+	RecentBlockHashes() []BlockHash
 }
 
 type BaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock] struct {
@@ -83,6 +307,8 @@ type BaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock] struct {
 
 	txPoolChns   *util.SyncMapTypeSafe[string, chan T]
 	newBlockChns *util.SyncMapTypeSafe[string, chan B]
+	reorgChns    *util.SyncMapTypeSafe[string, chan Reorg]
+	droppedChns  *util.SyncMapTypeSafe[string, chan DroppedTx]
 
 	blockSync           blockSync
 	transactionPoolSync transactionPoolSync
@@ -120,6 +346,68 @@ func (d *BaseDaemonRpcClientExecutor[T, B]) broadcastNewTx(tx *T) {
 	})
 }
 
+func (d *BaseDaemonRpcClientExecutor[T, B]) broadcastReorg(reorg *Reorg) {
+	d.reorgChns.Range(func(key string, cn chan Reorg) bool {
+		go func() {
+			select {
+			case cn <- *reorg:
+				return
+			case <-time.After(util.MIN_SYNC_TIMEOUT):
+				d.reorgChns.Delete(key)
+				return
+			}
+		}()
+		return true
+	})
+}
+
+func (d *BaseDaemonRpcClientExecutor[T, B]) broadcastDroppedTx(droppedTx *DroppedTx) {
+	d.droppedChns.Range(func(key string, cn chan DroppedTx) bool {
+		go func() {
+			select {
+			case cn <- *droppedTx:
+				return
+			case <-time.After(util.MIN_SYNC_TIMEOUT):
+				d.droppedChns.Delete(key)
+				return
+			}
+		}()
+		return true
+	})
+}
+
+// rollback walks back from height to the last synced block which is still part of the main chain and resumes syncing after it.
+func (d *BaseDaemonRpcClientExecutor[T, B]) rollback(height uint64) error {
+	forkHeight := height
+	for forkHeight > 0 {
+		hash, ok := d.blockSync.hash(forkHeight)
+		// The fork is deeper than the stored hashes, so all of them are rolled back.
+		if !ok {
+			break
+		}
+
+		block, err := d.client.GetBlockByHeight(forkHeight)
+		if err != nil {
+			d.log.Err(err).Str("method", "GetBlockByHeight").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
+			return err
+		}
+		if block.GetHash() == hash {
+			break
+		}
+
+		forkHeight--
+	}
+
+	d.blockSync.rollback(forkHeight)
+	d.blockSync.lastBlockHeight.Store(forkHeight + 1)
+
+	reorg := Reorg{ForkHeight: forkHeight, Depth: height - forkHeight}
+	d.log.Warn().Str("coin", string(d.coin)).Msgf("Chain reorganisation detected: %v blocks above %v were orphaned", reorg.Depth, reorg.ForkHeight)
+	d.broadcastReorg(&reorg)
+
+	return nil
+}
+
 func (d *BaseDaemonRpcClientExecutor[T, B]) syncBlock() {
 	height, err := d.client.GetLastBlockHeight()
 	if err != nil {
@@ -136,13 +424,25 @@ func (d *BaseDaemonRpcClientExecutor[T, B]) syncBlock() {
 				return
 			}
 
-			block, err := d.client.GetBlockByHeight(d.blockSync.lastBlockHeight.Load())
+			blockHeight := d.blockSync.lastBlockHeight.Load()
+			block, err := d.client.GetBlockByHeight(blockHeight)
 			if err != nil {
 				d.log.Err(err).Str("method", "GetBlockByHeight").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
 				return
 			}
+
+			if blockHeight > 0 {
+				if parentHash, ok := d.blockSync.hash(blockHeight - 1); ok && parentHash != block.GetParentHash() {
+					if err := d.rollback(blockHeight - 1); err != nil {
+						return
+					}
+					continue
+				}
+			}
 			d.log.Info().Str("coin", string(d.coin)).Msgf("Synced blockheight: %v", height)
 
+			d.blockSync.storeHash(blockHeight, block.GetHash())
+			d.transactionPoolSync.remove(block.GetTxHashes())
 			d.broadcastNewBlock(&block)
 
 			d.blockSync.lastBlockHeight.Add(1)
@@ -151,69 +451,241 @@ func (d *BaseDaemonRpcClientExecutor[T, B]) syncBlock() {
 }
 
 func (d *BaseDaemonRpcClientExecutor[T, B]) syncTransactionPool() {
+	if fetcher, ok := d.client.(TxPoolFetcher[T]); ok {
+		d.syncNewPoolTransactions(fetcher)
+		return
+	}
+
 	txHashes, err := d.client.GetTransactionPool()
 	if err != nil {
 		d.log.Err(err).Str("method", "GetTransactionPool").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
 		return
 	}
 
-	prevTxs := d.transactionPoolSync.txs
-	newTxs := make(map[string]bool)
+	newTxHashes := d.transactionPoolSync.replace(txHashes)
+	for i := 0; i < len(newTxHashes); i++ {
+		tx, err := d.client.GetTransactions([]string{newTxHashes[i]})
+		if err != nil || len(tx) < 1 {
+			d.log.Err(err).Str("method", "GetTransactions").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
+			continue
+		}
+
+		d.handleNewTx(tx[0])
+	}
 
-	for i := 0; i < len(txHashes); i++ {
-		newTxs[txHashes[i]] = true
+	d.detectDroppedTxs(d.transactionPoolSync.contains)
+}
+
+// syncNewPoolTransactions adds the txs which entered the mempool to the tracked ones. The mined txs are removed
+// from them as the blocks get synced, the rest once they are older than max_pool_tx_age.
+func (d *BaseDaemonRpcClientExecutor[T, B]) syncNewPoolTransactions(fetcher TxPoolFetcher[T]) {
+	txs, err := fetcher.GetNewPoolTransactions()
+	if err != nil {
+		d.log.Err(err).Str("method", "GetNewPoolTransactions").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
+		return
+	}
 
-		if prevTxs[txHashes[i]] {
+	for i := 0; i < len(txs); i++ {
+		if d.transactionPoolSync.add(txs[i].GetTxId()) {
+			d.handleNewTx(txs[i])
+		}
+	}
+
+	d.transactionPoolSync.removeOlderThan(max_pool_tx_age)
+	d.detectDroppedTxs(d.transactionPoolSync.contains)
+}
+
+func (d *BaseDaemonRpcClientExecutor[T, B]) handleNewTx(tx T) {
+	d.detectReplacedTxs(tx)
+	d.broadcastNewTx(&tx)
+}
+
+// detectReplacedTxs emits a DroppedTx for every watched tx spending the same outpoints as the new mempool tx.
+func (d *BaseDaemonRpcClientExecutor[T, B]) detectReplacedTxs(tx T) {
+	conflictingTx, ok := any(tx).(ConflictingTx)
+	if !ok {
+		return
+	}
+
+	outpoints := conflictingTx.GetSpentOutpoints()
+	for txId, watched := range d.transactionPoolSync.watched() {
+		if txId == tx.GetTxId() || !slices.ContainsFunc(watched.outpoints, func(outpoint string) bool { return slices.Contains(outpoints, outpoint) }) {
 			continue
 		}
 
-		tx, err := d.client.GetTransactions([]string{txHashes[i]})
-		if err != nil || len(tx) < 1 {
+		d.transactionPoolSync.unwatch(txId)
+		d.log.Info().Str("coin", string(d.coin)).Msgf("Tx %v was replaced by %v", txId, tx.GetTxId())
+		d.broadcastDroppedTx(&DroppedTx{TxId: txId, ReplacedBy: tx.GetTxId()})
+	}
+}
+
+// detectDroppedTxs checks the watched txs which are no longer in the mempool. The mined ones stop being watched,
+// the ones unknown to the daemon were evicted. A nil inPool makes every watched tx be checked.
+func (d *BaseDaemonRpcClientExecutor[T, B]) detectDroppedTxs(inPool func(txId string) bool) {
+	for txId := range d.transactionPoolSync.watched() {
+		if inPool != nil && inPool(txId) {
+			continue
+		}
+
+		txs, err := d.client.GetTransactions([]string{txId})
+		if err != nil {
 			d.log.Err(err).Str("method", "GetTransactions").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
 			continue
 		}
+		if len(txs) > 0 {
+			if _, mined := txs[0].GetBlockHeight(); mined {
+				d.transactionPoolSync.unwatch(txId)
+			} else {
+				// The tx is still pending, so it isn't looked up again until it's mined or ages out.
+				d.transactionPoolSync.add(txId)
+			}
+			continue
+		}
 
-		d.broadcastNewTx(&tx[0])
+		d.transactionPoolSync.unwatch(txId)
+		d.log.Info().Str("coin", string(d.coin)).Msgf("Tx %v was dropped from the mempool", txId)
+		d.broadcastDroppedTx(&DroppedTx{TxId: txId})
 	}
+}
 
-	d.transactionPoolSync.txs = newTxs
+// syncBlocks syncs the blocks on every new block notification if the client supports them.
+// The chain tip is polled every timeout otherwise, as well as while the subscription is down.
+func (d *BaseDaemonRpcClientExecutor[T, B]) syncBlocks(timeout time.Duration) {
+	subscriber, ok := d.client.(BlockSubscriber)
+	canSubscribe := ok && subscriber.SupportsBlockSubscription()
+
+	var sub Subscription
+	var subErrCn <-chan error
+	newBlockCn := make(chan uint64)
+	subscribe := func() {
+		s, err := subscriber.SubscribeNewBlocks(d.ctx, newBlockCn)
+		if err != nil {
+			d.log.Err(err).Str("method", "SubscribeNewBlocks").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
+			return
+		}
+		sub, subErrCn = s, s.Err()
 
-}
+		// The blocks mined while the subscription was down.
+		d.syncBlock()
+	}
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}()
 
-func (d *BaseDaemonRpcClientExecutor[T, B]) sync(blockTimeout time.Duration, txPoolTimeout time.Duration) {
-	go func() {
-		t := time.NewTicker(blockTimeout)
-		for {
-			select {
-			case <-d.ctx.Done():
-				return
-			case <-t.C:
-				d.syncBlock()
+	if canSubscribe {
+		subscribe()
+	}
+
+	t := time.NewTicker(timeout)
+	defer t.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-newBlockCn:
+			d.syncBlock()
+		case err := <-subErrCn:
+			d.log.Warn().Err(err).Str("coin", string(d.coin)).Msg("The new block subscription dropped, falling back to polling.")
+			sub.Unsubscribe()
+			sub, subErrCn = nil, nil
+		case <-t.C:
+			if sub != nil {
+				continue
+			}
+			if canSubscribe {
+				subscribe()
+				if sub != nil {
+					continue
+				}
 			}
+			d.syncBlock()
+		}
+	}
+}
+
+// syncTransactions handles the txs pushed by the daemon if the client supports it.
+// The mempool is polled every timeout otherwise, as well as while the subscription is down.
+func (d *BaseDaemonRpcClientExecutor[T, B]) syncTransactions(timeout time.Duration) {
+	subscriber, ok := d.client.(TxSubscriber[T])
+	canSubscribe := ok && subscriber.SupportsTxSubscription()
+
+	var sub Subscription
+	var subErrCn <-chan error
+	newTxCn := make(chan T)
+	subscribe := func() {
+		s, err := subscriber.SubscribeNewTxs(d.ctx, newTxCn)
+		if err != nil {
+			d.log.Err(err).Str("method", "SubscribeNewTxs").Str("coin", string(d.coin)).Msg(util.DefaultFailedFetchingDaemonMsg)
+			return
+		}
+		sub, subErrCn = s, s.Err()
+
+		// The txs which entered the mempool while the subscription was down.
+		d.syncTransactionPool()
+	}
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
 		}
 	}()
 
-	go func() {
-		t := time.NewTicker(txPoolTimeout)
-		for {
-			select {
-			case <-d.ctx.Done():
-				return
-			case <-t.C:
-				d.syncTransactionPool()
+	if canSubscribe {
+		subscribe()
+	}
+
+	t := time.NewTicker(timeout)
+	defer t.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case tx := <-newTxCn:
+			d.handleNewTx(tx)
+		case err := <-subErrCn:
+			d.log.Warn().Err(err).Str("coin", string(d.coin)).Msg("The new tx subscription dropped, falling back to polling.")
+			sub.Unsubscribe()
+			sub, subErrCn = nil, nil
+		case <-t.C:
+			if sub != nil {
+				// The pushed txs don't reveal the evicted ones, so the watched txs are looked up one by one.
+				d.detectDroppedTxs(nil)
+				continue
 			}
+			if canSubscribe {
+				subscribe()
+				if sub != nil {
+					continue
+				}
+			}
+			d.syncTransactionPool()
 		}
-	}()
+	}
 }
 
-func (d *BaseDaemonRpcClientExecutor[T, B]) Start(startBlock uint64) {
+func (d *BaseDaemonRpcClientExecutor[T, B]) sync(blockTimeout time.Duration, txPoolTimeout time.Duration) {
+	go d.syncBlocks(blockTimeout)
+	go d.syncTransactions(txPoolTimeout)
+}
+
+// Start syncs blocks from startBlock. The hashes of the previously synced blocks let a reorg that happened during downtime be detected.
+func (d *BaseDaemonRpcClientExecutor[T, B]) Start(startBlock uint64, blockHashes []BlockHash) {
 	if d.ctx.Err() == nil {
 		return
 	}
 	d.ctx, d.cancel = context.WithCancel(context.Background())
 	d.blockSync.lastBlockHeight.Store(startBlock)
+	for i := 0; i < len(blockHashes); i++ {
+		d.blockSync.storeHash(blockHashes[i].Height, blockHashes[i].Hash)
+	}
+
+	blockTimeout := util.MIN_SYNC_TIMEOUT
+	if getter, ok := d.client.(BlockTimeGetter); ok && getter.GetBlockTime() > 0 {
+		blockTimeout = getter.GetBlockTime()
+	}
 
-	d.sync(util.MIN_SYNC_TIMEOUT, util.MIN_SYNC_TIMEOUT/2)
+	d.sync(blockTimeout, util.MIN_SYNC_TIMEOUT/2)
 }
 
 func (d *BaseDaemonRpcClientExecutor[T, B]) Stop() {
@@ -232,10 +704,36 @@ func (d *BaseDaemonRpcClientExecutor[T, B]) NewTxPoolChan() <-chan T {
 	return cn
 }
 
+func (d *BaseDaemonRpcClientExecutor[T, B]) NewReorgChan() <-chan Reorg {
+	cn := make(chan Reorg)
+	d.reorgChns.Store(uuid.NewString(), cn)
+	return cn
+}
+
+func (d *BaseDaemonRpcClientExecutor[T, B]) NewDroppedTxChan() <-chan DroppedTx {
+	cn := make(chan DroppedTx)
+	d.droppedChns.Store(uuid.NewString(), cn)
+	return cn
+}
+
+// WatchTx makes the executor report the mempool tx if it gets replaced or evicted before being mined.
+func (d *BaseDaemonRpcClientExecutor[T, B]) WatchTx(tx T) {
+	var outpoints []string
+	if conflictingTx, ok := any(tx).(ConflictingTx); ok {
+		outpoints = conflictingTx.GetSpentOutpoints()
+	}
+
+	d.transactionPoolSync.watch(tx.GetTxId(), watchedTx{outpoints: outpoints})
+}
+
 func (d *BaseDaemonRpcClientExecutor[T, B]) LastSyncedBlockHeight() uint64 {
 	return d.blockSync.lastBlockHeight.Load()
 }
 
+func (d *BaseDaemonRpcClientExecutor[T, B]) RecentBlockHashes() []BlockHash {
+	return d.blockSync.recentHashes()
+}
+
 func NewBaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock](log *zerolog.Logger, client SharedDaemonRpcClient[T, B]) *BaseDaemonRpcClientExecutor[T, B] {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -246,8 +744,11 @@ func NewBaseDaemonRpcClientExecutor[T SharedTx, B SharedBlock](log *zerolog.Logg
 		cancel:              cancel,
 		coin:                client.GetCoinType(),
 		client:              client,
-		transactionPoolSync: transactionPoolSync{txs: make(map[string]bool)},
+		blockSync:           blockSync{hashes: make(map[uint64]string)},
+		transactionPoolSync: transactionPoolSync{txs: make(map[string]time.Time), watchedTxs: make(map[string]watchedTx)},
 		txPoolChns:          &util.SyncMapTypeSafe[string, chan T]{},
 		newBlockChns:        &util.SyncMapTypeSafe[string, chan B]{},
+		reorgChns:           &util.SyncMapTypeSafe[string, chan Reorg]{},
+		droppedChns:         &util.SyncMapTypeSafe[string, chan DroppedTx]{},
 	}
 }
@@ -2,7 +2,9 @@ package listener
 
 import (
 	"context"
+	"errors"
 	"math/rand"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -19,6 +21,8 @@ import (
 type TestTx struct {
 	TxId          string
 	Confirmations uint64
+	BlockHeight   uint64
+	Outpoints     []string
 }
 
 func (t TestTx) GetTxId() string {
@@ -30,14 +34,28 @@ func (t TestTx) GetConfirmations() uint64 {
 func (t TestTx) IsDoubleSpendSeen() bool {
 	return false
 }
+func (t TestTx) GetBlockHeight() (uint64, bool) {
+	return t.BlockHeight, t.Confirmations > 0
+}
+func (t TestTx) GetSpentOutpoints() []string {
+	return t.Outpoints
+}
 
 type TestBlock struct {
-	Height uint64
+	Height     uint64
+	Hash       string
+	ParentHash string
 }
 
 func (b TestBlock) GetTxHashes() []string {
 	return nil
 }
+func (b TestBlock) GetHash() string {
+	return b.Hash
+}
+func (b TestBlock) GetParentHash() string {
+	return b.ParentHash
+}
 
 func TestBlockChan(t *testing.T) {
 	t.Parallel()
@@ -113,10 +131,10 @@ func TestStartStop(t *testing.T) {
 
 	tbcrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, mockClient)
 
-	tbcrce.Start(0)
+	tbcrce.Start(0, nil)
 	assert.NoError(t, tbcrce.ctx.Err())
 
-	tbcrce.Start(0)
+	tbcrce.Start(0, nil)
 	assert.NoError(t, tbcrce.ctx.Err())
 
 	tbcrce.Stop()
@@ -191,6 +209,59 @@ func TestSyncBlock(t *testing.T) {
 		assert.Equal(t, 0, cnCount)
 	})
 
+	t.Run("Should Roll Back To The Fork Point", func(t *testing.T) {
+		chain := map[uint64]TestBlock{
+			10: {Height: 10, Hash: "a10", ParentHash: "a9"},
+			11: {Height: 11, Hash: "b11", ParentHash: "a10"},
+			12: {Height: 12, Hash: "b12", ParentHash: "b11"},
+			13: {Height: 13, Hash: "b13", ParentHash: "b12"},
+		}
+
+		d := NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		d.On("GetLastBlockHeight").Return(uint64(14), error(nil))
+		d.On("GetBlockByHeight", mock.Anything).Return(func(height uint64) (TestBlock, error) {
+			return chain[height], nil
+		})
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+		bdrce.blockSync.storeHash(10, "a10")
+		bdrce.blockSync.storeHash(11, "a11")
+		bdrce.blockSync.storeHash(12, "a12")
+		bdrce.blockSync.lastBlockHeight.Store(13)
+		blockCn := bdrce.NewBlockChan()
+		reorgCn := bdrce.NewReorgChan()
+
+		bdrce.ctx = context.Background()
+		bdrce.syncBlock()
+
+		reorg := test.GetValueFromCnOrLogFatalWithTimeout(reorgCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, Reorg{ForkHeight: 10, Depth: 2}, reorg)
+
+		syncedBlocks := make(map[uint64]TestBlock)
+		for i := 0; i < 3; i++ {
+			block := test.GetValueFromCnOrLogFatalWithTimeout(blockCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+			syncedBlocks[block.Height] = block
+		}
+		assert.Equal(t, map[uint64]TestBlock{11: chain[11], 12: chain[12], 13: chain[13]}, syncedBlocks)
+
+		assert.Equal(t, uint64(14), bdrce.LastSyncedBlockHeight())
+		assert.Equal(t, []BlockHash{{10, "a10"}, {11, "b11"}, {12, "b12"}, {13, "b13"}}, bdrce.RecentBlockHashes())
+	})
+
+	t.Run("Should Keep Only The Recent Block Hashes", func(t *testing.T) {
+		d := NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+		for h := uint64(0); h < 2*max_reorg_depth; h++ {
+			bdrce.blockSync.storeHash(h, uuid.NewString())
+		}
+
+		hashes := bdrce.RecentBlockHashes()
+		assert.Len(t, hashes, int(max_reorg_depth))
+		assert.Equal(t, max_reorg_depth, hashes[0].Height)
+	})
 }
 
 func TestSyncTransactionPool(t *testing.T) {
@@ -238,7 +309,7 @@ func TestSyncTransactionPool(t *testing.T) {
 		assert.Equal(t, expectedTxs1Map, txs1)
 		assert.Condition(t, func() (success bool) {
 			for id := range expectedTxs1Map {
-				if !bdrce.transactionPoolSync.txs[id] {
+				if !bdrce.transactionPoolSync.contains(id) {
 					return false
 				}
 			}
@@ -281,7 +352,7 @@ func TestSyncTransactionPool(t *testing.T) {
 		assert.Equal(t, map[string]TestTx{"tx7": {TxId: "tx7"}, "tx6": {TxId: "tx6"}}, txs2)
 		assert.Condition(t, func() (success bool) {
 			for id := range expectedTxs2Map {
-				if !bdrce.transactionPoolSync.txs[id] {
+				if !bdrce.transactionPoolSync.contains(id) {
 					return false
 				}
 			}
@@ -331,4 +402,289 @@ func TestSyncTransactionPool(t *testing.T) {
 
 		assert.Equal(t, 0, cnCount)
 	})
+
+	t.Run("Should Report Replaced Watched Tx", func(t *testing.T) {
+		watchedTx := TestTx{TxId: "tx1", Outpoints: []string{"prev:0", "prev:1"}}
+		replacementTx := TestTx{TxId: "tx2", Outpoints: []string{"prev:1"}}
+
+		d := NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetCoinType").Return(db.CoinTypeBTC)
+		d.On("GetTransactionPool").Return([]string{replacementTx.TxId}, error(nil))
+		d.On("GetTransactions", []string{replacementTx.TxId}).Return([]TestTx{replacementTx}, error(nil))
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+		bdrce.transactionPoolSync.add(watchedTx.TxId)
+		bdrce.WatchTx(watchedTx)
+		droppedTxCn := bdrce.NewDroppedTxChan()
+
+		bdrce.syncTransactionPool()
+
+		droppedTx := test.GetValueFromCnOrLogFatalWithTimeout(droppedTxCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, DroppedTx{TxId: watchedTx.TxId, ReplacedBy: replacementTx.TxId}, droppedTx)
+		assert.Empty(t, bdrce.transactionPoolSync.watched())
+	})
+
+	t.Run("Should Report Evicted Watched Tx", func(t *testing.T) {
+		evictedTx := TestTx{TxId: "tx1"}
+		minedTx := TestTx{TxId: "tx2"}
+		pendingTx := TestTx{TxId: "tx3"}
+
+		d := NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetCoinType").Return(db.CoinTypeBTC)
+		d.On("GetTransactionPool").Return([]string{pendingTx.TxId}, error(nil))
+		d.On("GetTransactions", []string{evictedTx.TxId}).Return([]TestTx{}, error(nil))
+		d.On("GetTransactions", []string{minedTx.TxId}).Return([]TestTx{{TxId: minedTx.TxId, Confirmations: 1}}, error(nil))
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+		for _, tx := range []TestTx{evictedTx, minedTx, pendingTx} {
+			bdrce.transactionPoolSync.add(tx.TxId)
+			bdrce.WatchTx(tx)
+		}
+		droppedTxCn := bdrce.NewDroppedTxChan()
+
+		bdrce.syncTransactionPool()
+
+		droppedTx := test.GetValueFromCnOrLogFatalWithTimeout(droppedTxCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, DroppedTx{TxId: evictedTx.TxId}, droppedTx)
+		assert.Equal(t, map[string]watchedTx{pendingTx.TxId: {}}, bdrce.transactionPoolSync.watched())
+	})
+}
+
+// testPoolFetcherClient reports the txs which entered the mempool since the previous call.
+type testPoolFetcherClient struct {
+	*MockSharedDaemonRpcClient[TestTx, TestBlock]
+
+	newTxs [][]TestTx
+}
+
+func (c *testPoolFetcherClient) GetNewPoolTransactions() ([]TestTx, error) {
+	if len(c.newTxs) == 0 {
+		return []TestTx{}, nil
+	}
+
+	txs := c.newTxs[0]
+	c.newTxs = c.newTxs[1:]
+	return txs, nil
+}
+
+func TestSyncNewPoolTransactions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Broadcast Every New Tx Once", func(t *testing.T) {
+		m := NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		m.On("GetCoinType").Return(db.CoinTypeETH)
+		client := &testPoolFetcherClient{MockSharedDaemonRpcClient: m, newTxs: [][]TestTx{{{TxId: "tx1"}, {TxId: "tx2"}}, {{TxId: "tx2"}, {TxId: "tx3"}}}}
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, client)
+		txPoolCn := bdrce.NewTxPoolChan()
+
+		txIds := make([]string, 0)
+		for i := 0; i < 2; i++ {
+			bdrce.syncTransactionPool()
+			for j := 0; j < 2-i; j++ {
+				txIds = append(txIds, test.GetValueFromCnOrLogFatalWithTimeout(txPoolCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired").TxId)
+			}
+		}
+
+		assert.ElementsMatch(t, []string{"tx1", "tx2", "tx3"}, txIds)
+		for _, txId := range txIds {
+			assert.True(t, bdrce.transactionPoolSync.contains(txId))
+		}
+	})
+
+	t.Run("Should Look Up Only The Watched Txs Which Left The Tracked Mempool", func(t *testing.T) {
+		pendingTx := TestTx{TxId: "tx1"}
+		minedTx := TestTx{TxId: "tx2"}
+		agedTx := TestTx{TxId: "tx3"}
+
+		m := NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		m.On("GetCoinType").Return(db.CoinTypeETH)
+		m.On("GetTransactions", []string{minedTx.TxId}).Once().Return([]TestTx{{TxId: minedTx.TxId, Confirmations: 1}}, error(nil))
+		m.On("GetTransactions", []string{agedTx.TxId}).Once().Return([]TestTx{agedTx}, error(nil))
+		client := &testPoolFetcherClient{MockSharedDaemonRpcClient: m, newTxs: [][]TestTx{{pendingTx, minedTx, agedTx}}}
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, client)
+		bdrce.syncTransactionPool()
+		for _, tx := range []TestTx{pendingTx, minedTx, agedTx} {
+			bdrce.WatchTx(tx)
+		}
+
+		bdrce.transactionPoolSync.remove([]string{minedTx.TxId})
+		bdrce.transactionPoolSync.txs[agedTx.TxId] = time.Now().Add(-max_pool_tx_age - time.Minute)
+
+		bdrce.syncTransactionPool()
+		// Neither the pending nor the refreshed aged tx is looked up again.
+		bdrce.syncTransactionPool()
+
+		assert.Equal(t, map[string]watchedTx{pendingTx.TxId: {}, agedTx.TxId: {}}, bdrce.transactionPoolSync.watched())
+		assert.True(t, bdrce.transactionPoolSync.contains(agedTx.TxId))
+	})
+}
+
+type testSubscription struct {
+	errCn chan error
+	once  sync.Once
+}
+
+func (s *testSubscription) Err() <-chan error {
+	return s.errCn
+}
+func (s *testSubscription) Unsubscribe() {
+	s.once.Do(func() { close(s.errCn) })
+}
+
+// testSubscriberClient notifies the executor about new blocks through newBlockCn.
+type testSubscriberClient struct {
+	*MockSharedDaemonRpcClient[TestTx, TestBlock]
+
+	subscriptions atomic.Int32
+	subscribeErr  atomic.Pointer[error]
+	subCn         chan *testSubscription
+	newBlockCn    chan<- uint64
+}
+
+func (c *testSubscriberClient) SupportsBlockSubscription() bool {
+	return true
+}
+func (c *testSubscriberClient) SubscribeNewBlocks(ctx context.Context, cn chan<- uint64) (Subscription, error) {
+	c.subscriptions.Add(1)
+	if err := c.subscribeErr.Load(); err != nil {
+		return nil, *err
+	}
+	c.newBlockCn = cn
+
+	sub := &testSubscription{errCn: make(chan error, 1)}
+	c.subCn <- sub
+	return sub, nil
+}
+
+func TestSyncBlocks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Sync On New Block Notification", func(t *testing.T) {
+		d := &testSubscriberClient{MockSharedDaemonRpcClient: NewMockSharedDaemonRpcClient[TestTx, TestBlock](t), subCn: make(chan *testSubscription, 1)}
+		d.On("GetCoinType").Return(db.CoinTypeETH)
+		d.On("GetLastBlockHeight").Return(uint64(10), error(nil)).Once()
+		d.On("GetLastBlockHeight").Return(uint64(11), error(nil))
+		d.On("GetBlockByHeight", uint64(10)).Return(TestBlock{Height: 10, Hash: "a10", ParentHash: "a9"}, error(nil))
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+		bdrce.blockSync.lastBlockHeight.Store(10)
+		blockCn := bdrce.NewBlockChan()
+
+		bdrce.ctx, bdrce.cancel = context.WithCancel(context.Background())
+		defer bdrce.Stop()
+		go bdrce.syncBlocks(time.Hour)
+
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(d.subCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		d.newBlockCn <- 10
+
+		block := test.GetValueFromCnOrLogFatalWithTimeout(blockCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, uint64(10), block.Height)
+		assert.Equal(t, int32(1), d.subscriptions.Load())
+	})
+
+	t.Run("Should Fall Back To Polling When The Subscription Drops", func(t *testing.T) {
+		polledCn := make(chan struct{}, 1)
+
+		d := &testSubscriberClient{MockSharedDaemonRpcClient: NewMockSharedDaemonRpcClient[TestTx, TestBlock](t), subCn: make(chan *testSubscription, 1)}
+		d.On("GetCoinType").Return(db.CoinTypeETH)
+		d.On("GetLastBlockHeight").Return(uint64(0), error(nil)).Run(func(args mock.Arguments) {
+			select {
+			case polledCn <- struct{}{}:
+			default:
+			}
+		})
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+
+		bdrce.ctx, bdrce.cancel = context.WithCancel(context.Background())
+		defer bdrce.Stop()
+		go bdrce.syncBlocks(100 * time.Millisecond)
+
+		sub := test.GetValueFromCnOrLogFatalWithTimeout(d.subCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(polledCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+
+		subscribeErr := errors.New("connection refused")
+		d.subscribeErr.Store(&subscribeErr)
+		sub.errCn <- errors.New("connection lost")
+
+		// The chain tip is polled while the subscription can't be restored.
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(polledCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+
+		d.subscribeErr.Store(nil)
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(d.subCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Greater(t, d.subscriptions.Load(), int32(2))
+	})
+}
+
+// testTxSubscriberClient pushes the mempool txs to the executor through newTxCn.
+type testTxSubscriberClient struct {
+	*MockSharedDaemonRpcClient[TestTx, TestBlock]
+
+	subCn   chan *testSubscription
+	newTxCn chan<- TestTx
+}
+
+func (c *testTxSubscriberClient) SupportsTxSubscription() bool {
+	return true
+}
+func (c *testTxSubscriberClient) SubscribeNewTxs(ctx context.Context, cn chan<- TestTx) (Subscription, error) {
+	c.newTxCn = cn
+
+	sub := &testSubscription{errCn: make(chan error, 1)}
+	c.subCn <- sub
+	return sub, nil
+}
+
+func TestSyncTransactions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Broadcast The Pushed Txs Without Fetching Them", func(t *testing.T) {
+		d := &testTxSubscriberClient{MockSharedDaemonRpcClient: NewMockSharedDaemonRpcClient[TestTx, TestBlock](t), subCn: make(chan *testSubscription, 1)}
+		d.On("GetCoinType").Return(db.CoinTypeBTC)
+		d.On("GetTransactionPool").Return([]string{}, error(nil)).Once()
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+		txCn := bdrce.NewTxPoolChan()
+
+		bdrce.ctx, bdrce.cancel = context.WithCancel(context.Background())
+		defer bdrce.Stop()
+		go bdrce.syncTransactions(time.Hour)
+
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(d.subCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		pushedTx := TestTx{TxId: uuid.NewString()}
+		d.newTxCn <- pushedTx
+
+		tx := test.GetValueFromCnOrLogFatalWithTimeout(txCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, pushedTx, tx)
+	})
+
+	t.Run("Should Poll The Mempool When The Subscription Drops", func(t *testing.T) {
+		polledCn := make(chan struct{}, 1)
+
+		d := &testTxSubscriberClient{MockSharedDaemonRpcClient: NewMockSharedDaemonRpcClient[TestTx, TestBlock](t), subCn: make(chan *testSubscription, 1)}
+		d.On("GetCoinType").Return(db.CoinTypeBTC)
+		d.On("GetTransactionPool").Return([]string{}, error(nil)).Run(func(args mock.Arguments) {
+			select {
+			case polledCn <- struct{}{}:
+			default:
+			}
+		})
+
+		bdrce := NewBaseDaemonRpcClientExecutor(&zerolog.Logger{}, d)
+
+		bdrce.ctx, bdrce.cancel = context.WithCancel(context.Background())
+		defer bdrce.Stop()
+		go bdrce.syncTransactions(100 * time.Millisecond)
+
+		sub := test.GetValueFromCnOrLogFatalWithTimeout(d.subCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(polledCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+
+		sub.errCn <- errors.New("connection lost")
+
+		// The subscription is restored on the next tick and the mempool is synced again.
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(d.subCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(polledCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+	})
 }
@@ -16,6 +16,14 @@ func (b BNBBlock) GetTxHashes() []string {
 	return ETHBlock(b).GetTxHashes()
 }
 
+func (b BNBBlock) GetHash() string {
+	return ETHBlock(b).GetHash()
+}
+
+func (b BNBBlock) GetParentHash() string {
+	return ETHBlock(b).GetParentHash()
+}
+
 type BNBTx ETHTx
 
 func (t BNBTx) GetTxId() string {
@@ -27,6 +35,9 @@ func (t BNBTx) GetConfirmations() uint64 {
 func (t BNBTx) IsDoubleSpendSeen() bool {
 	return ETHTx(t).IsDoubleSpendSeen()
 }
+func (t BNBTx) GetBlockHeight() (uint64, bool) {
+	return ETHTx(t).GetBlockHeight()
+}
 
 type SharedBNBDaemonRpcClient struct {
 	SharedETHDaemonRpcClient
@@ -40,6 +51,14 @@ func (c *SharedBNBDaemonRpcClient) GetTransactions(txHashes []string) ([]BNBTx,
 	res, err := c.SharedETHDaemonRpcClient.GetTransactions(txHashes)
 	return *(*[]BNBTx)(unsafe.Pointer(&res)), err
 }
+func (c *SharedBNBDaemonRpcClient) GetNewPoolTransactions() ([]BNBTx, error) {
+	res, err := c.SharedETHDaemonRpcClient.GetNewPoolTransactions()
+	return *(*[]BNBTx)(unsafe.Pointer(&res)), err
+}
+func (c *SharedBNBDaemonRpcClient) GetBlockTransactionsTo(block BNBBlock, addresses []string, tokenContracts []string) ([]BNBTx, error) {
+	res, err := c.SharedETHDaemonRpcClient.GetBlockTransactionsTo(ETHBlock(block), addresses, tokenContracts)
+	return *(*[]BNBTx)(unsafe.Pointer(&res)), err
+}
 func (c *SharedBNBDaemonRpcClient) GetNetworkType() (NetworkType, error) {
 	res, err := c.SharedETHDaemonRpcClient.client.NetworkID(context.Background())
 	if err != nil {
@@ -63,7 +82,7 @@ func (c *SharedBNBDaemonRpcClient) GetCoinType() db.CoinType {
 
 func NewSharedBNBDaemonRpcClient(client *ethclient.Client) *SharedBNBDaemonRpcClient {
 	return &SharedBNBDaemonRpcClient{
-		SharedETHDaemonRpcClient: *NewSharedETHDaemonRpcClient(client),
+		SharedETHDaemonRpcClient: SharedETHDaemonRpcClient{client: client},
 	}
 }
 
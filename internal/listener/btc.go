@@ -1,9 +1,20 @@
 package listener
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
 	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/util"
@@ -21,7 +32,19 @@ func (b BTCBlock) GetTxHashes() []string {
 	return txHashes
 }
 
-type BTCTx btcjson.TxRawResult
+func (b BTCBlock) GetHash() string {
+	return b.Header.BlockHash().String()
+}
+
+func (b BTCBlock) GetParentHash() string {
+	return b.Header.PrevBlock.String()
+}
+
+// BTCTx is the verbose tx of the daemon. It doesn't return the height of the block, so it's resolved from the block hash.
+type BTCTx struct {
+	btcjson.TxRawResult
+	BlockHeight uint64
+}
 
 func (t BTCTx) GetTxId() string {
 	return t.Txid
@@ -32,9 +55,103 @@ func (t BTCTx) GetConfirmations() uint64 {
 func (t BTCTx) IsDoubleSpendSeen() bool {
 	return false
 }
+func (t BTCTx) GetBlockHeight() (uint64, bool) {
+	return t.BlockHeight, t.BlockHash != ""
+}
+
+// GetSpentOutpoints returns the "txid:vout" outpoints spent by the tx. A BIP125 replacement spends at least one of them.
+func (t BTCTx) GetSpentOutpoints() []string {
+	outpoints := make([]string, 0, len(t.Vin))
+	for i := 0; i < len(t.Vin); i++ {
+		if t.Vin[i].IsCoinBase() {
+			continue
+		}
+		outpoints = append(outpoints, fmt.Sprintf("%v:%v", t.Vin[i].Txid, t.Vin[i].Vout))
+	}
+
+	return outpoints
+}
+
+// decodeBTCTx builds the tx the daemon would return for the raw tx pushed over ZMQ. The tx is assumed to be unconfirmed.
+func decodeBTCTx(rawTx []byte, params *chaincfg.Params) (BTCTx, error) {
+	var msgTx wire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return BTCTx{}, err
+	}
+
+	tx := BTCTx{TxRawResult: btcjson.TxRawResult{
+		Hex:      hex.EncodeToString(rawTx),
+		Txid:     msgTx.TxHash().String(),
+		Hash:     msgTx.WitnessHash().String(),
+		Size:     int32(len(rawTx)),
+		Version:  uint32(msgTx.Version),
+		LockTime: msgTx.LockTime,
+		Vin:      make([]btcjson.Vin, 0, len(msgTx.TxIn)),
+		Vout:     make([]btcjson.Vout, 0, len(msgTx.TxOut)),
+	}}
+
+	for i := 0; i < len(msgTx.TxIn); i++ {
+		txIn := msgTx.TxIn[i]
+		if len(msgTx.TxIn) == 1 && txIn.PreviousOutPoint.Index == math.MaxUint32 && txIn.PreviousOutPoint.Hash == (chainhash.Hash{}) {
+			tx.Vin = append(tx.Vin, btcjson.Vin{Coinbase: hex.EncodeToString(txIn.SignatureScript), Sequence: txIn.Sequence})
+			continue
+		}
+		tx.Vin = append(tx.Vin, btcjson.Vin{Txid: txIn.PreviousOutPoint.Hash.String(), Vout: txIn.PreviousOutPoint.Index, Sequence: txIn.Sequence})
+	}
+
+	for i := 0; i < len(msgTx.TxOut); i++ {
+		txOut := msgTx.TxOut[i]
+		class, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, params)
+		if err != nil {
+			return BTCTx{}, err
+		}
+
+		scriptPubKey := btcjson.ScriptPubKeyResult{Hex: hex.EncodeToString(txOut.PkScript), Type: class.String()}
+		if len(addrs) == 1 {
+			scriptPubKey.Address = addrs[0].EncodeAddress()
+		}
+		tx.Vout = append(tx.Vout, btcjson.Vout{Value: btcutil.Amount(txOut.Value).ToBTC(), N: uint32(i), ScriptPubKey: scriptPubKey})
+	}
+
+	return tx, nil
+}
 
 type SharedBTCDaemonRpcClient struct {
+	log    *zerolog.Logger
 	client *rpcclient.Client
+	zmq    ZMQConfig
+
+	mu sync.Mutex
+	// params are used to decode the addresses of the txs pushed over ZMQ.
+	params *chaincfg.Params
+}
+
+func (c *SharedBTCDaemonRpcClient) chainParams() (*chaincfg.Params, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.params != nil {
+		return c.params, nil
+	}
+
+	net, err := c.GetNetworkType()
+	if err != nil {
+		return nil, err
+	}
+	switch net {
+	case MainnetBTC:
+		c.params = &chaincfg.MainNetParams
+	case TestnetBTC:
+		c.params = &chaincfg.TestNet3Params
+	case SignetBTC:
+		c.params = &chaincfg.SigNetParams
+	case RegtestBTC:
+		c.params = &chaincfg.RegressionNetParams
+	default:
+		return nil, util.InvalidNetworkTypeErr
+	}
+
+	return c.params, nil
 }
 
 func (c *SharedBTCDaemonRpcClient) GetLastBlockHeight() (uint64, error) {
@@ -91,13 +208,56 @@ func (c *SharedBTCDaemonRpcClient) GetTransactions(txHashes []string) ([]BTCTx,
 	for i := 0; i < txHashesCount; i++ {
 		tx, err := txsAsync[i].Receive()
 		if err != nil {
+			// The tx is unknown to the daemon (e.g. it was evicted from the mempool).
+			var rpcErr *btcjson.RPCError
+			if errors.As(err, &rpcErr) && rpcErr.Code == btcjson.ErrRPCNoTxInfo {
+				continue
+			}
 			return nil, err
 		}
-		txs = append(txs, BTCTx(*tx))
+		txs = append(txs, BTCTx{TxRawResult: *tx})
+	}
+
+	if err := c.resolveBlockHeights(txs); err != nil {
+		return nil, err
 	}
 
 	return txs, nil
 }
+
+// resolveBlockHeights sets the block heights of the confirmed txs with a header request per block.
+func (c *SharedBTCDaemonRpcClient) resolveBlockHeights(txs []BTCTx) error {
+	headersAsync := make(map[string]rpcclient.FutureGetBlockHeaderVerboseResult)
+	for i := 0; i < len(txs); i++ {
+		if txs[i].BlockHash == "" {
+			continue
+		}
+		if _, ok := headersAsync[txs[i].BlockHash]; ok {
+			continue
+		}
+
+		hash, err := chainhash.NewHashFromStr(txs[i].BlockHash)
+		if err != nil {
+			return err
+		}
+		headersAsync[txs[i].BlockHash] = c.client.GetBlockHeaderVerboseAsync(hash)
+	}
+
+	heights := make(map[string]uint64, len(headersAsync))
+	for blockHash, headerAsync := range headersAsync {
+		header, err := headerAsync.Receive()
+		if err != nil {
+			return err
+		}
+		heights[blockHash] = uint64(header.Height)
+	}
+
+	for i := 0; i < len(txs); i++ {
+		txs[i].BlockHeight = heights[txs[i].BlockHash]
+	}
+
+	return nil
+}
 func (c *SharedBTCDaemonRpcClient) GetNetworkType() (NetworkType, error) {
 	res, err := c.client.GetBlockChainInfo()
 	if err != nil {
@@ -125,16 +285,61 @@ func (c *SharedBTCDaemonRpcClient) GetCoinType() db.CoinType {
 	return db.CoinTypeBTC
 }
 
-func NewSharedBTCDaemonRpcClient(client *rpcclient.Client) *SharedBTCDaemonRpcClient {
-	return &SharedBTCDaemonRpcClient{client: client}
+func (c *SharedBTCDaemonRpcClient) SupportsBlockSubscription() bool {
+	return c.zmq.HashBlock != ""
+}
+
+// SubscribeNewBlocks subscribes to the hashblock ZMQ topic. The height isn't part of the notification, so 0 is sent.
+func (c *SharedBTCDaemonRpcClient) SubscribeNewBlocks(ctx context.Context, cn chan<- uint64) (Subscription, error) {
+	return subscribeZMQ(ctx, c.log, c.zmq.HashBlock, zmq_hash_block_topic, func(body []byte, quit <-chan struct{}) {
+		if len(body) != chainhash.HashSize {
+			c.log.Warn().Str("coin", string(c.GetCoinType())).Msgf("Skipped the malformed block hash of %v bytes pushed over ZMQ", len(body))
+			return
+		}
+
+		select {
+		case cn <- 0:
+		case <-quit:
+		}
+	})
+}
+
+func (c *SharedBTCDaemonRpcClient) SupportsTxSubscription() bool {
+	return c.zmq.RawTx != ""
+}
+
+// SubscribeNewTxs subscribes to the rawtx ZMQ topic and decodes the pushed txs locally.
+func (c *SharedBTCDaemonRpcClient) SubscribeNewTxs(ctx context.Context, cn chan<- BTCTx) (Subscription, error) {
+	params, err := c.chainParams()
+	if err != nil {
+		return nil, err
+	}
+
+	return subscribeZMQ(ctx, c.log, c.zmq.RawTx, zmq_raw_tx_topic, func(body []byte, quit <-chan struct{}) {
+		tx, err := decodeBTCTx(body, params)
+		// The daemon only publishes the txs it accepted, so a malformed one isn't expected.
+		if err != nil {
+			c.log.Err(err).Str("coin", string(c.GetCoinType())).Msg("An error occurred while decoding the tx pushed over ZMQ.")
+			return
+		}
+
+		select {
+		case cn <- tx:
+		case <-quit:
+		}
+	})
+}
+
+func NewSharedBTCDaemonRpcClient(log *zerolog.Logger, client *rpcclient.Client, zmq ZMQConfig) *SharedBTCDaemonRpcClient {
+	return &SharedBTCDaemonRpcClient{log: log, client: client, zmq: zmq}
 }
 
 type BTCDaemonRpcClientExecutor struct {
 	BaseDaemonRpcClientExecutor[BTCTx, BTCBlock]
 }
 
-func NewBTCDaemonRpcClientExecutor(log *zerolog.Logger, client *rpcclient.Client) *BTCDaemonRpcClientExecutor {
+func NewBTCDaemonRpcClientExecutor(log *zerolog.Logger, client *rpcclient.Client, zmq ZMQConfig) *BTCDaemonRpcClientExecutor {
 	return &BTCDaemonRpcClientExecutor{
-		BaseDaemonRpcClientExecutor: *NewBaseDaemonRpcClientExecutor(log, NewSharedBTCDaemonRpcClient(client)),
+		BaseDaemonRpcClientExecutor: *NewBaseDaemonRpcClientExecutor(log, NewSharedBTCDaemonRpcClient(log, client, zmq)),
 	}
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/chekist32/goipay/internal/db"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +28,7 @@ func getBTCDaemonRpcClient() *rpcclient.Client {
 
 func TestBTCGetLastBlockHeight(t *testing.T) {
 	// Given
-	d := NewSharedBTCDaemonRpcClient(getBTCDaemonRpcClient())
+	d := NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, getBTCDaemonRpcClient(), ZMQConfig{})
 
 	// When
 	height, err := d.GetLastBlockHeight()
@@ -40,7 +41,7 @@ func TestBTCGetLastBlockHeight(t *testing.T) {
 func TestBTCGetBlockByHeight(t *testing.T) {
 	// Given
 	expectedBlockHash := "00000000000000000001aae6eacd54e7784b71010f62e0797c4dc2ef8a6963a7"
-	d := NewSharedBTCDaemonRpcClient(getBTCDaemonRpcClient())
+	d := NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, getBTCDaemonRpcClient(), ZMQConfig{})
 
 	// When
 	block, err := d.GetBlockByHeight(877485)
@@ -53,7 +54,7 @@ func TestBTCGetBlockByHeight(t *testing.T) {
 
 func TestBTCGeTransactionPool(t *testing.T) {
 	// Given
-	d := NewSharedBTCDaemonRpcClient(getBTCDaemonRpcClient())
+	d := NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, getBTCDaemonRpcClient(), ZMQConfig{})
 
 	// When
 	_, err := d.GetTransactionPool()
@@ -64,7 +65,7 @@ func TestBTCGeTransactionPool(t *testing.T) {
 
 func TestBTCGetTransactions(t *testing.T) {
 	// Given
-	d := NewSharedBTCDaemonRpcClient(getBTCDaemonRpcClient())
+	d := NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, getBTCDaemonRpcClient(), ZMQConfig{})
 	expectedTxId := "179a771d8d84193b23e4a9853e4d158c81a8a41dcd86e9ff220d385a0acac6a7"
 
 	// When
@@ -80,7 +81,7 @@ func TestBTCGetTransactions(t *testing.T) {
 
 func TestBTCGetNetworkType(t *testing.T) {
 	// Given
-	d := NewSharedBTCDaemonRpcClient(getBTCDaemonRpcClient())
+	d := NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, getBTCDaemonRpcClient(), ZMQConfig{})
 
 	// When
 	net, err := d.GetNetworkType()
@@ -92,7 +93,7 @@ func TestBTCGetNetworkType(t *testing.T) {
 
 func TestBTCGetCoinType(t *testing.T) {
 	// Given
-	d := NewSharedBTCDaemonRpcClient(getBTCDaemonRpcClient())
+	d := NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, getBTCDaemonRpcClient(), ZMQConfig{})
 
 	// When
 	coin := d.GetCoinType()
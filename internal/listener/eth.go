@@ -2,16 +2,33 @@ package listener
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"math"
 	"math/big"
+	"sync"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/util"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/zerolog"
 )
 
+const (
+	// erc20_transfer_topic is the signature of the ERC20/BEP20 Transfer(address,address,uint256) event.
+	erc20_transfer_topic string = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	// erc20_decimals_method_id is the selector of decimals().
+	erc20_decimals_method_id string = "0x313ce567"
+)
+
+var InvalidTokenDecimalsErr error = errors.New("the contract returned invalid decimals")
+
 type ETHBlock struct {
 	block *types.Block
 }
@@ -25,11 +42,22 @@ func (b ETHBlock) GetTxHashes() []string {
 	return txHashes
 }
 
+func (b ETHBlock) GetHash() string {
+	return b.block.Hash().Hex()
+}
+
+func (b ETHBlock) GetParentHash() string {
+	return b.block.ParentHash().Hex()
+}
+
 type ETHTx struct {
 	Tx            *types.Transaction
 	Status        uint8
 	Confirmations uint64
+	BlockNumber   uint64
 	Logs          []*types.Log
+	// Pending is set if the tx is still in the mempool. Such a tx has no receipt, so neither the status nor the logs are known.
+	Pending bool
 }
 
 func (t ETHTx) GetTxId() string {
@@ -39,11 +67,17 @@ func (t ETHTx) GetConfirmations() uint64 {
 	return t.Confirmations
 }
 func (t ETHTx) IsDoubleSpendSeen() bool {
-	return t.Status == 0
+	return !t.Pending && t.Status == 0
+}
+func (t ETHTx) GetBlockHeight() (uint64, bool) {
+	return t.BlockNumber, !t.Pending
 }
 
 type SharedETHDaemonRpcClient struct {
 	client *ethclient.Client
+
+	mu                sync.Mutex
+	pendingTxFilterId string
 }
 
 func (c *SharedETHDaemonRpcClient) GetLastBlockHeight() (uint64, error) {
@@ -62,8 +96,74 @@ func (c *SharedETHDaemonRpcClient) GetBlockByHeight(height uint64) (ETHBlock, er
 
 	return ETHBlock{block: block}, nil
 }
+
+// GetTransactionPool returns the hashes of the txs which entered the mempool since the previous call.
 func (c *SharedETHDaemonRpcClient) GetTransactionPool() ([]string, error) {
-	return []string{}, nil
+	txs, err := c.GetNewPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	txHashes := make([]string, 0, len(txs))
+	for i := 0; i < len(txs); i++ {
+		txHashes = append(txHashes, txs[i].GetTxId())
+	}
+
+	return txHashes, nil
+}
+
+// GetNewPoolTransactions returns the txs which entered the mempool since the previous call. The pending tx filter
+// is installed on the first call and installed again once the node drops it. It asks for the full txs, but the nodes
+// which ignore that report the hashes, whose txs are then fetched with a single batch call.
+func (c *SharedETHDaemonRpcClient) GetNewPoolTransactions() ([]ETHTx, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pendingTxFilterId == "" {
+		if err := c.client.Client().CallContext(context.Background(), &c.pendingTxFilterId, "eth_newPendingTransactionFilter", true); err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []json.RawMessage
+	if err := c.client.Client().CallContext(context.Background(), &changes, "eth_getFilterChanges", c.pendingTxFilterId); err != nil {
+		// Nodes uninstall the filters which haven't been polled for a while.
+		c.pendingTxFilterId = ""
+		return nil, err
+	}
+
+	txs := make([]ETHTx, 0, len(changes))
+	batch := make([]rpc.BatchElem, 0)
+	for i := 0; i < len(changes); i++ {
+		var hash common.Hash
+		if err := json.Unmarshal(changes[i], &hash); err == nil {
+			batch = append(batch, rpc.BatchElem{Method: "eth_getTransactionByHash", Args: []any{hash}, Result: new(*types.Transaction)})
+			continue
+		}
+
+		tx := new(types.Transaction)
+		if err := json.Unmarshal(changes[i], tx); err != nil {
+			return nil, err
+		}
+		txs = append(txs, ETHTx{Tx: tx, Pending: true})
+	}
+	if len(batch) == 0 {
+		return txs, nil
+	}
+
+	if err := c.client.Client().BatchCallContext(context.Background(), batch); err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(batch); i++ {
+		tx := *batch[i].Result.(**types.Transaction)
+		// The tx left the mempool in between.
+		if batch[i].Error != nil || tx == nil {
+			continue
+		}
+		txs = append(txs, ETHTx{Tx: tx, Pending: true})
+	}
+
+	return txs, nil
 }
 func (c *SharedETHDaemonRpcClient) GetTransactions(txHashes []string) ([]ETHTx, error) {
 	txHashesCount := len(txHashes)
@@ -80,10 +180,19 @@ func (c *SharedETHDaemonRpcClient) GetTransactions(txHashes []string) ([]ETHTx,
 
 	txs := make([]ETHTx, 0, txHashesCount)
 	for i := 0; i < txHashesCount; i++ {
-		tx, _, err := c.client.TransactionByHash(context.Background(), hashes[i])
+		tx, isPending, err := c.client.TransactionByHash(context.Background(), hashes[i])
 		if err != nil {
+			// The tx is unknown to the node (e.g. it was evicted from the mempool).
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			}
 			return nil, err
 		}
+		if isPending {
+			txs = append(txs, ETHTx{Tx: tx, Pending: true})
+			continue
+		}
+
 		txReceipt, err := c.client.TransactionReceipt(context.Background(), hashes[i])
 		if err != nil {
 			return nil, err
@@ -93,11 +202,151 @@ func (c *SharedETHDaemonRpcClient) GetTransactions(txHashes []string) ([]ETHTx,
 			Status:        uint8(txReceipt.Status),
 			Logs:          txReceipt.Logs,
 			Confirmations: lastBlockHeight - txReceipt.BlockNumber.Uint64(),
+			BlockNumber:   txReceipt.BlockNumber.Uint64(),
+		})
+	}
+
+	return txs, nil
+}
+
+// GetBlockTransactionsTo finds the token transfers to the addresses with a single eth_getLogs call. Their logs are taken
+// from the filter, so only the receipts of the native transfers to the addresses are fetched.
+func (c *SharedETHDaemonRpcClient) GetBlockTransactionsTo(block ETHBlock, addresses []string, tokenContracts []string) ([]ETHTx, error) {
+	// An empty filter would match every transfer.
+	if len(addresses) == 0 {
+		return []ETHTx{}, nil
+	}
+
+	watched := make(map[common.Address]bool, len(addresses))
+	recipients := make([]common.Hash, 0, len(addresses))
+	for i := 0; i < len(addresses); i++ {
+		addr := common.HexToAddress(addresses[i])
+		watched[addr] = true
+		recipients = append(recipients, common.BytesToHash(addr.Bytes()))
+	}
+
+	nativeTxHashes := make([]string, 0)
+	for _, tx := range block.block.Transactions() {
+		if to := tx.To(); to != nil && watched[*to] && tx.Value().Sign() > 0 {
+			nativeTxHashes = append(nativeTxHashes, tx.Hash().Hex())
+		}
+	}
+
+	txs, err := c.GetTransactions(nativeTxHashes)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokenContracts) == 0 {
+		return txs, nil
+	}
+
+	contracts := make([]common.Address, 0, len(tokenContracts))
+	for i := 0; i < len(tokenContracts); i++ {
+		contracts = append(contracts, common.HexToAddress(tokenContracts[i]))
+	}
+
+	blockHash := block.block.Hash()
+	logs, err := c.client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		BlockHash: &blockHash,
+		Addresses: contracts,
+		Topics:    [][]common.Hash{{common.HexToHash(erc20_transfer_topic)}, nil, recipients},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return txs, nil
+	}
+
+	lastBlockHeight, err := c.GetLastBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	fetched := make(map[common.Hash]bool, len(txs))
+	for i := 0; i < len(txs); i++ {
+		fetched[txs[i].Tx.Hash()] = true
+	}
+
+	tokenTxs := make(map[common.Hash]int)
+	for i := 0; i < len(logs); i++ {
+		if logs[i].Removed || fetched[logs[i].TxHash] {
+			continue
+		}
+
+		if j, ok := tokenTxs[logs[i].TxHash]; ok {
+			txs[j].Logs = append(txs[j].Logs, &logs[i])
+			continue
+		}
+
+		tx := block.block.Transaction(logs[i].TxHash)
+		if tx == nil {
+			continue
+		}
+		// Only the successful txs emit logs.
+		tokenTxs[logs[i].TxHash] = len(txs)
+		txs = append(txs, ETHTx{
+			Tx:            tx,
+			Status:        uint8(types.ReceiptStatusSuccessful),
+			Logs:          []*types.Log{&logs[i]},
+			Confirmations: lastBlockHeight - block.block.NumberU64(),
+			BlockNumber:   block.block.NumberU64(),
 		})
 	}
 
 	return txs, nil
 }
+
+// GetTokenDecimals calls decimals() of the ERC20/BEP20 contract.
+func (c *SharedETHDaemonRpcClient) GetTokenDecimals(contractAddress string) (uint8, error) {
+	contract := common.HexToAddress(contractAddress)
+	res, err := c.client.CallContract(context.Background(), ethereum.CallMsg{To: &contract, Data: hexutil.MustDecode(erc20_decimals_method_id)}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// An address without code returns nothing.
+	if len(res) != common.HashLength {
+		return 0, InvalidTokenDecimalsErr
+	}
+	decimals := new(big.Int).SetBytes(res)
+	if !decimals.IsUint64() || decimals.Uint64() > math.MaxUint8 {
+		return 0, InvalidTokenDecimalsErr
+	}
+
+	return uint8(decimals.Uint64()), nil
+}
+
+// SupportsBlockSubscription reports whether the client is connected over ws:// or wss://.
+func (c *SharedETHDaemonRpcClient) SupportsBlockSubscription() bool {
+	return c.client.Client().SupportsSubscriptions()
+}
+func (c *SharedETHDaemonRpcClient) SubscribeNewBlocks(ctx context.Context, cn chan<- uint64) (Subscription, error) {
+	headers := make(chan *types.Header)
+	headSub, err := c.client.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer headSub.Unsubscribe()
+
+		for {
+			select {
+			case header := <-headers:
+				select {
+				case cn <- header.Number.Uint64():
+				case <-quit:
+					return nil
+				}
+			case err := <-headSub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
 func (c *SharedETHDaemonRpcClient) GetNetworkType() (NetworkType, error) {
 	netVer, err := c.client.NetworkID(context.Background())
 	if err != nil {
@@ -0,0 +1,468 @@
+package listener
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// testETHService serves the subset of the eth namespace used by the client.
+type testETHService struct {
+	mu         sync.Mutex
+	filterId   string
+	pendingTxs []*types.Transaction
+	// changes are the pending txs which haven't been polled yet.
+	changes []*types.Transaction
+	// hashOnly makes the pending tx filter report the hashes even if the full txs are asked for.
+	hashOnly bool
+	// fullTxFilter is set if the full txs were asked for.
+	fullTxFilter bool
+	headers      chan *types.Header
+
+	// minedBlock holds minedTxs and emitted logs.
+	minedBlock   *types.Block
+	minedTxs     []*types.Transaction
+	logs         []types.Log
+	getLogsCalls int
+	receiptCalls int
+
+	// tokenDecimals are returned by decimals() of the contracts.
+	tokenDecimals map[common.Address]uint8
+	chainId       uint64
+}
+
+// testCallArgs is the call ethclient sends to eth_call.
+type testCallArgs struct {
+	To    *common.Address `json:"to"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// testLogFilter is the filter ethclient sends to eth_getLogs.
+type testLogFilter struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	Addresses []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+func (s *testETHService) BlockNumber() hexutil.Uint64 {
+	return 100
+}
+
+func (s *testETHService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(s.chainId))
+}
+
+func (s *testETHService) NewPendingTransactionFilter(fullTx *bool) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.filterId = uuid.NewString()
+	s.fullTxFilter = fullTx != nil && *fullTx
+	return s.filterId
+}
+
+func (s *testETHService) GetFilterChanges(id string) ([]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id != s.filterId {
+		return nil, errors.New("filter not found")
+	}
+
+	changes := make([]any, 0, len(s.changes))
+	for i := 0; i < len(s.changes); i++ {
+		if s.hashOnly || !s.fullTxFilter {
+			changes = append(changes, s.changes[i].Hash())
+			continue
+		}
+		changes = append(changes, s.changes[i])
+	}
+	s.changes = nil
+
+	return changes, nil
+}
+
+func (s *testETHService) GetTransactionByHash(hash common.Hash) (map[string]any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	toRpcTx := func(tx *types.Transaction) (map[string]any, error) {
+		data, err := tx.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+
+		var rpcTx map[string]any
+		return rpcTx, json.Unmarshal(data, &rpcTx)
+	}
+
+	for i := 0; i < len(s.pendingTxs); i++ {
+		if s.pendingTxs[i].Hash() == hash {
+			return toRpcTx(s.pendingTxs[i])
+		}
+	}
+
+	for i := 0; i < len(s.minedTxs); i++ {
+		if s.minedTxs[i].Hash() == hash {
+			rpcTx, err := toRpcTx(s.minedTxs[i])
+			if err != nil {
+				return nil, err
+			}
+			rpcTx["blockHash"] = s.minedBlock.Hash()
+			rpcTx["blockNumber"] = hexutil.Uint64(s.minedBlock.NumberU64())
+
+			return rpcTx, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *testETHService) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.receiptCalls++
+
+	for i := 0; i < len(s.minedTxs); i++ {
+		if s.minedTxs[i].Hash() == hash {
+			return &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      hash,
+				BlockHash:   s.minedBlock.Hash(),
+				BlockNumber: s.minedBlock.Number(),
+				Logs:        []*types.Log{},
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *testETHService) GetLogs(filter testLogFilter) ([]types.Log, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.getLogsCalls++
+
+	if filter.BlockHash == nil || *filter.BlockHash != s.minedBlock.Hash() || len(filter.Topics) != 3 {
+		return nil, errors.New("unexpected filter")
+	}
+
+	logs := make([]types.Log, 0)
+	for i := 0; i < len(s.logs); i++ {
+		if slices.Contains(filter.Addresses, s.logs[i].Address) &&
+			slices.Contains(filter.Topics[0], s.logs[i].Topics[0]) &&
+			slices.Contains(filter.Topics[2], s.logs[i].Topics[2]) {
+			logs = append(logs, s.logs[i])
+		}
+	}
+
+	return logs, nil
+}
+
+func (s *testETHService) Call(args testCallArgs, block string) (hexutil.Bytes, error) {
+	if hexutil.Encode(args.Input) != erc20_decimals_method_id {
+		return nil, errors.New("unexpected call")
+	}
+
+	decimals, ok := s.tokenDecimals[*args.To]
+	if !ok {
+		return hexutil.Bytes{}, nil
+	}
+
+	return common.LeftPadBytes([]byte{decimals}, common.HashLength), nil
+}
+
+func (s *testETHService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+
+	sub := notifier.CreateSubscription()
+	go func() {
+		for {
+			select {
+			case header := <-s.headers:
+				notifier.Notify(sub.ID, header)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+func (s *testETHService) addPendingTx(tx *types.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pendingTxs = append(s.pendingTxs, tx)
+	s.changes = append(s.changes, tx)
+}
+
+func (s *testETHService) dropFilter() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.filterId = ""
+}
+
+func newTestETHClient(t *testing.T, service *testETHService) *ethclient.Client {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		log.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
+
+func newSignedTestETHTxTo(nonce uint64, to common.Address, value *big.Int, data []byte) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tx, err := types.SignNewTx(key, types.HomesteadSigner{}, &types.LegacyTx{Nonce: nonce, To: &to, Value: value, Gas: 100000, GasPrice: big.NewInt(1), Data: data})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return tx
+}
+
+func newSignedTestETHTx(nonce uint64) *types.Transaction {
+	return newSignedTestETHTxTo(nonce, common.HexToAddress("0x305c30dDc9DBCd1E831D8c894790AE0835B9D65d"), big.NewInt(1000), nil)
+}
+
+func TestETHGetTransactionPoolPendingFilter(t *testing.T) {
+	t.Run("Should Return The Txs Entered The Mempool Since The Previous Call", func(t *testing.T) {
+		service := &testETHService{}
+		d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+		txHashes, err := d.GetTransactionPool()
+		assert.NoError(t, err)
+		assert.Empty(t, txHashes)
+
+		tx := newSignedTestETHTx(0)
+		service.addPendingTx(tx)
+
+		txHashes, err = d.GetTransactionPool()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{tx.Hash().Hex()}, txHashes)
+
+		txHashes, err = d.GetTransactionPool()
+		assert.NoError(t, err)
+		assert.Empty(t, txHashes)
+	})
+
+	t.Run("Should Install The Filter Again After The Node Dropped It", func(t *testing.T) {
+		service := &testETHService{}
+		d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+		_, err := d.GetTransactionPool()
+		assert.NoError(t, err)
+
+		service.dropFilter()
+		_, err = d.GetTransactionPool()
+		assert.Error(t, err)
+
+		tx := newSignedTestETHTx(0)
+		service.addPendingTx(tx)
+
+		txHashes, err := d.GetTransactionPool()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{tx.Hash().Hex()}, txHashes)
+	})
+}
+
+func TestETHGetNewPoolTransactions(t *testing.T) {
+	t.Run("Should Return The Full Txs Reported By The Filter", func(t *testing.T) {
+		service := &testETHService{}
+		d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+		_, err := d.GetNewPoolTransactions()
+		assert.NoError(t, err)
+		assert.True(t, service.fullTxFilter)
+
+		tx := newSignedTestETHTx(0)
+		service.addPendingTx(tx)
+
+		txs, err := d.GetNewPoolTransactions()
+		assert.NoError(t, err)
+		assert.Len(t, txs, 1)
+		assert.Equal(t, tx.Hash().Hex(), txs[0].GetTxId())
+		assert.Equal(t, tx.Value(), txs[0].Tx.Value())
+		assert.True(t, txs[0].Pending)
+	})
+
+	t.Run("Should Fetch The Txs If The Node Reports Only The Hashes", func(t *testing.T) {
+		service := &testETHService{hashOnly: true}
+		d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+		_, err := d.GetNewPoolTransactions()
+		assert.NoError(t, err)
+
+		tx := newSignedTestETHTx(0)
+		service.addPendingTx(tx)
+		// The tx evicted before it's fetched is skipped.
+		service.changes = append(service.changes, newSignedTestETHTx(1))
+
+		txs, err := d.GetNewPoolTransactions()
+		assert.NoError(t, err)
+		assert.Len(t, txs, 1)
+		assert.Equal(t, tx.Hash().Hex(), txs[0].GetTxId())
+		assert.Equal(t, tx.Value(), txs[0].Tx.Value())
+		assert.True(t, txs[0].Pending)
+	})
+}
+
+func TestETHGetTransactionsPendingTx(t *testing.T) {
+	service := &testETHService{}
+	d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+	pendingTx := newSignedTestETHTx(0)
+	service.addPendingTx(pendingTx)
+
+	t.Run("Should Return The Pending Tx", func(t *testing.T) {
+		txs, err := d.GetTransactions([]string{pendingTx.Hash().Hex()})
+		assert.NoError(t, err)
+		assert.Len(t, txs, 1)
+		assert.Equal(t, pendingTx.Hash().Hex(), txs[0].GetTxId())
+		assert.True(t, txs[0].Pending)
+		assert.Equal(t, uint64(0), txs[0].GetConfirmations())
+		assert.False(t, txs[0].IsDoubleSpendSeen())
+	})
+
+	t.Run("Should Skip The Unknown Tx", func(t *testing.T) {
+		txs, err := d.GetTransactions([]string{newSignedTestETHTx(1).Hash().Hex()})
+		assert.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+}
+
+func TestETHSubscribeNewBlocks(t *testing.T) {
+	service := &testETHService{headers: make(chan *types.Header)}
+	d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+	t.Run("Should Send The Height Of The New Block", func(t *testing.T) {
+		assert.True(t, d.SupportsBlockSubscription())
+
+		blockCn := make(chan uint64)
+		sub, err := d.SubscribeNewBlocks(context.Background(), blockCn)
+		assert.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		service.headers <- &types.Header{Number: big.NewInt(21660612), Difficulty: big.NewInt(0)}
+
+		height := test.GetValueFromCnOrLogFatalWithTimeout(blockCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, uint64(21660612), height)
+	})
+
+	t.Run("Should Not Support Subscriptions Over HTTP", func(t *testing.T) {
+		client, err := ethclient.Dial("http://127.0.0.1:8545")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		assert.False(t, NewSharedETHDaemonRpcClient(client).SupportsBlockSubscription())
+	})
+}
+
+func TestETHGetBlockTransactionsTo(t *testing.T) {
+	watchedAddr := common.HexToAddress("0x35df6C0ECA8AE63D489cd28ECfeA811fA8Fc5Bb1")
+	otherAddr := common.HexToAddress("0x06Ac0C1C504218af3448E00ba1924455183D042C")
+	contract := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+
+	nativeTx := newSignedTestETHTxTo(0, watchedAddr, big.NewInt(1000), nil)
+	tokenTx := newSignedTestETHTxTo(1, contract, big.NewInt(0), []byte{0xa9, 0x05, 0x9c, 0xbb})
+	otherTokenTx := newSignedTestETHTxTo(2, contract, big.NewInt(0), []byte{0xa9, 0x05, 0x9c, 0xbb})
+	otherNativeTx := newSignedTestETHTxTo(3, otherAddr, big.NewInt(1000), nil)
+
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(90), Difficulty: big.NewInt(0)}).
+		WithBody(types.Body{Transactions: []*types.Transaction{nativeTx, tokenTx, otherTokenTx, otherNativeTx}})
+
+	transferLog := func(tx *types.Transaction, recipient common.Address) types.Log {
+		return types.Log{
+			Address:     contract,
+			Topics:      []common.Hash{common.HexToHash(erc20_transfer_topic), common.BytesToHash(otherAddr.Bytes()), common.BytesToHash(recipient.Bytes())},
+			Data:        common.LeftPadBytes(big.NewInt(2169080917).Bytes(), common.HashLength),
+			BlockNumber: block.NumberU64(),
+			BlockHash:   block.Hash(),
+			TxHash:      tx.Hash(),
+		}
+	}
+
+	t.Run("Should Fetch Only The Native Transfers To The Addresses", func(t *testing.T) {
+		service := &testETHService{
+			minedBlock: block,
+			minedTxs:   block.Transactions(),
+			logs:       []types.Log{transferLog(tokenTx, watchedAddr), transferLog(otherTokenTx, otherAddr)},
+		}
+		d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+		txs, err := d.GetBlockTransactionsTo(ETHBlock{block: block}, []string{watchedAddr.Hex()}, []string{contract.Hex()})
+		assert.NoError(t, err)
+		assert.Len(t, txs, 2)
+
+		assert.Equal(t, nativeTx.Hash().Hex(), txs[0].GetTxId())
+		assert.Equal(t, uint64(10), txs[0].GetConfirmations())
+		assert.Empty(t, txs[0].Logs)
+
+		assert.Equal(t, tokenTx.Hash().Hex(), txs[1].GetTxId())
+		assert.Equal(t, uint64(10), txs[1].GetConfirmations())
+		assert.False(t, txs[1].IsDoubleSpendSeen())
+		assert.Len(t, txs[1].Logs, 1)
+		assert.Equal(t, watchedAddr, common.BytesToAddress(txs[1].Logs[0].Topics[2].Bytes()))
+
+		assert.Equal(t, 1, service.getLogsCalls)
+		assert.Equal(t, 1, service.receiptCalls)
+	})
+
+	t.Run("Should Not Call The Node Without Addresses", func(t *testing.T) {
+		service := &testETHService{minedBlock: block, minedTxs: block.Transactions()}
+		d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+		txs, err := d.GetBlockTransactionsTo(ETHBlock{block: block}, []string{}, []string{contract.Hex()})
+		assert.NoError(t, err)
+		assert.Empty(t, txs)
+		assert.Equal(t, 0, service.getLogsCalls)
+		assert.Equal(t, 0, service.receiptCalls)
+	})
+}
+
+func TestETHGetTokenDecimals(t *testing.T) {
+	contract := common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	service := &testETHService{tokenDecimals: map[common.Address]uint8{contract: 6}}
+	d := NewSharedETHDaemonRpcClient(newTestETHClient(t, service))
+
+	t.Run("Should Return The Decimals Of The Contract", func(t *testing.T) {
+		decimals, err := d.GetTokenDecimals(contract.Hex())
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(6), decimals)
+	})
+
+	t.Run("Should Return An Error For The Address Without Code", func(t *testing.T) {
+		_, err := d.GetTokenDecimals("0x06Ac0C1C504218af3448E00ba1924455183D042C")
+		assert.ErrorIs(t, err, InvalidTokenDecimalsErr)
+	})
+}
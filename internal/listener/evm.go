@@ -0,0 +1,65 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rs/zerolog"
+)
+
+var chainIdMismatchErr error = errors.New("the daemon is connected to a chain with another chain id")
+
+// SharedEVMDaemonRpcClient is the client of an EVM chain declared in the config (e.g. Polygon or Arbitrum).
+// Its txs and blocks are the same as the ETH ones.
+type SharedEVMDaemonRpcClient struct {
+	SharedETHDaemonRpcClient
+
+	coin      db.CoinType
+	chainId   uint64
+	blockTime time.Duration
+}
+
+// GetNetworkType verifies the daemon serves the chain with the declared chain id.
+func (c *SharedEVMDaemonRpcClient) GetNetworkType() (NetworkType, error) {
+	chainId, err := c.client.ChainID(context.Background())
+	if err != nil {
+		return math.MaxUint8, err
+	}
+	if !chainId.IsUint64() || chainId.Uint64() != c.chainId {
+		return math.MaxUint8, chainIdMismatchErr
+	}
+
+	return EVM, nil
+}
+func (c *SharedEVMDaemonRpcClient) GetCoinType() db.CoinType {
+	return c.coin
+}
+func (c *SharedEVMDaemonRpcClient) GetChainId() uint64 {
+	return c.chainId
+}
+func (c *SharedEVMDaemonRpcClient) GetBlockTime() time.Duration {
+	return c.blockTime
+}
+
+func NewSharedEVMDaemonRpcClient(client *ethclient.Client, coin db.CoinType, chainId uint64, blockTime time.Duration) *SharedEVMDaemonRpcClient {
+	return &SharedEVMDaemonRpcClient{
+		SharedETHDaemonRpcClient: SharedETHDaemonRpcClient{client: client},
+		coin:                     coin,
+		chainId:                  chainId,
+		blockTime:                blockTime,
+	}
+}
+
+type EVMDaemonRpcClientExecutor struct {
+	BaseDaemonRpcClientExecutor[ETHTx, ETHBlock]
+}
+
+func NewEVMDaemonRpcClientExecutor(log *zerolog.Logger, client *SharedEVMDaemonRpcClient) *EVMDaemonRpcClientExecutor {
+	return &EVMDaemonRpcClientExecutor{
+		BaseDaemonRpcClientExecutor: *NewBaseDaemonRpcClientExecutor(log, client),
+	}
+}
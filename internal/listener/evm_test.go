@@ -0,0 +1,30 @@
+package listener
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEVMGetNetworkType(t *testing.T) {
+	client := newTestETHClient(t, &testETHService{chainId: 137})
+
+	t.Run("Should Return EVM For The Declared Chain Id", func(t *testing.T) {
+		d := NewSharedEVMDaemonRpcClient(client, db.CoinType("POLYGON"), 137, 2*time.Second)
+
+		network, err := d.GetNetworkType()
+		assert.NoError(t, err)
+		assert.Equal(t, EVM, network)
+		assert.Equal(t, db.CoinType("POLYGON"), d.GetCoinType())
+		assert.Equal(t, 2*time.Second, d.GetBlockTime())
+	})
+
+	t.Run("Should Return An Error For Another Chain Id", func(t *testing.T) {
+		d := NewSharedEVMDaemonRpcClient(client, db.CoinType("ARBITRUM"), 42161, 0)
+
+		_, err := d.GetNetworkType()
+		assert.ErrorIs(t, err, chainIdMismatchErr)
+	})
+}
@@ -1,12 +1,22 @@
 package listener
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"math"
 	"unsafe"
 
+	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/util"
+	ltcchaincfg "github.com/ltcsuite/ltcd/chaincfg"
+	ltcchainhash "github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
 	ltcrpc "github.com/ltcsuite/ltcd/rpcclient"
+	ltctxscript "github.com/ltcsuite/ltcd/txscript"
+	ltcwire "github.com/ltcsuite/ltcd/wire"
 	"github.com/rs/zerolog"
 )
 
@@ -16,6 +26,14 @@ func (b LTCBlock) GetTxHashes() []string {
 	return BTCBlock(b).GetTxHashes()
 }
 
+func (b LTCBlock) GetHash() string {
+	return BTCBlock(b).GetHash()
+}
+
+func (b LTCBlock) GetParentHash() string {
+	return BTCBlock(b).GetParentHash()
+}
+
 type LTCTx BTCTx
 
 func (t LTCTx) GetConfirmations() uint64 {
@@ -27,10 +45,91 @@ func (t LTCTx) GetTxId() string {
 func (t LTCTx) IsDoubleSpendSeen() bool {
 	return BTCTx(t).IsDoubleSpendSeen()
 }
+func (t LTCTx) GetBlockHeight() (uint64, bool) {
+	return BTCTx(t).GetBlockHeight()
+}
+func (t LTCTx) GetSpentOutpoints() []string {
+	return BTCTx(t).GetSpentOutpoints()
+}
+
+// decodeLTCTx builds the tx the daemon would return for the raw tx pushed over ZMQ. The tx is assumed to be unconfirmed.
+func decodeLTCTx(rawTx []byte, params *ltcchaincfg.Params) (LTCTx, error) {
+	var msgTx ltcwire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(rawTx)); err != nil {
+		return LTCTx{}, err
+	}
+
+	tx := LTCTx{TxRawResult: btcjson.TxRawResult{
+		Hex:      hex.EncodeToString(rawTx),
+		Txid:     msgTx.TxHash().String(),
+		Hash:     msgTx.WitnessHash().String(),
+		Size:     int32(len(rawTx)),
+		Version:  uint32(msgTx.Version),
+		LockTime: msgTx.LockTime,
+		Vin:      make([]btcjson.Vin, 0, len(msgTx.TxIn)),
+		Vout:     make([]btcjson.Vout, 0, len(msgTx.TxOut)),
+	}}
+
+	for i := 0; i < len(msgTx.TxIn); i++ {
+		txIn := msgTx.TxIn[i]
+		if len(msgTx.TxIn) == 1 && txIn.PreviousOutPoint.Index == math.MaxUint32 && txIn.PreviousOutPoint.Hash == (ltcchainhash.Hash{}) {
+			tx.Vin = append(tx.Vin, btcjson.Vin{Coinbase: hex.EncodeToString(txIn.SignatureScript), Sequence: txIn.Sequence})
+			continue
+		}
+		tx.Vin = append(tx.Vin, btcjson.Vin{Txid: txIn.PreviousOutPoint.Hash.String(), Vout: txIn.PreviousOutPoint.Index, Sequence: txIn.Sequence})
+	}
+
+	for i := 0; i < len(msgTx.TxOut); i++ {
+		txOut := msgTx.TxOut[i]
+		class, addrs, _, err := ltctxscript.ExtractPkScriptAddrs(txOut.PkScript, params)
+		if err != nil {
+			return LTCTx{}, err
+		}
+
+		scriptPubKey := btcjson.ScriptPubKeyResult{Hex: hex.EncodeToString(txOut.PkScript), Type: class.String()}
+		if len(addrs) == 1 {
+			scriptPubKey.Address = addrs[0].EncodeAddress()
+		}
+		tx.Vout = append(tx.Vout, btcjson.Vout{Value: ltcutil.Amount(txOut.Value).ToBTC(), N: uint32(i), ScriptPubKey: scriptPubKey})
+	}
+
+	return tx, nil
+}
 
 type SharedLTCDaemonRpcClient struct {
 	SharedBTCDaemonRpcClient
 	ltcClient *ltcrpc.Client
+
+	// ltcParams are used to decode the addresses of the txs pushed over ZMQ.
+	ltcParams *ltcchaincfg.Params
+}
+
+func (c *SharedLTCDaemonRpcClient) chainParams() (*ltcchaincfg.Params, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ltcParams != nil {
+		return c.ltcParams, nil
+	}
+
+	net, err := c.GetNetworkType()
+	if err != nil {
+		return nil, err
+	}
+	switch net {
+	case MainnetLTC:
+		c.ltcParams = &ltcchaincfg.MainNetParams
+	case TestnetLTC:
+		c.ltcParams = &ltcchaincfg.TestNet4Params
+	case SignetLTC:
+		c.ltcParams = &ltcchaincfg.SigNetParams
+	case RegtestLTC:
+		c.ltcParams = &ltcchaincfg.RegressionNetParams
+	default:
+		return nil, util.InvalidNetworkTypeErr
+	}
+
+	return c.ltcParams, nil
 }
 
 func (c *SharedLTCDaemonRpcClient) GetNetworkType() (NetworkType, error) {
@@ -68,16 +167,38 @@ func (c *SharedLTCDaemonRpcClient) GetCoinType() db.CoinType {
 	return db.CoinTypeLTC
 }
 
-func NewSharedLTCDaemonRpcClient(client *rpcclient.Client, ltcClient *ltcrpc.Client) *SharedLTCDaemonRpcClient {
-	return &SharedLTCDaemonRpcClient{SharedBTCDaemonRpcClient: *NewSharedBTCDaemonRpcClient(client), ltcClient: ltcClient}
+// SubscribeNewTxs subscribes to the rawtx ZMQ topic and decodes the pushed txs locally.
+func (c *SharedLTCDaemonRpcClient) SubscribeNewTxs(ctx context.Context, cn chan<- LTCTx) (Subscription, error) {
+	params, err := c.chainParams()
+	if err != nil {
+		return nil, err
+	}
+
+	return subscribeZMQ(ctx, c.log, c.zmq.RawTx, zmq_raw_tx_topic, func(body []byte, quit <-chan struct{}) {
+		tx, err := decodeLTCTx(body, params)
+		// The daemon only publishes the txs it accepted, so a malformed one isn't expected.
+		if err != nil {
+			c.log.Err(err).Str("coin", string(c.GetCoinType())).Msg("An error occurred while decoding the tx pushed over ZMQ.")
+			return
+		}
+
+		select {
+		case cn <- tx:
+		case <-quit:
+		}
+	})
+}
+
+func NewSharedLTCDaemonRpcClient(log *zerolog.Logger, client *rpcclient.Client, ltcClient *ltcrpc.Client, zmq ZMQConfig) *SharedLTCDaemonRpcClient {
+	return &SharedLTCDaemonRpcClient{SharedBTCDaemonRpcClient: SharedBTCDaemonRpcClient{log: log, client: client, zmq: zmq}, ltcClient: ltcClient}
 }
 
 type LTCDaemonRpcClientExecutor struct {
 	BaseDaemonRpcClientExecutor[LTCTx, LTCBlock]
 }
 
-func NewLTCDaemonRpcClientExecutor(log *zerolog.Logger, client *rpcclient.Client, ltcClient *ltcrpc.Client) *LTCDaemonRpcClientExecutor {
+func NewLTCDaemonRpcClientExecutor(log *zerolog.Logger, client *rpcclient.Client, ltcClient *ltcrpc.Client, zmq ZMQConfig) *LTCDaemonRpcClientExecutor {
 	return &LTCDaemonRpcClientExecutor{
-		BaseDaemonRpcClientExecutor: *NewBaseDaemonRpcClientExecutor(log, NewSharedLTCDaemonRpcClient(client, ltcClient)),
+		BaseDaemonRpcClientExecutor: *NewBaseDaemonRpcClientExecutor(log, NewSharedLTCDaemonRpcClient(log, client, ltcClient, zmq)),
 	}
 }
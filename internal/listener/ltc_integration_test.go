@@ -7,6 +7,7 @@ import (
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/chekist32/goipay/internal/db"
 	ltcrpc "github.com/ltcsuite/ltcd/rpcclient"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -44,7 +45,7 @@ func getLTCDaemonRpcClient2() *ltcrpc.Client {
 
 func TestLTCGetLastBlockHeight(t *testing.T) {
 	// Given
-	d := NewSharedLTCDaemonRpcClient(getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2())
+	d := NewSharedLTCDaemonRpcClient(&zerolog.Logger{}, getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2(), ZMQConfig{})
 
 	// When
 	height, err := d.GetLastBlockHeight()
@@ -57,7 +58,7 @@ func TestLTCGetLastBlockHeight(t *testing.T) {
 func TestLTCGetBlockByHeight(t *testing.T) {
 	// Given
 	expectedBlockHash := "cdbc2bf7d8ff2e90d5f720775b95be1833b2cf7c7b27ce7f385cb068bdef3113"
-	d := NewSharedLTCDaemonRpcClient(getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2())
+	d := NewSharedLTCDaemonRpcClient(&zerolog.Logger{}, getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2(), ZMQConfig{})
 
 	// When
 	block, err := d.GetBlockByHeight(2829638)
@@ -70,7 +71,7 @@ func TestLTCGetBlockByHeight(t *testing.T) {
 
 func TestLTCGeTransactionPool(t *testing.T) {
 	// Given
-	d := NewSharedLTCDaemonRpcClient(getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2())
+	d := NewSharedLTCDaemonRpcClient(&zerolog.Logger{}, getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2(), ZMQConfig{})
 
 	// When
 	_, err := d.GetTransactionPool()
@@ -81,7 +82,7 @@ func TestLTCGeTransactionPool(t *testing.T) {
 
 func TestLTCGetTransactions(t *testing.T) {
 	// Given
-	d := NewSharedLTCDaemonRpcClient(getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2())
+	d := NewSharedLTCDaemonRpcClient(&zerolog.Logger{}, getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2(), ZMQConfig{})
 	expectedTxId := "e8ecc5e31df3cf6ae35f1949462a1dcd4690f54d80683ba1c5355b98df123eca"
 
 	// When
@@ -97,7 +98,7 @@ func TestLTCGetTransactions(t *testing.T) {
 
 func TestLTCGetNetworkType(t *testing.T) {
 	// Given
-	d := NewSharedLTCDaemonRpcClient(getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2())
+	d := NewSharedLTCDaemonRpcClient(&zerolog.Logger{}, getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2(), ZMQConfig{})
 
 	// When
 	net, err := d.GetNetworkType()
@@ -109,7 +110,7 @@ func TestLTCGetNetworkType(t *testing.T) {
 
 func TestLTCGetCoinType(t *testing.T) {
 	// Given
-	d := NewSharedLTCDaemonRpcClient(getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2())
+	d := NewSharedLTCDaemonRpcClient(&zerolog.Logger{}, getLTCDaemonRpcClient1(), getLTCDaemonRpcClient2(), ZMQConfig{})
 
 	// When
 	coin := d.GetCoinType()
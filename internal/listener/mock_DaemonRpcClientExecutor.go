@@ -47,6 +47,46 @@ func (_m *MockDaemonRpcClientExecutor[T, B]) NewBlockChan() <-chan B {
 	return r0
 }
 
+// NewDroppedTxChan provides a mock function with no fields
+func (_m *MockDaemonRpcClientExecutor[T, B]) NewDroppedTxChan() <-chan DroppedTx {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewDroppedTxChan")
+	}
+
+	var r0 <-chan DroppedTx
+	if rf, ok := ret.Get(0).(func() <-chan DroppedTx); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan DroppedTx)
+		}
+	}
+
+	return r0
+}
+
+// NewReorgChan provides a mock function with no fields
+func (_m *MockDaemonRpcClientExecutor[T, B]) NewReorgChan() <-chan Reorg {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewReorgChan")
+	}
+
+	var r0 <-chan Reorg
+	if rf, ok := ret.Get(0).(func() <-chan Reorg); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan Reorg)
+		}
+	}
+
+	return r0
+}
+
 // NewTxPoolChan provides a mock function with no fields
 func (_m *MockDaemonRpcClientExecutor[T, B]) NewTxPoolChan() <-chan T {
 	ret := _m.Called()
@@ -67,9 +107,29 @@ func (_m *MockDaemonRpcClientExecutor[T, B]) NewTxPoolChan() <-chan T {
 	return r0
 }
 
-// Start provides a mock function with given fields: startBlock
-func (_m *MockDaemonRpcClientExecutor[T, B]) Start(startBlock uint64) {
-	_m.Called(startBlock)
+// RecentBlockHashes provides a mock function with no fields
+func (_m *MockDaemonRpcClientExecutor[T, B]) RecentBlockHashes() []BlockHash {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecentBlockHashes")
+	}
+
+	var r0 []BlockHash
+	if rf, ok := ret.Get(0).(func() []BlockHash); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BlockHash)
+		}
+	}
+
+	return r0
+}
+
+// Start provides a mock function with given fields: startBlock, blockHashes
+func (_m *MockDaemonRpcClientExecutor[T, B]) Start(startBlock uint64, blockHashes []BlockHash) {
+	_m.Called(startBlock, blockHashes)
 }
 
 // Stop provides a mock function with no fields
@@ -77,6 +137,11 @@ func (_m *MockDaemonRpcClientExecutor[T, B]) Stop() {
 	_m.Called()
 }
 
+// WatchTx provides a mock function with given fields: tx
+func (_m *MockDaemonRpcClientExecutor[T, B]) WatchTx(tx T) {
+	_m.Called(tx)
+}
+
 // NewMockDaemonRpcClientExecutor creates a new instance of MockDaemonRpcClientExecutor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDaemonRpcClientExecutor[T SharedTx, B SharedBlock](t interface {
@@ -9,6 +9,42 @@ type MockSharedBlock struct {
 	mock.Mock
 }
 
+// GetHash provides a mock function with no fields
+func (_m *MockSharedBlock) GetHash() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHash")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetParentHash provides a mock function with no fields
+func (_m *MockSharedBlock) GetParentHash() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentHash")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GetTxHashes provides a mock function with no fields
 func (_m *MockSharedBlock) GetTxHashes() []string {
 	ret := _m.Called()
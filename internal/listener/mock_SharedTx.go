@@ -9,6 +9,34 @@ type MockSharedTx struct {
 	mock.Mock
 }
 
+// GetBlockHeight provides a mock function with no fields
+func (_m *MockSharedTx) GetBlockHeight() (uint64, bool) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockHeight")
+	}
+
+	var r0 uint64
+	var r1 bool
+	if rf, ok := ret.Get(0).(func() (uint64, bool)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // GetConfirmations provides a mock function with no fields
 func (_m *MockSharedTx) GetConfirmations() uint64 {
 	ret := _m.Called()
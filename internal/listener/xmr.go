@@ -17,6 +17,9 @@ func (t XMRTx) GetConfirmations() uint64 {
 func (t XMRTx) IsDoubleSpendSeen() bool {
 	return t.DoubleSpendSeen
 }
+func (t XMRTx) GetBlockHeight() (uint64, bool) {
+	return t.BlockHeight, !t.InPool
+}
 
 type XMRBlock daemon.GetBlockResult
 
@@ -24,6 +27,14 @@ func (b XMRBlock) GetTxHashes() []string {
 	return b.BlockDetails.TxHashes
 }
 
+func (b XMRBlock) GetHash() string {
+	return b.BlockHeader.Hash
+}
+
+func (b XMRBlock) GetParentHash() string {
+	return b.BlockHeader.PrevHash
+}
+
 type SharedXMRDaemonRpcClient struct {
 	client daemon.IDaemonRpcClient
 }
@@ -0,0 +1,92 @@
+package listener
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/lightninglabs/gozmq"
+	"github.com/rs/zerolog"
+)
+
+const (
+	zmq_raw_tx_topic     string = "rawtx"
+	zmq_hash_block_topic string = "hashblock"
+
+	// zmq_frame_timeout is how long the remaining frames of a started message are awaited.
+	zmq_frame_timeout time.Duration = 10 * time.Second
+)
+
+// ZMQConfig holds the ZMQ endpoints of the daemon (-zmqpubrawtx and -zmqpubhashblock).
+// The empty ones aren't subscribed to.
+type ZMQConfig struct {
+	RawTx     string
+	HashBlock string
+}
+
+type zmqSubscription struct {
+	conn  *gozmq.Conn
+	errCn chan error
+	quit  chan struct{}
+	once  sync.Once
+}
+
+func (s *zmqSubscription) Err() <-chan error {
+	return s.errCn
+}
+
+func (s *zmqSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		close(s.quit)
+		s.conn.Close()
+	})
+}
+
+// subscribeZMQ passes the body of every message published on the topic to handle until the subscription
+// is cancelled or the connection fails. handle must return once quit is closed.
+func subscribeZMQ(ctx context.Context, log *zerolog.Logger, addr string, topic string, handle func(body []byte, quit <-chan struct{})) (Subscription, error) {
+	conn, err := gozmq.Subscribe(addr, []string{topic}, zmq_frame_timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &zmqSubscription{conn: conn, errCn: make(chan error, 1), quit: make(chan struct{})}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+		case <-sub.quit:
+		}
+	}()
+
+	go func() {
+		for {
+			// The message consists of the topic, the body and the sequence number.
+			msg, err := conn.Receive(nil)
+			if err != nil {
+				// A message which stalled midway or a dropped connection, which gozmq reconnects on its own.
+				if ne, ok := err.(net.Error); ok && ne.Timeout() {
+					log.Debug().Err(err).Str("topic", topic).Msg("ZMQ receive timed out")
+					continue
+				}
+
+				select {
+				case <-sub.quit:
+				default:
+					sub.errCn <- err
+				}
+				return
+			}
+			if len(msg) < 2 || string(msg[0]) != topic {
+				log.Warn().Str("topic", topic).Msgf("Skipped the malformed ZMQ message of %v frames", len(msg))
+				continue
+			}
+
+			handle(msg[1], sub.quit)
+		}
+	}()
+
+	return sub, nil
+}
@@ -0,0 +1,282 @@
+package listener
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/test"
+	ltcchaincfg "github.com/ltcsuite/ltcd/chaincfg"
+	ltcchainhash "github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	ltctxscript "github.com/ltcsuite/ltcd/txscript"
+	ltcwire "github.com/ltcsuite/ltcd/wire"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// testZMQPublisher is a minimal ZMTP 3.0 PUB socket standing in for the daemon.
+type testZMQPublisher struct {
+	lis    net.Listener
+	connCn chan net.Conn
+}
+
+func writeTestZMQFrame(conn net.Conn, flag byte, body []byte) error {
+	header := []byte{flag, byte(len(body))}
+	if len(body) > 255 {
+		header = make([]byte, 9)
+		header[0] = flag | 2
+		binary.BigEndian.PutUint64(header[1:], uint64(len(body)))
+	}
+
+	if _, err := conn.Write(header); err != nil {
+		return err
+	}
+	_, err := conn.Write(body)
+	return err
+}
+
+func readTestZMQFrame(conn net.Conn) ([]byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	if header[0]&2 != 0 {
+		return nil, errors.New("unexpected long frame")
+	}
+
+	body := make([]byte, header[1])
+	_, err := io.ReadFull(conn, body)
+	return body, err
+}
+
+// handshake accepts the subscriber using the NULL mechanism and waits for its subscription.
+func (p *testZMQPublisher) handshake(conn net.Conn) error {
+	greeting := make([]byte, 64)
+	greeting[0], greeting[9], greeting[10] = 0xff, 0x7f, 3
+	copy(greeting[12:], "NULL")
+	if _, err := conn.Write(greeting); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(conn, make([]byte, 64)); err != nil {
+		return err
+	}
+
+	ready := append([]byte{5}, "READY"...)
+	ready = append(ready, 11)
+	ready = append(ready, "Socket-Type"...)
+	ready = append(ready, 0, 0, 0, 3)
+	ready = append(ready, "PUB"...)
+	if err := writeTestZMQFrame(conn, 4, ready); err != nil {
+		return err
+	}
+
+	// The READY command and the subscription of the subscriber.
+	for i := 0; i < 2; i++ {
+		if _, err := readTestZMQFrame(conn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *testZMQPublisher) addr() string {
+	return "tcp://" + p.lis.Addr().String()
+}
+
+// publish sends the message the way the daemon does: the topic, the body and the sequence number.
+func (p *testZMQPublisher) publish(conn net.Conn, topic string, body []byte) {
+	if err := writeTestZMQFrame(conn, 1, []byte(topic)); err != nil {
+		log.Fatal(err)
+	}
+	if err := writeTestZMQFrame(conn, 1, body); err != nil {
+		log.Fatal(err)
+	}
+	if err := writeTestZMQFrame(conn, 0, make([]byte, 4)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newTestZMQPublisher(t *testing.T) *testZMQPublisher {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	p := &testZMQPublisher{lis: lis, connCn: make(chan net.Conn, 1)}
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			if err := p.handshake(conn); err != nil {
+				conn.Close()
+				continue
+			}
+			p.connCn <- conn
+		}
+	}()
+
+	return p
+}
+
+func TestBTCSubscribeNewTxs(t *testing.T) {
+	publisher := newTestZMQPublisher(t)
+	var logs bytes.Buffer
+	logger := zerolog.New(&logs)
+	d := &SharedBTCDaemonRpcClient{log: &logger, zmq: ZMQConfig{RawTx: publisher.addr()}, params: &chaincfg.RegressionNetParams}
+
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(bytes.Repeat([]byte{1}, 20), &chaincfg.RegressionNetParams)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	msgTx := wire.NewMsgTx(2)
+	msgTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}, nil, nil))
+	msgTx.AddTxOut(wire.NewTxOut(150000, pkScript))
+	var rawTx bytes.Buffer
+	if err := msgTx.Serialize(&rawTx); err != nil {
+		log.Fatal(err)
+	}
+
+	t.Run("Should Decode The Pushed Raw Tx", func(t *testing.T) {
+		assert.True(t, d.SupportsTxSubscription())
+
+		txCn := make(chan BTCTx)
+		sub, err := d.SubscribeNewTxs(context.Background(), txCn)
+		assert.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		conn := test.GetValueFromCnOrLogFatalWithTimeout(publisher.connCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		publisher.publish(conn, zmq_raw_tx_topic, rawTx.Bytes())
+
+		tx := test.GetValueFromCnOrLogFatalWithTimeout(txCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, msgTx.TxHash().String(), tx.GetTxId())
+		assert.Equal(t, uint64(0), tx.GetConfirmations())
+		assert.Equal(t, []string{chainhash.Hash{1}.String() + ":1"}, tx.GetSpentOutpoints())
+		assert.Len(t, tx.Vout, 1)
+		assert.Equal(t, addr.EncodeAddress(), tx.Vout[0].ScriptPubKey.Address)
+		assert.Equal(t, 0.0015, tx.Vout[0].Value)
+	})
+
+	t.Run("Should Log The Undecodable Raw Tx And Keep Receiving", func(t *testing.T) {
+		txCn := make(chan BTCTx)
+		sub, err := d.SubscribeNewTxs(context.Background(), txCn)
+		assert.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		conn := test.GetValueFromCnOrLogFatalWithTimeout(publisher.connCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		publisher.publish(conn, zmq_raw_tx_topic, []byte{1, 2, 3})
+		publisher.publish(conn, zmq_raw_tx_topic, rawTx.Bytes())
+
+		tx := test.GetValueFromCnOrLogFatalWithTimeout(txCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, msgTx.TxHash().String(), tx.GetTxId())
+		assert.Contains(t, logs.String(), "decoding the tx pushed over ZMQ")
+	})
+
+	t.Run("Should Keep The Subscription After Reconnecting", func(t *testing.T) {
+		txCn := make(chan BTCTx)
+		sub, err := d.SubscribeNewTxs(context.Background(), txCn)
+		assert.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		conn := test.GetValueFromCnOrLogFatalWithTimeout(publisher.connCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		conn.Close()
+
+		conn = test.GetValueFromCnOrLogFatalWithTimeout(publisher.connCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		publisher.publish(conn, zmq_raw_tx_topic, rawTx.Bytes())
+
+		tx := test.GetValueFromCnOrLogFatalWithTimeout(txCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, msgTx.TxHash().String(), tx.GetTxId())
+		assert.Empty(t, sub.Err())
+	})
+
+	t.Run("Should Report The Failed Connection", func(t *testing.T) {
+		sub, err := d.SubscribeNewTxs(context.Background(), make(chan BTCTx))
+		assert.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		conn := test.GetValueFromCnOrLogFatalWithTimeout(publisher.connCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		if _, err := conn.Write([]byte{0xff}); err != nil {
+			log.Fatal(err)
+		}
+
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(sub.Err(), util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+	})
+}
+
+func TestBTCSubscribeNewBlocks(t *testing.T) {
+	publisher := newTestZMQPublisher(t)
+	d := NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, nil, ZMQConfig{HashBlock: publisher.addr()})
+
+	t.Run("Should Notify About The Pushed Block", func(t *testing.T) {
+		assert.True(t, d.SupportsBlockSubscription())
+		assert.False(t, d.SupportsTxSubscription())
+
+		blockCn := make(chan uint64)
+		sub, err := d.SubscribeNewBlocks(context.Background(), blockCn)
+		assert.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		conn := test.GetValueFromCnOrLogFatalWithTimeout(publisher.connCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		publisher.publish(conn, zmq_hash_block_topic, bytes.Repeat([]byte{2}, chainhash.HashSize))
+
+		_ = test.GetValueFromCnOrLogFatalWithTimeout(blockCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+	})
+}
+
+func TestLTCSubscribeNewTxs(t *testing.T) {
+	publisher := newTestZMQPublisher(t)
+	d := &SharedLTCDaemonRpcClient{SharedBTCDaemonRpcClient: SharedBTCDaemonRpcClient{log: &zerolog.Logger{}, zmq: ZMQConfig{RawTx: publisher.addr()}}, ltcParams: &ltcchaincfg.RegressionNetParams}
+
+	addr, err := ltcutil.NewAddressWitnessPubKeyHash(bytes.Repeat([]byte{1}, 20), &ltcchaincfg.RegressionNetParams)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkScript, err := ltctxscript.PayToAddrScript(addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	msgTx := ltcwire.NewMsgTx(2)
+	msgTx.AddTxIn(ltcwire.NewTxIn(&ltcwire.OutPoint{Hash: ltcchainhash.Hash{1}, Index: 0}, nil, nil))
+	msgTx.AddTxOut(ltcwire.NewTxOut(2500000, pkScript))
+	var rawTx bytes.Buffer
+	if err := msgTx.Serialize(&rawTx); err != nil {
+		log.Fatal(err)
+	}
+
+	t.Run("Should Decode The Pushed Raw Tx", func(t *testing.T) {
+		txCn := make(chan LTCTx)
+		sub, err := d.SubscribeNewTxs(context.Background(), txCn)
+		assert.NoError(t, err)
+		defer sub.Unsubscribe()
+
+		conn := test.GetValueFromCnOrLogFatalWithTimeout(publisher.connCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		publisher.publish(conn, zmq_raw_tx_topic, rawTx.Bytes())
+
+		tx := test.GetValueFromCnOrLogFatalWithTimeout(txCn, util.MIN_SYNC_TIMEOUT, "Timeout has been expired")
+		assert.Equal(t, msgTx.TxHash().String(), tx.GetTxId())
+		assert.Len(t, tx.Vout, 1)
+		assert.Equal(t, addr.EncodeAddress(), tx.Vout[0].ScriptPubKey.Address)
+		assert.Equal(t, 0.025, tx.Vout[0].Value)
+	})
+}
@@ -188,6 +188,104 @@ func (CoinType) EnumDescriptor() ([]byte, []int) {
 	return file_crypto_proto_rawDescGZIP(), []int{0}
 }
 
+// A token from the registry. The decimals are verified against the contract.
+type Token struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The identifier used as coinId, e.g. USDT_ERC20.
+	Coin            string   `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
+	Symbol          string   `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Chain           CoinType `protobuf:"varint,3,opt,name=chain,proto3,enum=crypto.v1.CoinType" json:"chain,omitempty"`
+	ChainId         uint64   `protobuf:"varint,4,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	ContractAddress string   `protobuf:"bytes,5,opt,name=contractAddress,proto3" json:"contractAddress,omitempty"`
+	Decimals        uint32   `protobuf:"varint,6,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	// The coinId of the native coin of the chain, e.g. ETH or POLYGON.
+	ChainCoinId string `protobuf:"bytes,7,opt,name=chainCoinId,proto3" json:"chainCoinId,omitempty"`
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Token) GetCoin() string {
+	if x != nil {
+		return x.Coin
+	}
+	return ""
+}
+
+func (x *Token) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Token) GetChain() CoinType {
+	if x != nil {
+		return x.Chain
+	}
+	return CoinType_XMR
+}
+
+func (x *Token) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Token) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *Token) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Token) GetChainCoinId() string {
+	if x != nil {
+		return x.ChainCoinId
+	}
+	return ""
+}
+
 type XmrKeysUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -200,7 +298,7 @@ type XmrKeysUpdateRequest struct {
 func (x *XmrKeysUpdateRequest) Reset() {
 	*x = XmrKeysUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_proto_msgTypes[0]
+		mi := &file_crypto_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -213,7 +311,7 @@ func (x *XmrKeysUpdateRequest) String() string {
 func (*XmrKeysUpdateRequest) ProtoMessage() {}
 
 func (x *XmrKeysUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_proto_msgTypes[0]
+	mi := &file_crypto_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,7 +324,7 @@ func (x *XmrKeysUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use XmrKeysUpdateRequest.ProtoReflect.Descriptor instead.
 func (*XmrKeysUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_crypto_proto_rawDescGZIP(), []int{0}
+	return file_crypto_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *XmrKeysUpdateRequest) GetPrivViewKey() string {
@@ -254,7 +352,7 @@ type BtcKeysUpdateRequest struct {
 func (x *BtcKeysUpdateRequest) Reset() {
 	*x = BtcKeysUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_proto_msgTypes[1]
+		mi := &file_crypto_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -267,7 +365,7 @@ func (x *BtcKeysUpdateRequest) String() string {
 func (*BtcKeysUpdateRequest) ProtoMessage() {}
 
 func (x *BtcKeysUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_proto_msgTypes[1]
+	mi := &file_crypto_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +378,7 @@ func (x *BtcKeysUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BtcKeysUpdateRequest.ProtoReflect.Descriptor instead.
 func (*BtcKeysUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_crypto_proto_rawDescGZIP(), []int{1}
+	return file_crypto_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *BtcKeysUpdateRequest) GetMasterPubKey() string {
@@ -301,7 +399,7 @@ type LtcKeysUpdateRequest struct {
 func (x *LtcKeysUpdateRequest) Reset() {
 	*x = LtcKeysUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_proto_msgTypes[2]
+		mi := &file_crypto_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -314,7 +412,7 @@ func (x *LtcKeysUpdateRequest) String() string {
 func (*LtcKeysUpdateRequest) ProtoMessage() {}
 
 func (x *LtcKeysUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_proto_msgTypes[2]
+	mi := &file_crypto_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,7 +425,7 @@ func (x *LtcKeysUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LtcKeysUpdateRequest.ProtoReflect.Descriptor instead.
 func (*LtcKeysUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_crypto_proto_rawDescGZIP(), []int{2}
+	return file_crypto_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LtcKeysUpdateRequest) GetMasterPubKey() string {
@@ -348,7 +446,7 @@ type EthKeysUpdateRequest struct {
 func (x *EthKeysUpdateRequest) Reset() {
 	*x = EthKeysUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_proto_msgTypes[3]
+		mi := &file_crypto_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -361,7 +459,7 @@ func (x *EthKeysUpdateRequest) String() string {
 func (*EthKeysUpdateRequest) ProtoMessage() {}
 
 func (x *EthKeysUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_proto_msgTypes[3]
+	mi := &file_crypto_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +472,7 @@ func (x *EthKeysUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EthKeysUpdateRequest.ProtoReflect.Descriptor instead.
 func (*EthKeysUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_crypto_proto_rawDescGZIP(), []int{3}
+	return file_crypto_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *EthKeysUpdateRequest) GetMasterPubKey() string {
@@ -395,7 +493,7 @@ type BnbKeysUpdateRequest struct {
 func (x *BnbKeysUpdateRequest) Reset() {
 	*x = BnbKeysUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_crypto_proto_msgTypes[4]
+		mi := &file_crypto_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -408,7 +506,7 @@ func (x *BnbKeysUpdateRequest) String() string {
 func (*BnbKeysUpdateRequest) ProtoMessage() {}
 
 func (x *BnbKeysUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_crypto_proto_msgTypes[4]
+	mi := &file_crypto_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +519,7 @@ func (x *BnbKeysUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BnbKeysUpdateRequest.ProtoReflect.Descriptor instead.
 func (*BnbKeysUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_crypto_proto_rawDescGZIP(), []int{4}
+	return file_crypto_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *BnbKeysUpdateRequest) GetMasterPubKey() string {
@@ -431,72 +529,147 @@ func (x *BnbKeysUpdateRequest) GetMasterPubKey() string {
 	return ""
 }
 
+type EvmKeysUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The coinId of the EVM chain declared in the config, e.g. POLYGON.
+	Chain        string `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	MasterPubKey string `protobuf:"bytes,2,opt,name=masterPubKey,proto3" json:"masterPubKey,omitempty"`
+}
+
+func (x *EvmKeysUpdateRequest) Reset() {
+	*x = EvmKeysUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_crypto_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvmKeysUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvmKeysUpdateRequest) ProtoMessage() {}
+
+func (x *EvmKeysUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvmKeysUpdateRequest.ProtoReflect.Descriptor instead.
+func (*EvmKeysUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EvmKeysUpdateRequest) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *EvmKeysUpdateRequest) GetMasterPubKey() string {
+	if x != nil {
+		return x.MasterPubKey
+	}
+	return ""
+}
+
 var File_crypto_proto protoreflect.FileDescriptor
 
 var file_crypto_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
-	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x22, 0x5a, 0x0a, 0x14, 0x58, 0x6d, 0x72,
-	0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x56, 0x69, 0x65, 0x77, 0x4b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x56, 0x69, 0x65, 0x77,
-	0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x4b,
-	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x53, 0x70, 0x65,
-	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x42, 0x74, 0x63, 0x4b, 0x65, 0x79, 0x73,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
-	0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65,
-	0x79, 0x22, 0x3a, 0x0a, 0x14, 0x4c, 0x74, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73,
-	0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a,
-	0x14, 0x45, 0x74, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50,
-	0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73,
-	0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x42, 0x6e, 0x62,
-	0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50,
-	0x75, 0x62, 0x4b, 0x65, 0x79, 0x2a, 0xf5, 0x04, 0x0a, 0x08, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x4d, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42,
-	0x54, 0x43, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x43, 0x10, 0x02, 0x12, 0x07, 0x0a,
-	0x03, 0x45, 0x54, 0x48, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x4f, 0x4e, 0x10, 0x04, 0x12,
-	0x0e, 0x0a, 0x0a, 0x55, 0x53, 0x44, 0x54, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x05, 0x12,
-	0x0e, 0x0a, 0x0a, 0x55, 0x53, 0x44, 0x43, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x06, 0x12,
-	0x0d, 0x0a, 0x09, 0x44, 0x41, 0x49, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x07, 0x12, 0x0e,
-	0x0a, 0x0a, 0x57, 0x42, 0x54, 0x43, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x08, 0x12, 0x0d,
-	0x0a, 0x09, 0x55, 0x4e, 0x49, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x09, 0x12, 0x0e, 0x0a,
-	0x0a, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x0a, 0x12, 0x0e, 0x0a,
-	0x0a, 0x41, 0x41, 0x56, 0x45, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x0b, 0x12, 0x0d, 0x0a,
-	0x09, 0x43, 0x52, 0x56, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b,
-	0x4d, 0x41, 0x54, 0x49, 0x43, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x0d, 0x12, 0x0e, 0x0a,
-	0x0a, 0x53, 0x48, 0x49, 0x42, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x0e, 0x12, 0x0d, 0x0a,
-	0x09, 0x42, 0x4e, 0x42, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a,
-	0x41, 0x54, 0x4f, 0x4d, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x10, 0x12, 0x0d, 0x0a, 0x09,
-	0x41, 0x52, 0x42, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x11, 0x12, 0x07, 0x0a, 0x03, 0x42,
-	0x4e, 0x42, 0x10, 0x12, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x53, 0x43, 0x55, 0x53, 0x44, 0x5f, 0x42,
-	0x45, 0x50, 0x32, 0x30, 0x10, 0x13, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x53, 0x44, 0x43, 0x5f, 0x42,
-	0x45, 0x50, 0x32, 0x30, 0x10, 0x14, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x41, 0x49, 0x5f, 0x42, 0x45,
-	0x50, 0x32, 0x30, 0x10, 0x15, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x55, 0x53, 0x44, 0x5f, 0x42, 0x45,
-	0x50, 0x32, 0x30, 0x10, 0x16, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x42, 0x54, 0x43, 0x5f, 0x42, 0x45,
-	0x50, 0x32, 0x30, 0x10, 0x17, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x54, 0x43, 0x42, 0x5f, 0x42, 0x45,
-	0x50, 0x32, 0x30, 0x10, 0x18, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x49, 0x5f, 0x42, 0x45, 0x50,
-	0x32, 0x30, 0x10, 0x19, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x42, 0x45, 0x50,
-	0x32, 0x30, 0x10, 0x1a, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x41, 0x56, 0x45, 0x5f, 0x42, 0x45, 0x50,
-	0x32, 0x30, 0x10, 0x1b, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x41, 0x54, 0x49, 0x43, 0x5f, 0x42, 0x45,
-	0x50, 0x32, 0x30, 0x10, 0x1c, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x48, 0x49, 0x42, 0x5f, 0x42, 0x45,
-	0x50, 0x32, 0x30, 0x10, 0x1d, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x54, 0x4f, 0x4d, 0x5f, 0x42, 0x45,
-	0x50, 0x32, 0x30, 0x10, 0x1e, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x52, 0x42, 0x5f, 0x42, 0x45, 0x50,
-	0x32, 0x30, 0x10, 0x1f, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x54, 0x48, 0x5f, 0x42, 0x45, 0x50, 0x32,
-	0x30, 0x10, 0x20, 0x12, 0x0d, 0x0a, 0x09, 0x58, 0x52, 0x50, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30,
-	0x10, 0x21, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x44, 0x41, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10,
-	0x22, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x52, 0x58, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x23,
-	0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x4f, 0x47, 0x45, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x24,
-	0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x54, 0x43, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x25, 0x12,
-	0x0d, 0x0a, 0x09, 0x42, 0x43, 0x48, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x26, 0x12, 0x0d,
-	0x0a, 0x09, 0x54, 0x57, 0x54, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x27, 0x12, 0x0e, 0x0a,
-	0x0a, 0x41, 0x56, 0x41, 0x58, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x28, 0x12, 0x0e, 0x0a,
-	0x0a, 0x43, 0x41, 0x4b, 0x45, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x29, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x22, 0xe0, 0x01, 0x0a, 0x05, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x29, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x43, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x5a, 0x0a, 0x14,
+	0x58, 0x6d, 0x72, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x56, 0x69, 0x65, 0x77,
+	0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x56,
+	0x69, 0x65, 0x77, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x75, 0x62, 0x53, 0x70, 0x65,
+	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x75, 0x62,
+	0x53, 0x70, 0x65, 0x6e, 0x64, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x42, 0x74, 0x63, 0x4b,
+	0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75,
+	0x62, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x4c, 0x74, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79,
+	0x22, 0x3a, 0x0a, 0x14, 0x45, 0x74, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74,
+	0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x3a, 0x0a, 0x14,
+	0x42, 0x6e, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x75,
+	0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x73, 0x74,
+	0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x22, 0x50, 0x0a, 0x14, 0x45, 0x76, 0x6d, 0x4b,
+	0x65, 0x79, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72,
+	0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x50, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x2a, 0xf5, 0x04, 0x0a, 0x08, 0x43,
+	0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x4d, 0x52, 0x10, 0x00,
+	0x12, 0x07, 0x0a, 0x03, 0x42, 0x54, 0x43, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x54, 0x43,
+	0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x54, 0x48, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x54,
+	0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x53, 0x44, 0x54, 0x5f, 0x45, 0x52, 0x43,
+	0x32, 0x30, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x53, 0x44, 0x43, 0x5f, 0x45, 0x52, 0x43,
+	0x32, 0x30, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x41, 0x49, 0x5f, 0x45, 0x52, 0x43, 0x32,
+	0x30, 0x10, 0x07, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x42, 0x54, 0x43, 0x5f, 0x45, 0x52, 0x43, 0x32,
+	0x30, 0x10, 0x08, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x49, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30,
+	0x10, 0x09, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x49, 0x4e, 0x4b, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30,
+	0x10, 0x0a, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x41, 0x56, 0x45, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30,
+	0x10, 0x0b, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x52, 0x56, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10,
+	0x0c, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x41, 0x54, 0x49, 0x43, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30,
+	0x10, 0x0d, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x48, 0x49, 0x42, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30,
+	0x10, 0x0e, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x4e, 0x42, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10,
+	0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x54, 0x4f, 0x4d, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10,
+	0x10, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x52, 0x42, 0x5f, 0x45, 0x52, 0x43, 0x32, 0x30, 0x10, 0x11,
+	0x12, 0x07, 0x0a, 0x03, 0x42, 0x4e, 0x42, 0x10, 0x12, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x53, 0x43,
+	0x55, 0x53, 0x44, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x13, 0x12, 0x0e, 0x0a, 0x0a, 0x55,
+	0x53, 0x44, 0x43, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x14, 0x12, 0x0d, 0x0a, 0x09, 0x44,
+	0x41, 0x49, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x15, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x55,
+	0x53, 0x44, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x16, 0x12, 0x0e, 0x0a, 0x0a, 0x57, 0x42,
+	0x54, 0x43, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x17, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x54,
+	0x43, 0x42, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x18, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e,
+	0x49, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x19, 0x12, 0x0e, 0x0a, 0x0a, 0x4c, 0x49, 0x4e,
+	0x4b, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x1a, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x41, 0x56,
+	0x45, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x1b, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x41, 0x54,
+	0x49, 0x43, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x1c, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x48,
+	0x49, 0x42, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x1d, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x54,
+	0x4f, 0x4d, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x1e, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x52,
+	0x42, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x1f, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x54, 0x48,
+	0x5f, 0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x20, 0x12, 0x0d, 0x0a, 0x09, 0x58, 0x52, 0x50, 0x5f,
+	0x42, 0x45, 0x50, 0x32, 0x30, 0x10, 0x21, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x44, 0x41, 0x5f, 0x42,
+	0x45, 0x50, 0x32, 0x30, 0x10, 0x22, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x52, 0x58, 0x5f, 0x42, 0x45,
+	0x50, 0x32, 0x30, 0x10, 0x23, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x4f, 0x47, 0x45, 0x5f, 0x42, 0x45,
+	0x50, 0x32, 0x30, 0x10, 0x24, 0x12, 0x0d, 0x0a, 0x09, 0x4c, 0x54, 0x43, 0x5f, 0x42, 0x45, 0x50,
+	0x32, 0x30, 0x10, 0x25, 0x12, 0x0d, 0x0a, 0x09, 0x42, 0x43, 0x48, 0x5f, 0x42, 0x45, 0x50, 0x32,
+	0x30, 0x10, 0x26, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x57, 0x54, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30,
+	0x10, 0x27, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x56, 0x41, 0x58, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30,
+	0x10, 0x28, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x41, 0x4b, 0x45, 0x5f, 0x42, 0x45, 0x50, 0x32, 0x30,
+	0x10, 0x29, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -512,21 +685,24 @@ func file_crypto_proto_rawDescGZIP() []byte {
 }
 
 var file_crypto_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_crypto_proto_goTypes = []any{
 	(CoinType)(0),                // 0: crypto.v1.CoinType
-	(*XmrKeysUpdateRequest)(nil), // 1: crypto.v1.XmrKeysUpdateRequest
-	(*BtcKeysUpdateRequest)(nil), // 2: crypto.v1.BtcKeysUpdateRequest
-	(*LtcKeysUpdateRequest)(nil), // 3: crypto.v1.LtcKeysUpdateRequest
-	(*EthKeysUpdateRequest)(nil), // 4: crypto.v1.EthKeysUpdateRequest
-	(*BnbKeysUpdateRequest)(nil), // 5: crypto.v1.BnbKeysUpdateRequest
+	(*Token)(nil),                // 1: crypto.v1.Token
+	(*XmrKeysUpdateRequest)(nil), // 2: crypto.v1.XmrKeysUpdateRequest
+	(*BtcKeysUpdateRequest)(nil), // 3: crypto.v1.BtcKeysUpdateRequest
+	(*LtcKeysUpdateRequest)(nil), // 4: crypto.v1.LtcKeysUpdateRequest
+	(*EthKeysUpdateRequest)(nil), // 5: crypto.v1.EthKeysUpdateRequest
+	(*BnbKeysUpdateRequest)(nil), // 6: crypto.v1.BnbKeysUpdateRequest
+	(*EvmKeysUpdateRequest)(nil), // 7: crypto.v1.EvmKeysUpdateRequest
 }
 var file_crypto_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: crypto.v1.Token.chain:type_name -> crypto.v1.CoinType
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_crypto_proto_init() }
@@ -536,7 +712,7 @@ func file_crypto_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_crypto_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*XmrKeysUpdateRequest); i {
+			switch v := v.(*Token); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -548,7 +724,7 @@ func file_crypto_proto_init() {
 			}
 		}
 		file_crypto_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*BtcKeysUpdateRequest); i {
+			switch v := v.(*XmrKeysUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -560,7 +736,7 @@ func file_crypto_proto_init() {
 			}
 		}
 		file_crypto_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*LtcKeysUpdateRequest); i {
+			switch v := v.(*BtcKeysUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -572,7 +748,7 @@ func file_crypto_proto_init() {
 			}
 		}
 		file_crypto_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*EthKeysUpdateRequest); i {
+			switch v := v.(*LtcKeysUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -584,6 +760,18 @@ func file_crypto_proto_init() {
 			}
 		}
 		file_crypto_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*EthKeysUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_crypto_proto_msgTypes[5].Exporter = func(v any, i int) any {
 			switch v := v.(*BnbKeysUpdateRequest); i {
 			case 0:
 				return &v.state
@@ -595,6 +783,18 @@ func file_crypto_proto_init() {
 				return nil
 			}
 		}
+		file_crypto_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*EvmKeysUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -602,7 +802,7 @@ func file_crypto_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_crypto_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
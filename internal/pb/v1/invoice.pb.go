@@ -28,6 +28,10 @@ const (
 	InvoiceStatusType_PENDING_MEMPOOL InvoiceStatusType = 1
 	InvoiceStatusType_EXPIRED         InvoiceStatusType = 2
 	InvoiceStatusType_CONFIRMED       InvoiceStatusType = 3
+	InvoiceStatusType_CANCELLED       InvoiceStatusType = 4
+	InvoiceStatusType_PARTIALLY_PAID  InvoiceStatusType = 5
+	// A payment arrived after the invoice had expired.
+	InvoiceStatusType_PAID_AFTER_EXPIRY InvoiceStatusType = 6
 )
 
 // Enum value maps for InvoiceStatusType.
@@ -37,12 +41,18 @@ var (
 		1: "PENDING_MEMPOOL",
 		2: "EXPIRED",
 		3: "CONFIRMED",
+		4: "CANCELLED",
+		5: "PARTIALLY_PAID",
+		6: "PAID_AFTER_EXPIRY",
 	}
 	InvoiceStatusType_value = map[string]int32{
-		"PENDING":         0,
-		"PENDING_MEMPOOL": 1,
-		"EXPIRED":         2,
-		"CONFIRMED":       3,
+		"PENDING":           0,
+		"PENDING_MEMPOOL":   1,
+		"EXPIRED":           2,
+		"CONFIRMED":         3,
+		"CANCELLED":         4,
+		"PARTIALLY_PAID":    5,
+		"PAID_AFTER_EXPIRY": 6,
 	}
 )
 
@@ -73,23 +83,291 @@ func (InvoiceStatusType) EnumDescriptor() ([]byte, []int) {
 	return file_invoice_proto_rawDescGZIP(), []int{0}
 }
 
+type PaymentOutcomeType int32
+
+const (
+	PaymentOutcomeType_OVERPAID  PaymentOutcomeType = 0
+	PaymentOutcomeType_UNDERPAID PaymentOutcomeType = 1
+)
+
+// Enum value maps for PaymentOutcomeType.
+var (
+	PaymentOutcomeType_name = map[int32]string{
+		0: "OVERPAID",
+		1: "UNDERPAID",
+	}
+	PaymentOutcomeType_value = map[string]int32{
+		"OVERPAID":  0,
+		"UNDERPAID": 1,
+	}
+)
+
+func (x PaymentOutcomeType) Enum() *PaymentOutcomeType {
+	p := new(PaymentOutcomeType)
+	*p = x
+	return p
+}
+
+func (x PaymentOutcomeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentOutcomeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_invoice_proto_enumTypes[1].Descriptor()
+}
+
+func (PaymentOutcomeType) Type() protoreflect.EnumType {
+	return &file_invoice_proto_enumTypes[1]
+}
+
+func (x PaymentOutcomeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentOutcomeType.Descriptor instead.
+func (PaymentOutcomeType) EnumDescriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{1}
+}
+
+type PaymentRequestStatusType int32
+
+const (
+	PaymentRequestStatusType_PAYMENT_REQUEST_PENDING PaymentRequestStatusType = 0
+	PaymentRequestStatusType_PAYMENT_REQUEST_PAID    PaymentRequestStatusType = 1
+	// Every invoice of the request expired or was cancelled.
+	PaymentRequestStatusType_PAYMENT_REQUEST_EXPIRED PaymentRequestStatusType = 2
+)
+
+// Enum value maps for PaymentRequestStatusType.
+var (
+	PaymentRequestStatusType_name = map[int32]string{
+		0: "PAYMENT_REQUEST_PENDING",
+		1: "PAYMENT_REQUEST_PAID",
+		2: "PAYMENT_REQUEST_EXPIRED",
+	}
+	PaymentRequestStatusType_value = map[string]int32{
+		"PAYMENT_REQUEST_PENDING": 0,
+		"PAYMENT_REQUEST_PAID":    1,
+		"PAYMENT_REQUEST_EXPIRED": 2,
+	}
+)
+
+func (x PaymentRequestStatusType) Enum() *PaymentRequestStatusType {
+	p := new(PaymentRequestStatusType)
+	*p = x
+	return p
+}
+
+func (x PaymentRequestStatusType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentRequestStatusType) Descriptor() protoreflect.EnumDescriptor {
+	return file_invoice_proto_enumTypes[2].Descriptor()
+}
+
+func (PaymentRequestStatusType) Type() protoreflect.EnumType {
+	return &file_invoice_proto_enumTypes[2]
+}
+
+func (x PaymentRequestStatusType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentRequestStatusType.Descriptor instead.
+func (PaymentRequestStatusType) EnumDescriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{2}
+}
+
+type InvoiceEventType int32
+
+const (
+	InvoiceEventType_STATUS_CHANGED InvoiceEventType = 0
+	// The confirmation count of the invoice payments changed without a status change.
+	InvoiceEventType_CONFIRMATION_PROGRESS InvoiceEventType = 1
+	// The mempool tx paying the invoice was replaced or evicted, so the invoice awaits payment again.
+	InvoiceEventType_MEMPOOL_DROPPED InvoiceEventType = 2
+)
+
+// Enum value maps for InvoiceEventType.
+var (
+	InvoiceEventType_name = map[int32]string{
+		0: "STATUS_CHANGED",
+		1: "CONFIRMATION_PROGRESS",
+		2: "MEMPOOL_DROPPED",
+	}
+	InvoiceEventType_value = map[string]int32{
+		"STATUS_CHANGED":        0,
+		"CONFIRMATION_PROGRESS": 1,
+		"MEMPOOL_DROPPED":       2,
+	}
+)
+
+func (x InvoiceEventType) Enum() *InvoiceEventType {
+	p := new(InvoiceEventType)
+	*p = x
+	return p
+}
+
+func (x InvoiceEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InvoiceEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_invoice_proto_enumTypes[3].Descriptor()
+}
+
+func (InvoiceEventType) Type() protoreflect.EnumType {
+	return &file_invoice_proto_enumTypes[3]
+}
+
+func (x InvoiceEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InvoiceEventType.Descriptor instead.
+func (InvoiceEventType) EnumDescriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{3}
+}
+
+type QrCodeFormat int32
+
+const (
+	QrCodeFormat_PNG QrCodeFormat = 0
+	QrCodeFormat_SVG QrCodeFormat = 1
+)
+
+// Enum value maps for QrCodeFormat.
+var (
+	QrCodeFormat_name = map[int32]string{
+		0: "PNG",
+		1: "SVG",
+	}
+	QrCodeFormat_value = map[string]int32{
+		"PNG": 0,
+		"SVG": 1,
+	}
+)
+
+func (x QrCodeFormat) Enum() *QrCodeFormat {
+	p := new(QrCodeFormat)
+	*p = x
+	return p
+}
+
+func (x QrCodeFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QrCodeFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_invoice_proto_enumTypes[4].Descriptor()
+}
+
+func (QrCodeFormat) Type() protoreflect.EnumType {
+	return &file_invoice_proto_enumTypes[4]
+}
+
+func (x QrCodeFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QrCodeFormat.Descriptor instead.
+func (QrCodeFormat) EnumDescriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{4}
+}
+
+// QR code error correction levels (L, M, Q, H).
+type QrCodeErrorCorrection int32
+
+const (
+	QrCodeErrorCorrection_MEDIUM   QrCodeErrorCorrection = 0
+	QrCodeErrorCorrection_LOW      QrCodeErrorCorrection = 1
+	QrCodeErrorCorrection_QUARTILE QrCodeErrorCorrection = 2
+	QrCodeErrorCorrection_HIGH     QrCodeErrorCorrection = 3
+)
+
+// Enum value maps for QrCodeErrorCorrection.
+var (
+	QrCodeErrorCorrection_name = map[int32]string{
+		0: "MEDIUM",
+		1: "LOW",
+		2: "QUARTILE",
+		3: "HIGH",
+	}
+	QrCodeErrorCorrection_value = map[string]int32{
+		"MEDIUM":   0,
+		"LOW":      1,
+		"QUARTILE": 2,
+		"HIGH":     3,
+	}
+)
+
+func (x QrCodeErrorCorrection) Enum() *QrCodeErrorCorrection {
+	p := new(QrCodeErrorCorrection)
+	*p = x
+	return p
+}
+
+func (x QrCodeErrorCorrection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QrCodeErrorCorrection) Descriptor() protoreflect.EnumDescriptor {
+	return file_invoice_proto_enumTypes[5].Descriptor()
+}
+
+func (QrCodeErrorCorrection) Type() protoreflect.EnumType {
+	return &file_invoice_proto_enumTypes[5]
+}
+
+func (x QrCodeErrorCorrection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QrCodeErrorCorrection.Descriptor instead.
+func (QrCodeErrorCorrection) EnumDescriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{5}
+}
+
 type Invoice struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CryptoAddress         string                 `protobuf:"bytes,2,opt,name=cryptoAddress,proto3" json:"cryptoAddress,omitempty"`
-	Coin                  CoinType               `protobuf:"varint,3,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
-	RequiredAmount        float64                `protobuf:"fixed64,4,opt,name=requiredAmount,proto3" json:"requiredAmount,omitempty"`
-	ActualAmount          float64                `protobuf:"fixed64,5,opt,name=actualAmount,proto3" json:"actualAmount,omitempty"`
+	Id            string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CryptoAddress string   `protobuf:"bytes,2,opt,name=cryptoAddress,proto3" json:"cryptoAddress,omitempty"`
+	Coin          CoinType `protobuf:"varint,3,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	// Amounts are in atomic units (piconero, satoshi, wei, etc.) as base 10 integers.
+	RequiredAmount string `protobuf:"bytes,4,opt,name=requiredAmount,proto3" json:"requiredAmount,omitempty"`
+	// Sum of all the txs paying the invoice.
+	ActualAmount          string                 `protobuf:"bytes,5,opt,name=actualAmount,proto3" json:"actualAmount,omitempty"`
 	ConfirmationsRequired uint32                 `protobuf:"varint,6,opt,name=confirmationsRequired,proto3" json:"confirmationsRequired,omitempty"`
 	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	ConfirmedAt           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=confirmedAt,proto3" json:"confirmedAt,omitempty"`
 	Status                InvoiceStatusType      `protobuf:"varint,9,opt,name=status,proto3,enum=invoice.v1.InvoiceStatusType" json:"status,omitempty"`
 	ExpiresAt             *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
-	TxId                  string                 `protobuf:"bytes,11,opt,name=txId,proto3" json:"txId,omitempty"`
-	UserId                string                 `protobuf:"bytes,12,opt,name=userId,proto3" json:"userId,omitempty"`
+	// The tx which made the paid amount reach the required amount.
+	TxId             string            `protobuf:"bytes,11,opt,name=txId,proto3" json:"txId,omitempty"`
+	UserId           string            `protobuf:"bytes,12,opt,name=userId,proto3" json:"userId,omitempty"`
+	ExternalId       *string           `protobuf:"bytes,13,opt,name=externalId,proto3,oneof" json:"externalId,omitempty"`
+	Description      *string           `protobuf:"bytes,14,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Metadata         map[string]string `protobuf:"bytes,15,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	FiatAmount       *float64          `protobuf:"fixed64,16,opt,name=fiatAmount,proto3,oneof" json:"fiatAmount,omitempty"`
+	FiatCurrency     *string           `protobuf:"bytes,17,opt,name=fiatCurrency,proto3,oneof" json:"fiatCurrency,omitempty"`
+	ExchangeRate     *float64          `protobuf:"fixed64,18,opt,name=exchangeRate,proto3,oneof" json:"exchangeRate,omitempty"`
+	RateSource       *string           `protobuf:"bytes,19,opt,name=rateSource,proto3,oneof" json:"rateSource,omitempty"`
+	TolerancePercent *float64          `protobuf:"fixed64,20,opt,name=tolerancePercent,proto3,oneof" json:"tolerancePercent,omitempty"`
+	ToleranceAmount  *string           `protobuf:"bytes,21,opt,name=toleranceAmount,proto3,oneof" json:"toleranceAmount,omitempty"`
+	// Set once the invoice is paid or expires partially paid.
+	PaymentOutcome *PaymentOutcomeType `protobuf:"varint,22,opt,name=paymentOutcome,proto3,enum=invoice.v1.PaymentOutcomeType,oneof" json:"paymentOutcome,omitempty"`
+	// The surplus or the shortfall in atomic units depending on paymentOutcome.
+	PaymentOutcomeAmount *string `protobuf:"bytes,23,opt,name=paymentOutcomeAmount,proto3,oneof" json:"paymentOutcomeAmount,omitempty"`
+	// Set if the invoice is one of the coin options of a payment request.
+	PaymentRequestId *string `protobuf:"bytes,24,opt,name=paymentRequestId,proto3,oneof" json:"paymentRequestId,omitempty"`
+	// The lowest confirmation count among the txs paying the invoice.
+	Confirmations uint32 `protobuf:"varint,25,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	// The coin identifier (e.g. USDT_ERC20). Unlike coin, it's set for the tokens missing from CoinType as well.
+	CoinId string `protobuf:"bytes,26,opt,name=coinId,proto3" json:"coinId,omitempty"`
 }
 
 func (x *Invoice) Reset() {
@@ -145,18 +423,18 @@ func (x *Invoice) GetCoin() CoinType {
 	return CoinType_XMR
 }
 
-func (x *Invoice) GetRequiredAmount() float64 {
+func (x *Invoice) GetRequiredAmount() string {
 	if x != nil {
 		return x.RequiredAmount
 	}
-	return 0
+	return ""
 }
 
-func (x *Invoice) GetActualAmount() float64 {
+func (x *Invoice) GetActualAmount() string {
 	if x != nil {
 		return x.ActualAmount
 	}
-	return 0
+	return ""
 }
 
 func (x *Invoice) GetConfirmationsRequired() uint32 {
@@ -208,20 +486,115 @@ func (x *Invoice) GetUserId() string {
 	return ""
 }
 
-type CreateInvoiceRequest struct {
+func (x *Invoice) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *Invoice) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Invoice) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Invoice) GetFiatAmount() float64 {
+	if x != nil && x.FiatAmount != nil {
+		return *x.FiatAmount
+	}
+	return 0
+}
+
+func (x *Invoice) GetFiatCurrency() string {
+	if x != nil && x.FiatCurrency != nil {
+		return *x.FiatCurrency
+	}
+	return ""
+}
+
+func (x *Invoice) GetExchangeRate() float64 {
+	if x != nil && x.ExchangeRate != nil {
+		return *x.ExchangeRate
+	}
+	return 0
+}
+
+func (x *Invoice) GetRateSource() string {
+	if x != nil && x.RateSource != nil {
+		return *x.RateSource
+	}
+	return ""
+}
+
+func (x *Invoice) GetTolerancePercent() float64 {
+	if x != nil && x.TolerancePercent != nil {
+		return *x.TolerancePercent
+	}
+	return 0
+}
+
+func (x *Invoice) GetToleranceAmount() string {
+	if x != nil && x.ToleranceAmount != nil {
+		return *x.ToleranceAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetPaymentOutcome() PaymentOutcomeType {
+	if x != nil && x.PaymentOutcome != nil {
+		return *x.PaymentOutcome
+	}
+	return PaymentOutcomeType_OVERPAID
+}
+
+func (x *Invoice) GetPaymentOutcomeAmount() string {
+	if x != nil && x.PaymentOutcomeAmount != nil {
+		return *x.PaymentOutcomeAmount
+	}
+	return ""
+}
+
+func (x *Invoice) GetPaymentRequestId() string {
+	if x != nil && x.PaymentRequestId != nil {
+		return *x.PaymentRequestId
+	}
+	return ""
+}
+
+func (x *Invoice) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Invoice) GetCoinId() string {
+	if x != nil {
+		return x.CoinId
+	}
+	return ""
+}
+
+type FiatAmount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId        string   `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Coin          CoinType `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
-	Amount        float64  `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Timeout       uint64   `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Confirmations uint32   `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Amount   float64 `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency string  `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
 }
 
-func (x *CreateInvoiceRequest) Reset() {
-	*x = CreateInvoiceRequest{}
+func (x *FiatAmount) Reset() {
+	*x = FiatAmount{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_invoice_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -229,13 +602,13 @@ func (x *CreateInvoiceRequest) Reset() {
 	}
 }
 
-func (x *CreateInvoiceRequest) String() string {
+func (x *FiatAmount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateInvoiceRequest) ProtoMessage() {}
+func (*FiatAmount) ProtoMessage() {}
 
-func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+func (x *FiatAmount) ProtoReflect() protoreflect.Message {
 	mi := &file_invoice_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -247,72 +620,1355 @@ func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
-func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FiatAmount.ProtoReflect.Descriptor instead.
+func (*FiatAmount) Descriptor() ([]byte, []int) {
 	return file_invoice_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreateInvoiceRequest) GetUserId() string {
+func (x *FiatAmount) GetAmount() float64 {
 	if x != nil {
-		return x.UserId
+		return x.Amount
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateInvoiceRequest) GetCoin() CoinType {
+func (x *FiatAmount) GetCurrency() string {
 	if x != nil {
-		return x.Coin
+		return x.Currency
 	}
-	return CoinType_XMR
+	return ""
 }
 
-func (x *CreateInvoiceRequest) GetAmount() float64 {
-	if x != nil {
-		return x.Amount
+// Shortfall which is still accepted as a full payment.
+type PaymentTolerance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Value:
+	//	*PaymentTolerance_Percent
+	//	*PaymentTolerance_Amount
+	Value isPaymentTolerance_Value `protobuf_oneof:"value"`
+}
+
+func (x *PaymentTolerance) Reset() {
+	*x = PaymentTolerance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentTolerance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentTolerance) ProtoMessage() {}
+
+func (x *PaymentTolerance) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentTolerance.ProtoReflect.Descriptor instead.
+func (*PaymentTolerance) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *PaymentTolerance) GetValue() isPaymentTolerance_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *PaymentTolerance) GetPercent() float64 {
+	if x, ok := x.GetValue().(*PaymentTolerance_Percent); ok {
+		return x.Percent
 	}
 	return 0
 }
 
-func (x *CreateInvoiceRequest) GetTimeout() uint64 {
-	if x != nil {
-		return x.Timeout
+func (x *PaymentTolerance) GetAmount() string {
+	if x, ok := x.GetValue().(*PaymentTolerance_Amount); ok {
+		return x.Amount
+	}
+	return ""
+}
+
+type isPaymentTolerance_Value interface {
+	isPaymentTolerance_Value()
+}
+
+type PaymentTolerance_Percent struct {
+	// Percentage of the required amount.
+	Percent float64 `protobuf:"fixed64,1,opt,name=percent,proto3,oneof"`
+}
+
+type PaymentTolerance_Amount struct {
+	// Amount in atomic units.
+	Amount string `protobuf:"bytes,2,opt,name=amount,proto3,oneof"`
+}
+
+func (*PaymentTolerance_Percent) isPaymentTolerance_Value() {}
+
+func (*PaymentTolerance_Amount) isPaymentTolerance_Value() {}
+
+type CreateInvoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string   `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Coin   CoinType `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	// Amount in atomic units (piconero, satoshi, wei, etc.) as a base 10 integer.
+	Amount         string            `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timeout        uint64            `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Confirmations  uint32            `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	IdempotencyKey *string           `protobuf:"bytes,6,opt,name=idempotencyKey,proto3,oneof" json:"idempotencyKey,omitempty"`
+	ExternalId     *string           `protobuf:"bytes,7,opt,name=externalId,proto3,oneof" json:"externalId,omitempty"`
+	Description    *string           `protobuf:"bytes,8,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Metadata       map[string]string `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Fiat           *FiatAmount       `protobuf:"bytes,10,opt,name=fiat,proto3,oneof" json:"fiat,omitempty"`
+	// Defaults to the user's tolerance.
+	Tolerance *PaymentTolerance `protobuf:"bytes,11,opt,name=tolerance,proto3,oneof" json:"tolerance,omitempty"`
+	// Identifier of the coin or of a token from ListTokens. Takes precedence over coin.
+	CoinId *string `protobuf:"bytes,12,opt,name=coinId,proto3,oneof" json:"coinId,omitempty"`
+}
+
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateInvoiceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetCoin() CoinType {
+	if x != nil {
+		return x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *CreateInvoiceRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetTimeout() uint64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetFiat() *FiatAmount {
+	if x != nil {
+		return x.Fiat
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetTolerance() *PaymentTolerance {
+	if x != nil {
+		return x.Tolerance
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetCoinId() string {
+	if x != nil && x.CoinId != nil {
+		return *x.CoinId
+	}
+	return ""
+}
+
+type CreateInvoiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaymentId string `protobuf:"bytes,1,opt,name=paymentId,proto3" json:"paymentId,omitempty"`
+	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Wallet URI paying the invoice: BIP21 for BTC/LTC, monero: for XMR and EIP-681 for ETH/BNB and tokens.
+	PaymentUri string `protobuf:"bytes,3,opt,name=paymentUri,proto3" json:"paymentUri,omitempty"`
+}
+
+func (x *CreateInvoiceResponse) Reset() {
+	*x = CreateInvoiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceResponse) ProtoMessage() {}
+
+func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateInvoiceResponse) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetPaymentUri() string {
+	if x != nil {
+		return x.PaymentUri
+	}
+	return ""
+}
+
+type PaymentRequestCoin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coin CoinType `protobuf:"varint,1,opt,name=coin,proto3,enum=crypto.v1.CoinType" json:"coin,omitempty"`
+	// Amount in atomic units. Converted from the request's fiat amount if not set.
+	Amount *string `protobuf:"bytes,2,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	// Identifier of the coin or of a token from ListTokens. Takes precedence over coin.
+	CoinId *string `protobuf:"bytes,3,opt,name=coinId,proto3,oneof" json:"coinId,omitempty"`
+}
+
+func (x *PaymentRequestCoin) Reset() {
+	*x = PaymentRequestCoin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentRequestCoin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRequestCoin) ProtoMessage() {}
+
+func (x *PaymentRequestCoin) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentRequestCoin.ProtoReflect.Descriptor instead.
+func (*PaymentRequestCoin) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PaymentRequestCoin) GetCoin() CoinType {
+	if x != nil {
+		return x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *PaymentRequestCoin) GetAmount() string {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return ""
+}
+
+func (x *PaymentRequestCoin) GetCoinId() string {
+	if x != nil && x.CoinId != nil {
+		return *x.CoinId
+	}
+	return ""
+}
+
+// A payment request reserves an invoice in each coin. The first confirmed invoice pays the request and cancels the others.
+type PaymentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                   `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Status        PaymentRequestStatusType `protobuf:"varint,3,opt,name=status,proto3,enum=invoice.v1.PaymentRequestStatusType" json:"status,omitempty"`
+	PaidInvoiceId *string                  `protobuf:"bytes,4,opt,name=paidInvoiceId,proto3,oneof" json:"paidInvoiceId,omitempty"`
+	Invoices      []*Invoice               `protobuf:"bytes,5,rep,name=invoices,proto3" json:"invoices,omitempty"`
+	CreatedAt     *timestamppb.Timestamp   `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	PaidAt        *timestamppb.Timestamp   `protobuf:"bytes,7,opt,name=paidAt,proto3,oneof" json:"paidAt,omitempty"`
+}
+
+func (x *PaymentRequest) Reset() {
+	*x = PaymentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRequest) ProtoMessage() {}
+
+func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentRequest.ProtoReflect.Descriptor instead.
+func (*PaymentRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PaymentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetStatus() PaymentRequestStatusType {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentRequestStatusType_PAYMENT_REQUEST_PENDING
+}
+
+func (x *PaymentRequest) GetPaidInvoiceId() string {
+	if x != nil && x.PaidInvoiceId != nil {
+		return *x.PaidInvoiceId
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetInvoices() []*Invoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+func (x *PaymentRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PaymentRequest) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+type CreatePaymentRequestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId        string                `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Coins         []*PaymentRequestCoin `protobuf:"bytes,2,rep,name=coins,proto3" json:"coins,omitempty"`
+	Fiat          *FiatAmount           `protobuf:"bytes,3,opt,name=fiat,proto3,oneof" json:"fiat,omitempty"`
+	Timeout       uint64                `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Confirmations uint32                `protobuf:"varint,5,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	ExternalId    *string               `protobuf:"bytes,6,opt,name=externalId,proto3,oneof" json:"externalId,omitempty"`
+	Description   *string               `protobuf:"bytes,7,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Metadata      map[string]string     `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CreatePaymentRequestRequest) Reset() {
+	*x = CreatePaymentRequestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatePaymentRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentRequestRequest) ProtoMessage() {}
+
+func (x *CreatePaymentRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePaymentRequestRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreatePaymentRequestRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequestRequest) GetCoins() []*PaymentRequestCoin {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+func (x *CreatePaymentRequestRequest) GetFiat() *FiatAmount {
+	if x != nil {
+		return x.Fiat
+	}
+	return nil
+}
+
+func (x *CreatePaymentRequestRequest) GetTimeout() uint64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *CreatePaymentRequestRequest) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *CreatePaymentRequestRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequestRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreatePaymentRequestRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type CreatePaymentRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaymentRequest *PaymentRequest `protobuf:"bytes,1,opt,name=paymentRequest,proto3" json:"paymentRequest,omitempty"`
+}
+
+func (x *CreatePaymentRequestResponse) Reset() {
+	*x = CreatePaymentRequestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreatePaymentRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentRequestResponse) ProtoMessage() {}
+
+func (x *CreatePaymentRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePaymentRequestResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreatePaymentRequestResponse) GetPaymentRequest() *PaymentRequest {
+	if x != nil {
+		return x.PaymentRequest
+	}
+	return nil
+}
+
+type GetPaymentRequestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetPaymentRequestRequest) Reset() {
+	*x = GetPaymentRequestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPaymentRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentRequestRequest) ProtoMessage() {}
+
+func (x *GetPaymentRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentRequestRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetPaymentRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPaymentRequestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PaymentRequest *PaymentRequest `protobuf:"bytes,1,opt,name=paymentRequest,proto3" json:"paymentRequest,omitempty"`
+}
+
+func (x *GetPaymentRequestResponse) Reset() {
+	*x = GetPaymentRequestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPaymentRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentRequestResponse) ProtoMessage() {}
+
+func (x *GetPaymentRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentRequestResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentRequestResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPaymentRequestResponse) GetPaymentRequest() *PaymentRequest {
+	if x != nil {
+		return x.PaymentRequest
+	}
+	return nil
+}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetInvoiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetInvoiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type GetInvoiceQrCodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Format QrCodeFormat `protobuf:"varint,2,opt,name=format,proto3,enum=invoice.v1.QrCodeFormat" json:"format,omitempty"`
+	// Width and height of the image in pixels. Defaults to 256.
+	Size            *uint32               `protobuf:"varint,3,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	ErrorCorrection QrCodeErrorCorrection `protobuf:"varint,4,opt,name=errorCorrection,proto3,enum=invoice.v1.QrCodeErrorCorrection" json:"errorCorrection,omitempty"`
+}
+
+func (x *GetInvoiceQrCodeRequest) Reset() {
+	*x = GetInvoiceQrCodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceQrCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceQrCodeRequest) ProtoMessage() {}
+
+func (x *GetInvoiceQrCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceQrCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceQrCodeRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetInvoiceQrCodeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetInvoiceQrCodeRequest) GetFormat() QrCodeFormat {
+	if x != nil {
+		return x.Format
+	}
+	return QrCodeFormat_PNG
+}
+
+func (x *GetInvoiceQrCodeRequest) GetSize() uint32 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *GetInvoiceQrCodeRequest) GetErrorCorrection() QrCodeErrorCorrection {
+	if x != nil {
+		return x.ErrorCorrection
+	}
+	return QrCodeErrorCorrection_MEDIUM
+}
+
+type GetInvoiceQrCodeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Image       []byte `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// The payment URI encoded in the QR code.
+	PaymentUri string `protobuf:"bytes,3,opt,name=paymentUri,proto3" json:"paymentUri,omitempty"`
+}
+
+func (x *GetInvoiceQrCodeResponse) Reset() {
+	*x = GetInvoiceQrCodeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvoiceQrCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceQrCodeResponse) ProtoMessage() {}
+
+func (x *GetInvoiceQrCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceQrCodeResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceQrCodeResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetInvoiceQrCodeResponse) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *GetInvoiceQrCodeResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetInvoiceQrCodeResponse) GetPaymentUri() string {
+	if x != nil {
+		return x.PaymentUri
+	}
+	return ""
+}
+
+type ListInvoicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId      *string                `protobuf:"bytes,1,opt,name=userId,proto3,oneof" json:"userId,omitempty"`
+	Coin        *CoinType              `protobuf:"varint,2,opt,name=coin,proto3,enum=crypto.v1.CoinType,oneof" json:"coin,omitempty"`
+	Status      *InvoiceStatusType     `protobuf:"varint,3,opt,name=status,proto3,enum=invoice.v1.InvoiceStatusType,oneof" json:"status,omitempty"`
+	CreatedFrom *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=createdFrom,proto3" json:"createdFrom,omitempty"`
+	CreatedTo   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdTo,proto3" json:"createdTo,omitempty"`
+	ExpiresFrom *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expiresFrom,proto3" json:"expiresFrom,omitempty"`
+	ExpiresTo   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expiresTo,proto3" json:"expiresTo,omitempty"`
+	// Matches any of the txs paying the invoice.
+	TxId          *string `protobuf:"bytes,8,opt,name=txId,proto3,oneof" json:"txId,omitempty"`
+	Limit         uint32  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        uint32  `protobuf:"varint,10,opt,name=offset,proto3" json:"offset,omitempty"`
+	ExternalId    *string `protobuf:"bytes,11,opt,name=externalId,proto3,oneof" json:"externalId,omitempty"`
+	MetadataKey   *string `protobuf:"bytes,12,opt,name=metadataKey,proto3,oneof" json:"metadataKey,omitempty"`
+	MetadataValue *string `protobuf:"bytes,13,opt,name=metadataValue,proto3,oneof" json:"metadataValue,omitempty"`
+	// Takes precedence over coin.
+	CoinId *string `protobuf:"bytes,14,opt,name=coinId,proto3,oneof" json:"coinId,omitempty"`
+}
+
+func (x *ListInvoicesRequest) Reset() {
+	*x = ListInvoicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesRequest) ProtoMessage() {}
+
+func (x *ListInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListInvoicesRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetCoin() CoinType {
+	if x != nil && x.Coin != nil {
+		return *x.Coin
+	}
+	return CoinType_XMR
+}
+
+func (x *ListInvoicesRequest) GetStatus() InvoiceStatusType {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return InvoiceStatusType_PENDING
+}
+
+func (x *ListInvoicesRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetExpiresFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresFrom
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetExpiresTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresTo
+	}
+	return nil
+}
+
+func (x *ListInvoicesRequest) GetTxId() string {
+	if x != nil && x.TxId != nil {
+		return *x.TxId
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListInvoicesRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListInvoicesRequest) GetExternalId() string {
+	if x != nil && x.ExternalId != nil {
+		return *x.ExternalId
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetMetadataKey() string {
+	if x != nil && x.MetadataKey != nil {
+		return *x.MetadataKey
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetMetadataValue() string {
+	if x != nil && x.MetadataValue != nil {
+		return *x.MetadataValue
+	}
+	return ""
+}
+
+func (x *ListInvoicesRequest) GetCoinId() string {
+	if x != nil && x.CoinId != nil {
+		return *x.CoinId
+	}
+	return ""
+}
+
+type ListInvoicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invoices []*Invoice `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+}
+
+func (x *ListInvoicesResponse) Reset() {
+	*x = ListInvoicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesResponse) ProtoMessage() {}
+
+func (x *ListInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListInvoicesResponse) GetInvoices() []*Invoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+type CancelInvoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelInvoiceRequest) Reset() {
+	*x = CancelInvoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelInvoiceRequest) ProtoMessage() {}
+
+func (x *CancelInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CancelInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CancelInvoiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelInvoiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+}
+
+func (x *CancelInvoiceResponse) Reset() {
+	*x = CancelInvoiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelInvoiceResponse) ProtoMessage() {}
+
+func (x *CancelInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CancelInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+type InvoiceStatusStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserIds    []string            `protobuf:"bytes,1,rep,name=userIds,proto3" json:"userIds,omitempty"`
+	InvoiceIds []string            `protobuf:"bytes,2,rep,name=invoiceIds,proto3" json:"invoiceIds,omitempty"`
+	Coins      []CoinType          `protobuf:"varint,3,rep,packed,name=coins,proto3,enum=crypto.v1.CoinType" json:"coins,omitempty"`
+	Statuses   []InvoiceStatusType `protobuf:"varint,4,rep,packed,name=statuses,proto3,enum=invoice.v1.InvoiceStatusType" json:"statuses,omitempty"`
+	// The sequence of the last received event. The events published after it are replayed first.
+	FromSequence *uint64  `protobuf:"varint,5,opt,name=fromSequence,proto3,oneof" json:"fromSequence,omitempty"`
+	CoinIds      []string `protobuf:"bytes,6,rep,name=coinIds,proto3" json:"coinIds,omitempty"`
+}
+
+func (x *InvoiceStatusStreamRequest) Reset() {
+	*x = InvoiceStatusStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_invoice_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InvoiceStatusStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceStatusStreamRequest) ProtoMessage() {}
+
+func (x *InvoiceStatusStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceStatusStreamRequest.ProtoReflect.Descriptor instead.
+func (*InvoiceStatusStreamRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *InvoiceStatusStreamRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetInvoiceIds() []string {
+	if x != nil {
+		return x.InvoiceIds
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetCoins() []CoinType {
+	if x != nil {
+		return x.Coins
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetStatuses() []InvoiceStatusType {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *InvoiceStatusStreamRequest) GetFromSequence() uint64 {
+	if x != nil && x.FromSequence != nil {
+		return *x.FromSequence
 	}
 	return 0
 }
 
-func (x *CreateInvoiceRequest) GetConfirmations() uint32 {
+func (x *InvoiceStatusStreamRequest) GetCoinIds() []string {
 	if x != nil {
-		return x.Confirmations
+		return x.CoinIds
 	}
-	return 0
+	return nil
 }
 
-type CreateInvoiceResponse struct {
+type InvoiceStatusStreamResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PaymentId string `protobuf:"bytes,1,opt,name=paymentId,proto3" json:"paymentId,omitempty"`
-	Address   string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	// Assigned when the event is published, so it grows in the commit order.
+	Sequence uint64           `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type     InvoiceEventType `protobuf:"varint,3,opt,name=type,proto3,enum=invoice.v1.InvoiceEventType" json:"type,omitempty"`
 }
 
-func (x *CreateInvoiceResponse) Reset() {
-	*x = CreateInvoiceResponse{}
+func (x *InvoiceStatusStreamResponse) Reset() {
+	*x = InvoiceStatusStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_invoice_proto_msgTypes[2]
+		mi := &file_invoice_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateInvoiceResponse) String() string {
+func (x *InvoiceStatusStreamResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateInvoiceResponse) ProtoMessage() {}
+func (*InvoiceStatusStreamResponse) ProtoMessage() {}
 
-func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_invoice_proto_msgTypes[2]
+func (x *InvoiceStatusStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,48 +1979,55 @@ func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
-func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
-	return file_invoice_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use InvoiceStatusStreamResponse.ProtoReflect.Descriptor instead.
+func (*InvoiceStatusStreamResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CreateInvoiceResponse) GetPaymentId() string {
+func (x *InvoiceStatusStreamResponse) GetInvoice() *Invoice {
 	if x != nil {
-		return x.PaymentId
+		return x.Invoice
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateInvoiceResponse) GetAddress() string {
+func (x *InvoiceStatusStreamResponse) GetSequence() uint64 {
 	if x != nil {
-		return x.Address
+		return x.Sequence
 	}
-	return ""
+	return 0
 }
 
-type InvoiceStatusStreamRequest struct {
+func (x *InvoiceStatusStreamResponse) GetType() InvoiceEventType {
+	if x != nil {
+		return x.Type
+	}
+	return InvoiceEventType_STATUS_CHANGED
+}
+
+type ListTokensRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *InvoiceStatusStreamRequest) Reset() {
-	*x = InvoiceStatusStreamRequest{}
+func (x *ListTokensRequest) Reset() {
+	*x = ListTokensRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_invoice_proto_msgTypes[3]
+		mi := &file_invoice_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InvoiceStatusStreamRequest) String() string {
+func (x *ListTokensRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvoiceStatusStreamRequest) ProtoMessage() {}
+func (*ListTokensRequest) ProtoMessage() {}
 
-func (x *InvoiceStatusStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_invoice_proto_msgTypes[3]
+func (x *ListTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -375,36 +2038,36 @@ func (x *InvoiceStatusStreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvoiceStatusStreamRequest.ProtoReflect.Descriptor instead.
-func (*InvoiceStatusStreamRequest) Descriptor() ([]byte, []int) {
-	return file_invoice_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ListTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListTokensRequest) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{21}
 }
 
-type InvoiceStatusStreamResponse struct {
+type ListTokensResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Invoice *Invoice `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	Tokens []*Token `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
 }
 
-func (x *InvoiceStatusStreamResponse) Reset() {
-	*x = InvoiceStatusStreamResponse{}
+func (x *ListTokensResponse) Reset() {
+	*x = ListTokensResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_invoice_proto_msgTypes[4]
+		mi := &file_invoice_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InvoiceStatusStreamResponse) String() string {
+func (x *ListTokensResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvoiceStatusStreamResponse) ProtoMessage() {}
+func (*ListTokensResponse) ProtoMessage() {}
 
-func (x *InvoiceStatusStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_invoice_proto_msgTypes[4]
+func (x *ListTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_invoice_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,14 +2078,14 @@ func (x *InvoiceStatusStreamResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvoiceStatusStreamResponse.ProtoReflect.Descriptor instead.
-func (*InvoiceStatusStreamResponse) Descriptor() ([]byte, []int) {
-	return file_invoice_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListTokensResponse) Descriptor() ([]byte, []int) {
+	return file_invoice_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *InvoiceStatusStreamResponse) GetInvoice() *Invoice {
+func (x *ListTokensResponse) GetTokens() []*Token {
 	if x != nil {
-		return x.Invoice
+		return x.Tokens
 	}
 	return nil
 }
@@ -434,7 +2097,7 @@ var file_invoice_proto_rawDesc = []byte{
 	0x0a, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
 	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0c, 0x63, 0x72,
-	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xff, 0x03, 0x0a, 0x07, 0x49,
+	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x81, 0x0b, 0x0a, 0x07, 0x49,
 	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f,
 	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63,
@@ -442,10 +2105,10 @@ var file_invoice_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79,
 	0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52,
 	0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x72,
+	0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72,
 	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a,
 	0x0c, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
 	0x74, 0x12, 0x34, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d,
 	0x52, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
@@ -466,41 +2129,384 @@ var file_invoice_proto_rawDesc = []byte{
 	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
 	0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
 	0x74, 0x78, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xaf, 0x01, 0x0a,
-	0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a,
-	0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72,
-	0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4f,
-	0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65,
-	0x6e, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x79, 0x6d,
-	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
-	0x1c, 0x0a, 0x1a, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4c, 0x0a,
-	0x1b, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07,
-	0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69,
-	0x63, 0x65, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2a, 0x51, 0x0a, 0x11, 0x49,
-	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x13, 0x0a,
-	0x0f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x4d, 0x50, 0x4f, 0x4f, 0x4c,
-	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02, 0x12,
-	0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x03, 0x32, 0xd0,
-	0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x54, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69,
-	0x63, 0x65, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0a,
+	0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x3d, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0a, 0x66, 0x69, 0x61, 0x74, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x0a, 0x66,
+	0x69, 0x61, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c,
+	0x66, 0x69, 0x61, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x03, 0x52, 0x0c, 0x66, 0x69, 0x61, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x61, 0x74, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x48, 0x04, 0x52, 0x0c, 0x65,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x23,
+	0x0a, 0x0a, 0x72, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x05, 0x52, 0x0a, 0x72, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x10, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x01, 0x48, 0x06, 0x52,
+	0x10, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x0f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63,
+	0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x48, 0x07, 0x52,
+	0x0f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x4b, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x75,
+	0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x48, 0x08, 0x52, 0x0e, 0x70,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x37, 0x0a, 0x14, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x48, 0x09,
+	0x52, 0x14, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x10, 0x70, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x0a, 0x52, 0x10, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x19, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x49, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x69, 0x61, 0x74, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x66, 0x69, 0x61, 0x74, 0x43, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x63, 0x79, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x61, 0x74, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e,
+	0x63, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x74, 0x6f,
+	0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x11, 0x0a,
+	0x0f, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x42, 0x17, 0x0a, 0x15, 0x5f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x40,
+	0x0a, 0x0a, 0x46, 0x69, 0x61, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x22, 0x51, 0x0a, 0x10, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72,
+	0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x12, 0x18, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x94, 0x05, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88,
+	0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x4a,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2e, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2f, 0x0a, 0x04, 0x66, 0x69,
+	0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x61, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x48, 0x03, 0x52, 0x04, 0x66, 0x69, 0x61, 0x74, 0x88, 0x01, 0x01, 0x12, 0x3f, 0x0a, 0x09, 0x74,
+	0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x04, 0x52, 0x09,
+	0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06,
+	0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x06,
+	0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x66, 0x69, 0x61,
+	0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x42,
+	0x09, 0x0a, 0x07, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x6f, 0x0a, 0x15, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x69, 0x22, 0x8d, 0x01, 0x0a, 0x12,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f,
+	0x69, 0x6e, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x1b, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x63, 0x6f, 0x69, 0x6e,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x06, 0x63, 0x6f, 0x69, 0x6e,
+	0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x42, 0x09, 0x0a, 0x07, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x22, 0xe2, 0x02, 0x0a, 0x0e,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x29, 0x0a, 0x0d, 0x70, 0x61, 0x69, 0x64, 0x49, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0d, 0x70,
+	0x61, 0x69, 0x64, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x2f, 0x0a, 0x08, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x08, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73,
+	0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x70, 0x61,
+	0x69, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x01, 0x52, 0x06, 0x70, 0x61, 0x69, 0x64, 0x41, 0x74,
+	0x88, 0x01, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x49, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x41, 0x74,
+	0x22, 0xe0, 0x03, 0x0a, 0x1b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x05, 0x63, 0x6f, 0x69, 0x6e,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x43, 0x6f, 0x69, 0x6e, 0x52, 0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x12, 0x2f,
+	0x0a, 0x04, 0x66, 0x69, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x61, 0x74, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x61, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x23, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x51, 0x0a, 0x08, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e,
+	0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f,
+	0x66, 0x69, 0x61, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x49, 0x64, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x62, 0x0a, 0x1c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x5f, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x42, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x0e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x23, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x43, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2d, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x22, 0xca,
+	0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x51, 0x72, 0x43,
+	0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x17, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x4b, 0x0a, 0x0f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f,
+	0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21,
+	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x72, 0x43, 0x6f,
+	0x64, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x72, 0x0a, 0x18, 0x47,
+	0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x51, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x69, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x69, 0x22,
+	0xcb, 0x05, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x48, 0x01, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x3a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70,
+	0x65, 0x48, 0x02, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3c,
+	0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x38, 0x0a, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x46, 0x72, 0x6f, 0x6d, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x54,
+	0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x54, 0x6f, 0x12, 0x17,
+	0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x04,
+	0x74, 0x78, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x49, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x0a, 0x65, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b, 0x65, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x05, 0x52, 0x0b, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b, 0x65, 0x79, 0x88, 0x01,
+	0x01, 0x12, 0x29, 0x0a, 0x0d, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x06, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06,
+	0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x48, 0x07, 0x52, 0x06,
+	0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x74, 0x78, 0x49,
+	0x64, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64,
+	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4b, 0x65, 0x79,
+	0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x47, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x08, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x46,
+	0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x07, 0x69,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x22, 0x90, 0x02, 0x0a, 0x1a, 0x49, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12,
+	0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x64, 0x73, 0x12,
+	0x29, 0x0a, 0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69,
+	0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0c, 0x66,
+	0x72, 0x6f, 0x6d, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x69, 0x6e, 0x49, 0x64, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x66, 0x72, 0x6f,
+	0x6d, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x1b, 0x49, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52,
+	0x07, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3e, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x28, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x2a, 0x8b, 0x01, 0x0a, 0x11,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x13,
+	0x0a, 0x0f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x45, 0x4d, 0x50, 0x4f, 0x4f,
+	0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x45, 0x44, 0x10, 0x03, 0x12,
+	0x0d, 0x0a, 0x09, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x12,
+	0x0a, 0x0e, 0x50, 0x41, 0x52, 0x54, 0x49, 0x41, 0x4c, 0x4c, 0x59, 0x5f, 0x50, 0x41, 0x49, 0x44,
+	0x10, 0x05, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x41, 0x49, 0x44, 0x5f, 0x41, 0x46, 0x54, 0x45, 0x52,
+	0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x59, 0x10, 0x06, 0x2a, 0x31, 0x0a, 0x12, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x0c, 0x0a, 0x08, 0x4f, 0x56, 0x45, 0x52, 0x50, 0x41, 0x49, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a,
+	0x09, 0x55, 0x4e, 0x44, 0x45, 0x52, 0x50, 0x41, 0x49, 0x44, 0x10, 0x01, 0x2a, 0x6e, 0x0a, 0x18,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x50, 0x41, 0x59, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x50, 0x45, 0x4e, 0x44,
+	0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x50, 0x41, 0x49, 0x44, 0x10, 0x01, 0x12,
+	0x1b, 0x0a, 0x17, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45,
+	0x53, 0x54, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x02, 0x2a, 0x56, 0x0a, 0x10,
+	0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x52, 0x4d, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12,
+	0x13, 0x0a, 0x0f, 0x4d, 0x45, 0x4d, 0x50, 0x4f, 0x4f, 0x4c, 0x5f, 0x44, 0x52, 0x4f, 0x50, 0x50,
+	0x45, 0x44, 0x10, 0x02, 0x2a, 0x20, 0x0a, 0x0c, 0x51, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x07, 0x0a,
+	0x03, 0x53, 0x56, 0x47, 0x10, 0x01, 0x2a, 0x44, 0x0a, 0x15, 0x51, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0a, 0x0a, 0x06, 0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4c,
+	0x4f, 0x57, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x51, 0x55, 0x41, 0x52, 0x54, 0x49, 0x4c, 0x45,
+	0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48, 0x10, 0x03, 0x32, 0xbf, 0x06, 0x0a,
+	0x0e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x54, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x12, 0x20, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x5d, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x51, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x51, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f,
+	0x69, 0x63, 0x65, 0x51, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x51, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x1f, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x49, 0x6e, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a, 0x14, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x27, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x69, 0x6e,
+	0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x2e, 0x69, 0x6e, 0x76,
+	0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x25, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x68, 0x0a, 0x13, 0x49, 0x6e, 0x76, 0x6f, 0x69,
 	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x26,
 	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f,
@@ -508,7 +2514,12 @@ var file_invoice_proto_rawDesc = []byte{
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65,
 	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
 	0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
-	0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x12, 0x4b, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12,
+	0x1d, 0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x69, 0x6e, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -523,35 +2534,106 @@ func file_invoice_proto_rawDescGZIP() []byte {
 	return file_invoice_proto_rawDescData
 }
 
-var file_invoice_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_invoice_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_invoice_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_invoice_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_invoice_proto_goTypes = []any{
-	(InvoiceStatusType)(0),              // 0: invoice.v1.InvoiceStatusType
-	(*Invoice)(nil),                     // 1: invoice.v1.Invoice
-	(*CreateInvoiceRequest)(nil),        // 2: invoice.v1.CreateInvoiceRequest
-	(*CreateInvoiceResponse)(nil),       // 3: invoice.v1.CreateInvoiceResponse
-	(*InvoiceStatusStreamRequest)(nil),  // 4: invoice.v1.InvoiceStatusStreamRequest
-	(*InvoiceStatusStreamResponse)(nil), // 5: invoice.v1.InvoiceStatusStreamResponse
-	(CoinType)(0),                       // 6: crypto.v1.CoinType
-	(*timestamppb.Timestamp)(nil),       // 7: google.protobuf.Timestamp
+	(InvoiceStatusType)(0),               // 0: invoice.v1.InvoiceStatusType
+	(PaymentOutcomeType)(0),              // 1: invoice.v1.PaymentOutcomeType
+	(PaymentRequestStatusType)(0),        // 2: invoice.v1.PaymentRequestStatusType
+	(InvoiceEventType)(0),                // 3: invoice.v1.InvoiceEventType
+	(QrCodeFormat)(0),                    // 4: invoice.v1.QrCodeFormat
+	(QrCodeErrorCorrection)(0),           // 5: invoice.v1.QrCodeErrorCorrection
+	(*Invoice)(nil),                      // 6: invoice.v1.Invoice
+	(*FiatAmount)(nil),                   // 7: invoice.v1.FiatAmount
+	(*PaymentTolerance)(nil),             // 8: invoice.v1.PaymentTolerance
+	(*CreateInvoiceRequest)(nil),         // 9: invoice.v1.CreateInvoiceRequest
+	(*CreateInvoiceResponse)(nil),        // 10: invoice.v1.CreateInvoiceResponse
+	(*PaymentRequestCoin)(nil),           // 11: invoice.v1.PaymentRequestCoin
+	(*PaymentRequest)(nil),               // 12: invoice.v1.PaymentRequest
+	(*CreatePaymentRequestRequest)(nil),  // 13: invoice.v1.CreatePaymentRequestRequest
+	(*CreatePaymentRequestResponse)(nil), // 14: invoice.v1.CreatePaymentRequestResponse
+	(*GetPaymentRequestRequest)(nil),     // 15: invoice.v1.GetPaymentRequestRequest
+	(*GetPaymentRequestResponse)(nil),    // 16: invoice.v1.GetPaymentRequestResponse
+	(*GetInvoiceRequest)(nil),            // 17: invoice.v1.GetInvoiceRequest
+	(*GetInvoiceResponse)(nil),           // 18: invoice.v1.GetInvoiceResponse
+	(*GetInvoiceQrCodeRequest)(nil),      // 19: invoice.v1.GetInvoiceQrCodeRequest
+	(*GetInvoiceQrCodeResponse)(nil),     // 20: invoice.v1.GetInvoiceQrCodeResponse
+	(*ListInvoicesRequest)(nil),          // 21: invoice.v1.ListInvoicesRequest
+	(*ListInvoicesResponse)(nil),         // 22: invoice.v1.ListInvoicesResponse
+	(*CancelInvoiceRequest)(nil),         // 23: invoice.v1.CancelInvoiceRequest
+	(*CancelInvoiceResponse)(nil),        // 24: invoice.v1.CancelInvoiceResponse
+	(*InvoiceStatusStreamRequest)(nil),   // 25: invoice.v1.InvoiceStatusStreamRequest
+	(*InvoiceStatusStreamResponse)(nil),  // 26: invoice.v1.InvoiceStatusStreamResponse
+	(*ListTokensRequest)(nil),            // 27: invoice.v1.ListTokensRequest
+	(*ListTokensResponse)(nil),           // 28: invoice.v1.ListTokensResponse
+	nil,                                  // 29: invoice.v1.Invoice.MetadataEntry
+	nil,                                  // 30: invoice.v1.CreateInvoiceRequest.MetadataEntry
+	nil,                                  // 31: invoice.v1.CreatePaymentRequestRequest.MetadataEntry
+	(CoinType)(0),                        // 32: crypto.v1.CoinType
+	(*timestamppb.Timestamp)(nil),        // 33: google.protobuf.Timestamp
+	(*Token)(nil),                        // 34: crypto.v1.Token
 }
 var file_invoice_proto_depIdxs = []int32{
-	6, // 0: invoice.v1.Invoice.coin:type_name -> crypto.v1.CoinType
-	7, // 1: invoice.v1.Invoice.createdAt:type_name -> google.protobuf.Timestamp
-	7, // 2: invoice.v1.Invoice.confirmedAt:type_name -> google.protobuf.Timestamp
-	0, // 3: invoice.v1.Invoice.status:type_name -> invoice.v1.InvoiceStatusType
-	7, // 4: invoice.v1.Invoice.expiresAt:type_name -> google.protobuf.Timestamp
-	6, // 5: invoice.v1.CreateInvoiceRequest.coin:type_name -> crypto.v1.CoinType
-	1, // 6: invoice.v1.InvoiceStatusStreamResponse.invoice:type_name -> invoice.v1.Invoice
-	2, // 7: invoice.v1.InvoiceService.CreateInvoice:input_type -> invoice.v1.CreateInvoiceRequest
-	4, // 8: invoice.v1.InvoiceService.InvoiceStatusStream:input_type -> invoice.v1.InvoiceStatusStreamRequest
-	3, // 9: invoice.v1.InvoiceService.CreateInvoice:output_type -> invoice.v1.CreateInvoiceResponse
-	5, // 10: invoice.v1.InvoiceService.InvoiceStatusStream:output_type -> invoice.v1.InvoiceStatusStreamResponse
-	9, // [9:11] is the sub-list for method output_type
-	7, // [7:9] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	32, // 0: invoice.v1.Invoice.coin:type_name -> crypto.v1.CoinType
+	33, // 1: invoice.v1.Invoice.createdAt:type_name -> google.protobuf.Timestamp
+	33, // 2: invoice.v1.Invoice.confirmedAt:type_name -> google.protobuf.Timestamp
+	0,  // 3: invoice.v1.Invoice.status:type_name -> invoice.v1.InvoiceStatusType
+	33, // 4: invoice.v1.Invoice.expiresAt:type_name -> google.protobuf.Timestamp
+	29, // 5: invoice.v1.Invoice.metadata:type_name -> invoice.v1.Invoice.MetadataEntry
+	1,  // 6: invoice.v1.Invoice.paymentOutcome:type_name -> invoice.v1.PaymentOutcomeType
+	32, // 7: invoice.v1.CreateInvoiceRequest.coin:type_name -> crypto.v1.CoinType
+	30, // 8: invoice.v1.CreateInvoiceRequest.metadata:type_name -> invoice.v1.CreateInvoiceRequest.MetadataEntry
+	7,  // 9: invoice.v1.CreateInvoiceRequest.fiat:type_name -> invoice.v1.FiatAmount
+	8,  // 10: invoice.v1.CreateInvoiceRequest.tolerance:type_name -> invoice.v1.PaymentTolerance
+	32, // 11: invoice.v1.PaymentRequestCoin.coin:type_name -> crypto.v1.CoinType
+	2,  // 12: invoice.v1.PaymentRequest.status:type_name -> invoice.v1.PaymentRequestStatusType
+	6,  // 13: invoice.v1.PaymentRequest.invoices:type_name -> invoice.v1.Invoice
+	33, // 14: invoice.v1.PaymentRequest.createdAt:type_name -> google.protobuf.Timestamp
+	33, // 15: invoice.v1.PaymentRequest.paidAt:type_name -> google.protobuf.Timestamp
+	11, // 16: invoice.v1.CreatePaymentRequestRequest.coins:type_name -> invoice.v1.PaymentRequestCoin
+	7,  // 17: invoice.v1.CreatePaymentRequestRequest.fiat:type_name -> invoice.v1.FiatAmount
+	31, // 18: invoice.v1.CreatePaymentRequestRequest.metadata:type_name -> invoice.v1.CreatePaymentRequestRequest.MetadataEntry
+	12, // 19: invoice.v1.CreatePaymentRequestResponse.paymentRequest:type_name -> invoice.v1.PaymentRequest
+	12, // 20: invoice.v1.GetPaymentRequestResponse.paymentRequest:type_name -> invoice.v1.PaymentRequest
+	6,  // 21: invoice.v1.GetInvoiceResponse.invoice:type_name -> invoice.v1.Invoice
+	4,  // 22: invoice.v1.GetInvoiceQrCodeRequest.format:type_name -> invoice.v1.QrCodeFormat
+	5,  // 23: invoice.v1.GetInvoiceQrCodeRequest.errorCorrection:type_name -> invoice.v1.QrCodeErrorCorrection
+	32, // 24: invoice.v1.ListInvoicesRequest.coin:type_name -> crypto.v1.CoinType
+	0,  // 25: invoice.v1.ListInvoicesRequest.status:type_name -> invoice.v1.InvoiceStatusType
+	33, // 26: invoice.v1.ListInvoicesRequest.createdFrom:type_name -> google.protobuf.Timestamp
+	33, // 27: invoice.v1.ListInvoicesRequest.createdTo:type_name -> google.protobuf.Timestamp
+	33, // 28: invoice.v1.ListInvoicesRequest.expiresFrom:type_name -> google.protobuf.Timestamp
+	33, // 29: invoice.v1.ListInvoicesRequest.expiresTo:type_name -> google.protobuf.Timestamp
+	6,  // 30: invoice.v1.ListInvoicesResponse.invoices:type_name -> invoice.v1.Invoice
+	6,  // 31: invoice.v1.CancelInvoiceResponse.invoice:type_name -> invoice.v1.Invoice
+	32, // 32: invoice.v1.InvoiceStatusStreamRequest.coins:type_name -> crypto.v1.CoinType
+	0,  // 33: invoice.v1.InvoiceStatusStreamRequest.statuses:type_name -> invoice.v1.InvoiceStatusType
+	6,  // 34: invoice.v1.InvoiceStatusStreamResponse.invoice:type_name -> invoice.v1.Invoice
+	3,  // 35: invoice.v1.InvoiceStatusStreamResponse.type:type_name -> invoice.v1.InvoiceEventType
+	34, // 36: invoice.v1.ListTokensResponse.tokens:type_name -> crypto.v1.Token
+	9,  // 37: invoice.v1.InvoiceService.CreateInvoice:input_type -> invoice.v1.CreateInvoiceRequest
+	17, // 38: invoice.v1.InvoiceService.GetInvoice:input_type -> invoice.v1.GetInvoiceRequest
+	19, // 39: invoice.v1.InvoiceService.GetInvoiceQrCode:input_type -> invoice.v1.GetInvoiceQrCodeRequest
+	21, // 40: invoice.v1.InvoiceService.ListInvoices:input_type -> invoice.v1.ListInvoicesRequest
+	23, // 41: invoice.v1.InvoiceService.CancelInvoice:input_type -> invoice.v1.CancelInvoiceRequest
+	13, // 42: invoice.v1.InvoiceService.CreatePaymentRequest:input_type -> invoice.v1.CreatePaymentRequestRequest
+	15, // 43: invoice.v1.InvoiceService.GetPaymentRequest:input_type -> invoice.v1.GetPaymentRequestRequest
+	25, // 44: invoice.v1.InvoiceService.InvoiceStatusStream:input_type -> invoice.v1.InvoiceStatusStreamRequest
+	27, // 45: invoice.v1.InvoiceService.ListTokens:input_type -> invoice.v1.ListTokensRequest
+	10, // 46: invoice.v1.InvoiceService.CreateInvoice:output_type -> invoice.v1.CreateInvoiceResponse
+	18, // 47: invoice.v1.InvoiceService.GetInvoice:output_type -> invoice.v1.GetInvoiceResponse
+	20, // 48: invoice.v1.InvoiceService.GetInvoiceQrCode:output_type -> invoice.v1.GetInvoiceQrCodeResponse
+	22, // 49: invoice.v1.InvoiceService.ListInvoices:output_type -> invoice.v1.ListInvoicesResponse
+	24, // 50: invoice.v1.InvoiceService.CancelInvoice:output_type -> invoice.v1.CancelInvoiceResponse
+	14, // 51: invoice.v1.InvoiceService.CreatePaymentRequest:output_type -> invoice.v1.CreatePaymentRequestResponse
+	16, // 52: invoice.v1.InvoiceService.GetPaymentRequest:output_type -> invoice.v1.GetPaymentRequestResponse
+	26, // 53: invoice.v1.InvoiceService.InvoiceStatusStream:output_type -> invoice.v1.InvoiceStatusStreamResponse
+	28, // 54: invoice.v1.InvoiceService.ListTokens:output_type -> invoice.v1.ListTokensResponse
+	46, // [46:55] is the sub-list for method output_type
+	37, // [37:46] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_invoice_proto_init() }
@@ -574,7 +2656,7 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateInvoiceRequest); i {
+			switch v := v.(*FiatAmount); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -586,7 +2668,7 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*CreateInvoiceResponse); i {
+			switch v := v.(*PaymentTolerance); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -598,7 +2680,7 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*InvoiceStatusStreamRequest); i {
+			switch v := v.(*CreateInvoiceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -610,6 +2692,198 @@ func file_invoice_proto_init() {
 			}
 		}
 		file_invoice_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateInvoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentRequestCoin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*CreatePaymentRequestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*CreatePaymentRequestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPaymentRequestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPaymentRequestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceQrCodeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetInvoiceQrCodeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInvoicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ListInvoicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelInvoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*CancelInvoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*InvoiceStatusStreamRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[20].Exporter = func(v any, i int) any {
 			switch v := v.(*InvoiceStatusStreamResponse); i {
 			case 0:
 				return &v.state
@@ -621,14 +2895,50 @@ func file_invoice_proto_init() {
 				return nil
 			}
 		}
+		file_invoice_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_invoice_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTokensResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_invoice_proto_msgTypes[0].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[2].OneofWrappers = []any{
+		(*PaymentTolerance_Percent)(nil),
+		(*PaymentTolerance_Amount)(nil),
 	}
+	file_invoice_proto_msgTypes[3].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[5].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[6].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[7].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[13].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[15].OneofWrappers = []any{}
+	file_invoice_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_invoice_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      6,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
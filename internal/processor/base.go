@@ -2,7 +2,11 @@ package processor
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"math/big"
 	"sync/atomic"
 	"time"
 
@@ -11,6 +15,7 @@ import (
 	"github.com/chekist32/goipay/internal/listener"
 	"github.com/chekist32/goipay/internal/util"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
@@ -18,8 +23,13 @@ import (
 
 var (
 	unsupportedCoin error = errors.New("coin is unsupported by crypto processor")
+
+	InvoiceNotCancellableErr  error = errors.New("invoice is not pending and can't be cancelled")
+	IdempotencyKeyConflictErr error = errors.New("idempotency key has already been used with different parameters")
 )
 
+const unique_idempotency_key_constraint string = "unique_user_id_idempotency_key"
+
 type pendingInvoice struct {
 	invoice           *atomic.Pointer[db.Invoice]
 	cancelTimeoutFunc context.CancelFunc
@@ -28,6 +38,8 @@ type pendingInvoice struct {
 type verifyTxHandlerData[T listener.SharedTx] struct {
 	invoice db.Invoice
 	tx      T
+	// token is set if the invoice is paid in one of the tokens of the chain.
+	token *db.Token
 }
 
 type generateNextAddressHandlerData struct {
@@ -39,7 +51,16 @@ type cryptoProcessor interface {
 	load(ctx context.Context) error
 	handleInvoicePbReq(ctx context.Context, req *dto.NewInvoiceRequest) (*db.Invoice, error)
 	handleInvoice(ctx context.Context, invoice db.Invoice)
+	cancelInvoice(ctx context.Context, invoice *db.Invoice) (*db.Invoice, error)
+	// dropCancelledInvoice stops handling the invoice which was cancelled along with the settlement of its payment request.
+	dropCancelledInvoice(ctx context.Context, invoice *db.Invoice)
+	handleExpiredInvoice(ctx context.Context, invoice db.Invoice)
+	paymentUri(invoice *db.Invoice) (string, error)
 	supportsCoin(coin db.CoinType) bool
+	decimals(coin db.CoinType) (int, bool)
+	// rateCoin returns the coin whose exchange rate is used to convert the fiat amount of the invoices in the coin.
+	rateCoin(coin db.CoinType) db.CoinType
+	supportedTokens() []db.Token
 }
 
 type baseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock] struct {
@@ -51,14 +72,26 @@ type baseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock] struct {
 	daemonEx listener.DaemonRpcClientExecutor[T, B]
 	network  listener.NetworkType
 
-	coin            db.CoinType
-	supportedTokens map[db.CoinType]bool
+	coin db.CoinType
+	// tokens are the enabled tokens of the chain from the token registry.
+	tokens map[db.CoinType]db.Token
 
 	invoiceCn       chan<- db.Invoice
 	pendingInvoices *util.SyncMapTypeSafe[string, pendingInvoice]
+	// expiredInvoices are still watched for late payments.
+	expiredInvoices *util.SyncMapTypeSafe[string, pendingInvoice]
+
+	latePaymentWindow    time.Duration
+	addressReuseCooldown time.Duration
 
-	verifyTxHandler            func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (float64, error)
+	verifyTxHandler            func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (*big.Int, error)
 	generateNextAddressHandler func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error)
+	paymentPolicy              paymentPolicy
+}
+
+// awaitsPayment reports whether txs paying the invoice are still accepted.
+func awaitsPayment(status db.InvoiceStatusType) bool {
+	return status == db.InvoiceStatusTypePENDING || status == db.InvoiceStatusTypePARTIALLYPAID
 }
 
 func (b *baseCryptoProcessor[T, B]) verifyTxOnMempool(ctx context.Context, cryptoTx T) {
@@ -68,102 +101,346 @@ func (b *baseCryptoProcessor[T, B]) verifyTxOnMempool(ctx context.Context, crypt
 
 	b.pendingInvoices.Range(func(key string, value pendingInvoice) bool {
 		go func() {
-			q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
-			if err != nil {
-				b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+			if !awaitsPayment(value.invoice.Load().Status) {
 				return
 			}
-			defer tx.Rollback(ctx)
 
-			invoice := value.invoice.Load()
-
-			amount, err := b.verifyTxHandler(ctx, q, &verifyTxHandlerData[T]{invoice: *invoice, tx: cryptoTx})
-			if err != nil {
-				b.log.Err(err).Str("coin", string(b.coin)).Msg("An error occurred while verifying the tx output.")
+			added := b.handleTx(ctx, cryptoTx, value, func(q *db.Queries, amount *big.Int) bool {
+				return b.addPayment(ctx, q, cryptoTx, amount, value)
+			})
+			if !added {
 				return
 			}
-
-			if invoice.RequiredAmount <= amount && invoice.Status == db.InvoiceStatusTypePENDING {
-				b.confirmPENDING_MEMPOOL(ctx, q, cryptoTx, amount, value)
-				b.confirmCONFIRMED(ctx, q, value)
+			// An unconfirmed payment is reverted if its tx gets replaced or evicted.
+			if cryptoTx.GetConfirmations() == 0 {
+				b.daemonEx.WatchTx(cryptoTx)
+			}
+			if value.invoice.Load().Status == db.InvoiceStatusTypePENDINGMEMPOOL {
+				b.verifyConfirmations(ctx, value)
 			}
-
-			tx.Commit(ctx)
 		}()
 
 		return true
 	})
+
+	b.expiredInvoices.Range(func(key string, value pendingInvoice) bool {
+		go b.handleTx(ctx, cryptoTx, value, func(q *db.Queries, amount *big.Int) bool {
+			return b.addLatePayment(ctx, q, cryptoTx, amount, value)
+		})
+
+		return true
+	})
 }
 
-func (b *baseCryptoProcessor[T, B]) confirmPENDING_MEMPOOL(ctx context.Context, q *db.Queries, cryptoTx T, am float64, value pendingInvoice) {
-	var txId pgtype.Text
-	if err := txId.Scan(cryptoTx.GetTxId()); err != nil {
-		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "txId").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
-		return
+// handleTx passes the amount the tx pays to the invoice address to the handler. The changes are committed if the handler returns true.
+func (b *baseCryptoProcessor[T, B]) handleTx(ctx context.Context, cryptoTx T, value pendingInvoice, handler func(q *db.Queries, amount *big.Int) bool) bool {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return false
 	}
+	defer tx.Rollback(ctx)
 
-	var amount pgtype.Float8
-	if err := amount.Scan(am); err != nil {
-		b.log.Err(err).Str("coin", string(b.coin)).Str("fieldName", "amount").Msg(util.DefaultFailedScanningToPostgresqlDataTypeMsg)
-		return
+	invoice := *value.invoice.Load()
+	amount, err := b.verifyTxHandler(ctx, q, &verifyTxHandlerData[T]{invoice: invoice, tx: cryptoTx, token: b.token(invoice.Coin)})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg("An error occurred while verifying the tx output.")
+		return false
 	}
+	if amount.Sign() <= 0 {
+		return false
+	}
+
+	if !handler(q, amount) {
+		return false
+	}
+
+	tx.Commit(ctx)
+	b.broadcastUpdatedInvoice(ctx, value.invoice.Load())
 
-	invoice, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: value.invoice.Load().ID, ActualAmount: amount, TxID: txId})
+	return true
+}
+
+// recordPayment stores the tx as a payment of the locked invoice and returns the sum of the invoice payments.
+// It returns false if the tx has already been recorded.
+func (b *baseCryptoProcessor[T, B]) recordPayment(ctx context.Context, q *db.Queries, invoice *db.Invoice, cryptoTx T, amount *big.Int) (pgtype.Numeric, *big.Int, bool) {
+	if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{
+		InvoiceID:     invoice.ID,
+		TxID:          cryptoTx.GetTxId(),
+		Amount:        util.BigIntToPgNumeric(amount),
+		Confirmations: int64(cryptoTx.GetConfirmations()),
+	}); err != nil {
+		// The same tx is seen twice: first in the mempool and then in a block.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.Numeric{}, nil, false
+		}
+
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateInvoicePayment").Msg(util.DefaultFailedSqlQueryMsg)
+		return pgtype.Numeric{}, nil, false
+	}
+
+	total, err := q.SumInvoicePaymentsByInvoiceId(ctx, invoice.ID)
 	if err != nil {
-		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ConfirmInvoiceStatusMempoolById").Msg(util.DefaultFailedSqlQueryMsg)
-		return
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "SumInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return pgtype.Numeric{}, nil, false
+	}
+
+	paidAmount, err := util.PgNumericToBigInt(total)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while reading the invoice paid amount.")
+		return pgtype.Numeric{}, nil, false
+	}
+
+	return total, paidAmount, true
+}
+
+// addPayment records the tx as a payment of the invoice. The invoice becomes PARTIALLY_PAID
+// until the sum of its payments is accepted by the payment policy and PENDING_MEMPOOL afterwards.
+func (b *baseCryptoProcessor[T, B]) addPayment(ctx context.Context, q *db.Queries, cryptoTx T, amount *big.Int, value pendingInvoice) bool {
+	// Locking the invoice serializes concurrent payments to the same address.
+	invoice, err := q.FindInvoiceByIdForUpdate(ctx, value.invoice.Load().ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoiceByIdForUpdate").Msg(util.DefaultFailedSqlQueryMsg)
+		return false
+	}
+	if !awaitsPayment(invoice.Status) {
+		return false
+	}
+
+	total, paidAmount, ok := b.recordPayment(ctx, q, &invoice, cryptoTx, amount)
+	if !ok {
+		return false
+	}
+
+	evaluation, err := b.paymentPolicy(&invoice, paidAmount)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while evaluating the invoice payment.")
+		return false
+	}
+
+	if !evaluation.accepted {
+		invoice, err = q.UpdateInvoiceStatusPartiallyPaidById(ctx, db.UpdateInvoiceStatusPartiallyPaidByIdParams{ID: invoice.ID, ActualAmount: total})
+		if err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateInvoiceStatusPartiallyPaidById").Msg(util.DefaultFailedSqlQueryMsg)
+			return false
+		}
+	} else {
+		invoice, err = q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{
+			ID:                   invoice.ID,
+			ActualAmount:         total,
+			TxID:                 pgtype.Text{String: cryptoTx.GetTxId(), Valid: true},
+			PaymentOutcome:       evaluation.outcome,
+			PaymentOutcomeAmount: evaluation.outcomeAmount(),
+		})
+		if err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ConfirmInvoiceStatusMempoolById").Msg(util.DefaultFailedSqlQueryMsg)
+			return false
+		}
+	}
+	if err := createInvoiceEvent(ctx, b.log, q, &invoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+		return false
 	}
 
 	value.invoice.Store(&invoice)
-	b.broadcastUpdatedInvoice(ctx, &invoice)
+
+	return true
 }
 
-func (b *baseCryptoProcessor[T, B]) confirmCONFIRMED(ctx context.Context, q *db.Queries, value pendingInvoice) {
-	invoice := value.invoice.Load()
-	if !invoice.TxID.Valid {
-		return
+// addLatePayment records the tx as a payment of the expired invoice. The invoice becomes PAID_AFTER_EXPIRY.
+func (b *baseCryptoProcessor[T, B]) addLatePayment(ctx context.Context, q *db.Queries, cryptoTx T, amount *big.Int, value pendingInvoice) bool {
+	invoice, err := q.FindInvoiceByIdForUpdate(ctx, value.invoice.Load().ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoiceByIdForUpdate").Msg(util.DefaultFailedSqlQueryMsg)
+		return false
+	}
+	if invoice.Status != db.InvoiceStatusTypeEXPIRED && invoice.Status != db.InvoiceStatusTypePAIDAFTEREXPIRY {
+		return false
 	}
 
-	txs, err := b.daemon.GetTransactions([]string{invoice.TxID.String})
+	total, paidAmount, ok := b.recordPayment(ctx, q, &invoice, cryptoTx, amount)
+	if !ok {
+		return false
+	}
+
+	evaluation, err := b.paymentPolicy(&invoice, paidAmount)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while evaluating the invoice payment.")
+		return false
+	}
+
+	invoice, err = q.UpdateInvoiceStatusPaidAfterExpiryById(ctx, db.UpdateInvoiceStatusPaidAfterExpiryByIdParams{
+		ID:                   invoice.ID,
+		ActualAmount:         total,
+		PaymentOutcome:       evaluation.outcome,
+		PaymentOutcomeAmount: evaluation.outcomeAmount(),
+	})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateInvoiceStatusPaidAfterExpiryById").Msg(util.DefaultFailedSqlQueryMsg)
+		return false
+	}
+
+	if err := createInvoiceEvent(ctx, b.log, q, &invoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+		return false
+	}
+
+	b.log.Info().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msgf("Tx %v paid the invoice after expiry", cryptoTx.GetTxId())
+
+	value.invoice.Store(&invoice)
+
+	return true
+}
+
+// updatePaymentConfirmations refreshes the confirmations of the invoice payments.
+// It returns false if any of the txs was rejected by the blockchain.
+func (b *baseCryptoProcessor[T, B]) updatePaymentConfirmations(ctx context.Context, q *db.Queries, payments []db.InvoicePayment) (bool, error) {
+	txIds := make([]string, len(payments))
+	for i := 0; i < len(payments); i++ {
+		txIds[i] = payments[i].TxID
+	}
+
+	txs, err := b.daemon.GetTransactions(txIds)
 	if err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("method", "get_transactions").Msg(util.DefaultFailedFetchingDaemonMsg)
-		return
+		return false, err
+	}
+	txsById := make(map[string]T, len(txs))
+	for i := 0; i < len(txs); i++ {
+		txsById[txs[i].GetTxId()] = txs[i]
 	}
-	if len(txs) < 1 || txs[0].IsDoubleSpendSeen() {
-		b.log.Info().Str("coin", string(b.coin)).Msgf("Tx %v was rejected by blockchain", invoice.TxID.String)
+
+	for i := 0; i < len(payments); i++ {
+		cryptoTx, ok := txsById[payments[i].TxID]
+		// The unconfirmed txs missing from the mempool are reverted by handleDroppedTx.
+		if !ok && payments[i].Confirmations == 0 {
+			continue
+		}
+		if !ok || cryptoTx.IsDoubleSpendSeen() {
+			b.log.Info().Str("coin", string(b.coin)).Msgf("Tx %v was rejected by blockchain", payments[i].TxID)
+			return false, nil
+		}
+
+		// The height of the block is stored, so the payment is reset if the block gets orphaned.
+		var blockHeight pgtype.Int8
+		if height, mined := cryptoTx.GetBlockHeight(); mined {
+			blockHeight = pgtype.Int8{Int64: int64(height), Valid: true}
+		}
+
+		confirmations := cryptoTx.GetConfirmations()
+		if int64(confirmations) == payments[i].Confirmations && blockHeight == payments[i].BlockHeight {
+			continue
+		}
+
+		payments[i], err = q.UpdateInvoicePaymentConfirmationsById(ctx, db.UpdateInvoicePaymentConfirmationsByIdParams{ID: payments[i].ID, Confirmations: int64(confirmations), BlockHeight: blockHeight})
+		if err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateInvoicePaymentConfirmationsById").Msg(util.DefaultFailedSqlQueryMsg)
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// updateInvoiceConfirmations stores the lowest confirmation count of the payments with the invoice.
+// Every change is recorded as a CONFIRMATION_PROGRESS event. It returns nil if the count hasn't changed.
+func (b *baseCryptoProcessor[T, B]) updateInvoiceConfirmations(ctx context.Context, q *db.Queries, invoice *db.Invoice, payments []db.InvoicePayment) *db.Invoice {
+	confirmations := payments[0].Confirmations
+	for i := 1; i < len(payments); i++ {
+		confirmations = min(confirmations, payments[i].Confirmations)
+	}
+	if confirmations == int64(invoice.Confirmations) {
+		return nil
+	}
+
+	updatedInvoice, err := q.UpdateInvoiceConfirmationsById(ctx, db.UpdateInvoiceConfirmationsByIdParams{ID: invoice.ID, Confirmations: int32(confirmations)})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateInvoiceConfirmationsById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil
+	}
+	if err := createInvoiceEvent(ctx, b.log, q, &updatedInvoice, db.InvoiceEventTypeCONFIRMATIONPROGRESS); err != nil {
+		return nil
+	}
+
+	return &updatedInvoice
+}
+
+// confirmCONFIRMED returns the updated invoice if either its confirmations or its status changed.
+func (b *baseCryptoProcessor[T, B]) confirmCONFIRMED(ctx context.Context, q *db.Queries, value pendingInvoice) *db.Invoice {
+	invoice := value.invoice.Load()
+	if invoice.Status != db.InvoiceStatusTypePENDINGMEMPOOL && invoice.Status != db.InvoiceStatusTypePARTIALLYPAID {
+		return nil
+	}
+
+	payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil
+	}
+	if len(payments) < 1 {
+		return nil
+	}
+
+	accepted, err := b.updatePaymentConfirmations(ctx, q, payments)
+	if err != nil {
+		return nil
+	}
+	if !accepted {
 		b.expireInvoice(ctx, invoice)
-		return
+		return nil
 	}
 
-	if uint64(invoice.ConfirmationsRequired) > txs[0].GetConfirmations() {
-		return
+	updatedInvoice := b.updateInvoiceConfirmations(ctx, q, invoice, payments)
+	if updatedInvoice != nil {
+		value.invoice.Store(updatedInvoice)
 	}
+
+	if invoice.Status != db.InvoiceStatusTypePENDINGMEMPOOL {
+		return updatedInvoice
+	}
+	// Every tx contributing to the paid amount has to be confirmed.
+	for i := 0; i < len(payments); i++ {
+		if int64(invoice.ConfirmationsRequired) > payments[i].Confirmations {
+			return updatedInvoice
+		}
+	}
+
 	if _, loaded := b.pendingInvoices.LoadAndDelete(invoice.CryptoAddress); !loaded {
-		return
+		return updatedInvoice
 	}
 	value.cancelTimeoutFunc()
 
 	confirmedInvoice, err := q.ConfirmInvoiceById(ctx, invoice.ID)
 	if err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ConfirmInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
-		return
+		return nil
+	}
+	if err := createInvoiceEvent(ctx, b.log, q, &confirmedInvoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+		return nil
 	}
 
 	go b.releaseAddressHelper(ctx, invoice)
-	b.broadcastUpdatedInvoice(ctx, &confirmedInvoice)
+
+	return &confirmedInvoice
+}
+
+func (b *baseCryptoProcessor[T, B]) verifyConfirmations(ctx context.Context, value pendingInvoice) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	updatedInvoice := b.confirmCONFIRMED(ctx, q, value)
+	tx.Commit(ctx)
+
+	if updatedInvoice != nil {
+		b.broadcastUpdatedInvoice(ctx, updatedInvoice)
+	}
 }
 
 func (b *baseCryptoProcessor[T, B]) verifyTxOnNewBlock(ctx context.Context) {
 	b.pendingInvoices.Range(func(key string, value pendingInvoice) bool {
-		go func() {
-			q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
-			if err != nil {
-				b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
-				return
-			}
-			b.confirmCONFIRMED(ctx, q, value)
-			tx.Commit(ctx)
-		}()
+		go b.verifyConfirmations(ctx, value)
 		return true
 	})
 }
@@ -186,10 +463,32 @@ func (b *baseCryptoProcessor[T, B]) persistCryptoCache(ctx context.Context) {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateCryptoCacheByCoin").Msg(util.DefaultFailedSqlQueryMsg)
 		return
 	}
+	if err := b.persistBlockHashes(ctx, q); err != nil {
+		return
+	}
 
 	tx.Commit(ctx)
 }
 
+// blockTxs returns the txs of the block which may pay the watched invoices. The clients able to filter them
+// don't fetch the rest of the block.
+func (b *baseCryptoProcessor[T, B]) blockTxs(block B) ([]T, error) {
+	filterer, ok := b.daemon.(listener.BlockTxFilterer[T, B])
+	if !ok {
+		return b.daemon.GetTransactions(block.GetTxHashes())
+	}
+
+	addresses := make([]string, 0)
+	collectAddress := func(key string, value pendingInvoice) bool {
+		addresses = append(addresses, key)
+		return true
+	}
+	b.pendingInvoices.Range(collectAddress)
+	b.expiredInvoices.Range(collectAddress)
+
+	return filterer.GetBlockTransactionsTo(block, addresses, tokenContractsETHCompatible(b.tokens))
+}
+
 func (b *baseCryptoProcessor[T, B]) load(ctx context.Context) error {
 	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
 	if err != nil {
@@ -215,6 +514,12 @@ func (b *baseCryptoProcessor[T, B]) load(ctx context.Context) error {
 		height = cache.LastSyncedBlockHeight.Int64
 	}
 
+	blockHashes, err := q.FindBlockHashesByCoin(ctx, b.coin)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindBlockHashesByCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
 	tx.Commit(ctx)
 
 	go func() {
@@ -224,7 +529,7 @@ func (b *baseCryptoProcessor[T, B]) load(ctx context.Context) error {
 			select {
 			case block := <-blockCn:
 				go func() {
-					txs, err := b.daemon.GetTransactions(block.GetTxHashes())
+					txs, err := b.blockTxs(block)
 					if err != nil {
 						b.log.Err(err).Str("coin", string(b.coin)).Str("method", "GetTransactions").Msg(util.DefaultFailedFetchingDaemonMsg)
 						return
@@ -257,7 +562,33 @@ func (b *baseCryptoProcessor[T, B]) load(ctx context.Context) error {
 		}
 	}()
 
-	b.daemonEx.Start(uint64(height))
+	go func() {
+		reorgCn := b.daemonEx.NewReorgChan()
+
+		for {
+			select {
+			case reorg := <-reorgCn:
+				go b.handleReorg(ctx, reorg)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		droppedTxCn := b.daemonEx.NewDroppedTxChan()
+
+		for {
+			select {
+			case droppedTx := <-droppedTxCn:
+				go b.handleDroppedTx(ctx, droppedTx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	b.daemonEx.Start(uint64(height), dbBlockHashesToListenerBlockHashes(blockHashes))
 
 	go func() {
 		b.persistCryptoCache(ctx)
@@ -312,34 +643,167 @@ func (b *baseCryptoProcessor[T, B]) createInvoice(ctx context.Context, req *dto.
 		}
 	}
 
+	var idempotencyKey, idempotencyFingerprint pgtype.Text
+	if req.IdempotencyKey != "" {
+		fingerprint, err := newInvoiceRequestFingerprint(req)
+		if err != nil {
+			return nil, err
+		}
+
+		idempotencyKey = pgtype.Text{String: req.IdempotencyKey, Valid: true}
+		idempotencyFingerprint = pgtype.Text{String: fingerprint, Valid: true}
+	}
+
+	metadata := req.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadataJson, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	tolerancePercent, toleranceAmount, err := b.invoiceTolerance(ctx, q, userId, req.Tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	var paymentRequestId pgtype.UUID
+	if req.PaymentRequestId != "" {
+		if err := paymentRequestId.Scan(req.PaymentRequestId); err != nil {
+			return nil, err
+		}
+	}
+
+	requiredAmount := req.Amount
+	var fiatAmount, exchangeRate pgtype.Float8
+	var fiatCurrency, rateSource pgtype.Text
+	if req.Conversion != nil {
+		requiredAmount = req.Conversion.CoinAmount
+		fiatAmount = pgtype.Float8{Float64: req.FiatAmount, Valid: true}
+		fiatCurrency = pgtype.Text{String: req.FiatCurrency, Valid: true}
+		exchangeRate = pgtype.Float8{Float64: req.Conversion.Rate.Value, Valid: true}
+		rateSource = pgtype.Text{String: req.Conversion.Rate.Source, Valid: true}
+	}
+
 	invoice, err := q.CreateInvoice(
 		ctx,
 		db.CreateInvoiceParams{
-			CryptoAddress:         addr.Address,
-			Coin:                  coin,
-			RequiredAmount:        req.Amount,
-			ConfirmationsRequired: int16(req.Confirmations),
-			ExpiresAt:             expiresAt,
-			UserID:                userId,
+			CryptoAddress:          addr.Address,
+			Coin:                   coin,
+			RequiredAmount:         util.BigIntToPgNumeric(requiredAmount),
+			ConfirmationsRequired:  int16(req.Confirmations),
+			ExpiresAt:              expiresAt,
+			UserID:                 userId,
+			IdempotencyKey:         idempotencyKey,
+			IdempotencyFingerprint: idempotencyFingerprint,
+			ExternalID:             pgtype.Text{String: req.ExternalId, Valid: req.ExternalId != ""},
+			Description:            pgtype.Text{String: req.Description, Valid: req.Description != ""},
+			Metadata:               metadataJson,
+			FiatAmount:             fiatAmount,
+			FiatCurrency:           fiatCurrency,
+			ExchangeRate:           exchangeRate,
+			RateSource:             rateSource,
+			TolerancePercent:       tolerancePercent,
+			ToleranceAmount:        toleranceAmount,
+			PaymentRequestID:       paymentRequestId,
 		},
 	)
 	if err != nil {
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateInvoice").Msg(util.DefaultFailedSqlQueryMsg)
 		return nil, err
 	}
+	if err := createInvoiceEvent(ctx, b.log, q, &invoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+		return nil, err
+	}
 
 	tx.Commit(ctx)
 
 	return &invoice, nil
 }
 
+// invoiceTolerance returns the tolerance stored with the invoice. Without an explicit one the user's default is used.
+func (b *baseCryptoProcessor[T, B]) invoiceTolerance(ctx context.Context, q *db.Queries, userId pgtype.UUID, tolerance *dto.PaymentTolerance) (pgtype.Float8, pgtype.Numeric, error) {
+	if tolerance != nil {
+		if tolerance.Amount != nil {
+			return pgtype.Float8{}, util.BigIntToPgNumeric(tolerance.Amount), nil
+		}
+
+		return pgtype.Float8{Float64: tolerance.Percent, Valid: true}, pgtype.Numeric{}, nil
+	}
+
+	tolerancePercent, err := q.FindTolerancePercentByUserId(ctx, userId)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindTolerancePercentByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+		return pgtype.Float8{}, pgtype.Numeric{}, err
+	}
+
+	return tolerancePercent, pgtype.Numeric{}, nil
+}
+
+// newInvoiceRequestFingerprint returns a hash of the invoice creation parameters excluding the idempotency key.
+func newInvoiceRequestFingerprint(req *dto.NewInvoiceRequest) (string, error) {
+	r := *req
+	r.IdempotencyKey = ""
+	// The locked rate may differ between retries, so only the fiat amount is taken into account.
+	r.Conversion = nil
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// findIdempotentInvoice returns the invoice previously created with the same idempotency key.
+// If the key was used with different parameters IdempotencyKeyConflictErr is returned.
+func (b *baseCryptoProcessor[T, B]) findIdempotentInvoice(ctx context.Context, req *dto.NewInvoiceRequest) (*db.Invoice, error) {
+	var userId pgtype.UUID
+	if err := userId.Scan(req.UserId); err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := newInvoiceRequestFingerprint(req)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice, err := db.New(b.dbConnPool).FindInvoiceByUserIdAndIdempotencyKey(ctx, db.FindInvoiceByUserIdAndIdempotencyKeyParams{UserID: userId, IdempotencyKey: pgtype.Text{String: req.IdempotencyKey, Valid: true}})
+	if err != nil {
+		return nil, err
+	}
+	if !invoice.IdempotencyFingerprint.Valid || invoice.IdempotencyFingerprint.String != fingerprint {
+		return nil, IdempotencyKeyConflictErr
+	}
+
+	return &invoice, nil
+}
+
 func (b *baseCryptoProcessor[T, B]) handleInvoicePbReq(ctx context.Context, req *dto.NewInvoiceRequest) (*db.Invoice, error) {
 	if !b.supportsCoin(req.Coin) {
 		return nil, unsupportedCoin
 	}
 
+	if req.IdempotencyKey != "" {
+		invoice, err := b.findIdempotentInvoice(ctx, req)
+		if err == nil {
+			return invoice, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+
 	invoice, err := b.createInvoice(ctx, req)
 	if err != nil {
+		// A concurrent request with the same idempotency key won the race.
+		var pgErr *pgconn.PgError
+		if req.IdempotencyKey != "" && errors.As(err, &pgErr) && pgErr.ConstraintName == unique_idempotency_key_constraint {
+			return b.findIdempotentInvoice(ctx, req)
+		}
+
 		return nil, err
 	}
 
@@ -357,8 +821,13 @@ func (b *baseCryptoProcessor[T, B]) releaseAddressHelper(ctx context.Context, in
 	}
 	defer tx.Rollback(ctx)
 
-	if _, err := q.UpdateIsOccupiedByCryptoAddress(ctx, db.UpdateIsOccupiedByCryptoAddressParams{IsOccupied: false, Address: invoice.CryptoAddress}); err != nil {
-		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "UpdateIsOccupiedByCryptoAddress").Msg(util.DefaultFailedSqlQueryMsg)
+	var availableAt pgtype.Timestamptz
+	if b.addressReuseCooldown > 0 {
+		availableAt = pgtype.Timestamptz{Time: time.Now().UTC().Add(b.addressReuseCooldown), Valid: true}
+	}
+
+	if _, err := q.ReleaseCryptoAddress(ctx, db.ReleaseCryptoAddressParams{Address: invoice.CryptoAddress, AvailableAt: availableAt}); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ReleaseCryptoAddress").Msg(util.DefaultFailedSqlQueryMsg)
 		return
 	}
 
@@ -398,11 +867,103 @@ func (b *baseCryptoProcessor[T, B]) expireInvoice(ctx context.Context, invoice *
 		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ExpireInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
 		return
 	}
+	if err := createInvoiceEvent(ctx, b.log, q, &expiredInvoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+		return
+	}
+
+	tx.Commit(ctx)
 
 	go b.releaseAddressHelper(ctx, invoice)
 	b.broadcastUpdatedInvoice(ctx, &expiredInvoice)
 
-	tx.Commit(ctx)
+	b.watchExpiredInvoice(ctx, expiredInvoice, time.Now().UTC().Add(b.latePaymentWindow))
+}
+
+// watchExpiredInvoice keeps watching the address of the expired invoice for late payments until watchUntil.
+func (b *baseCryptoProcessor[T, B]) watchExpiredInvoice(ctx context.Context, invoice db.Invoice, watchUntil time.Time) {
+	if !watchUntil.After(time.Now().UTC()) {
+		return
+	}
+	if _, ok := b.expiredInvoices.Load(invoice.CryptoAddress); ok {
+		return
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+
+	invoicePtr := &atomic.Pointer[db.Invoice]{}
+	invoicePtr.Store(&invoice)
+	b.expiredInvoices.Store(invoice.CryptoAddress, pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
+
+	go func() {
+		defer cancel()
+
+		select {
+		case <-time.After(time.Until(watchUntil)):
+		case <-watchCtx.Done():
+		}
+		b.expiredInvoices.Delete(invoice.CryptoAddress)
+	}()
+}
+
+// handleExpiredInvoice resumes watching an invoice that expired before a restart.
+func (b *baseCryptoProcessor[T, B]) handleExpiredInvoice(ctx context.Context, invoice db.Invoice) {
+	b.watchExpiredInvoice(ctx, invoice, invoice.ExpiresAt.Time.Add(b.latePaymentWindow))
+}
+
+func (b *baseCryptoProcessor[T, B]) cancelInvoice(ctx context.Context, invoice *db.Invoice) (*db.Invoice, error) {
+	value, ok := b.pendingInvoices.Load(invoice.CryptoAddress)
+	if !ok || value.invoice.Load().ID != invoice.ID || value.invoice.Load().Status != db.InvoiceStatusTypePENDING {
+		return nil, InvoiceNotCancellableErr
+	}
+
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// The status check in the query guards against a tx hitting the mempool concurrently.
+	cancelledInvoice, err := q.CancelInvoiceById(ctx, invoice.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, InvoiceNotCancellableErr
+		}
+
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CancelInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	if err := createInvoiceEvent(ctx, b.log, q, &cancelledInvoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return nil, err
+	}
+
+	// The address is released by whoever drops the invoice from the pending ones.
+	if _, loaded := b.pendingInvoices.LoadAndDelete(invoice.CryptoAddress); loaded {
+		value.cancelTimeoutFunc()
+		go b.releaseAddressHelper(ctx, invoice)
+	}
+	b.broadcastUpdatedInvoice(ctx, &cancelledInvoice)
+
+	return &cancelledInvoice, nil
+}
+
+func (b *baseCryptoProcessor[T, B]) dropCancelledInvoice(ctx context.Context, invoice *db.Invoice) {
+	value, ok := b.pendingInvoices.Load(invoice.CryptoAddress)
+	if !ok || value.invoice.Load().ID != invoice.ID {
+		return
+	}
+	if _, loaded := b.pendingInvoices.LoadAndDelete(invoice.CryptoAddress); !loaded {
+		return
+	}
+	value.cancelTimeoutFunc()
+
+	go b.releaseAddressHelper(ctx, invoice)
+	b.broadcastUpdatedInvoice(ctx, invoice)
 }
 
 func (b *baseCryptoProcessor[T, B]) handleInvoiceHelper(confirmedInvoiceCtx context.Context, invoice *db.Invoice) {
@@ -426,20 +987,60 @@ func (b *baseCryptoProcessor[T, B]) handleInvoice(ctx context.Context, invoice d
 	b.pendingInvoices.Store(invoice.CryptoAddress, pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
 
 	go b.handleInvoiceHelper(confirmedInvoiceCtx, &invoice)
+
+	if invoice.Status == db.InvoiceStatusTypePENDINGMEMPOOL || invoice.Status == db.InvoiceStatusTypePARTIALLYPAID {
+		go b.watchInvoiceTxs(ctx, invoice)
+	}
+}
+
+func (b *baseCryptoProcessor[T, B]) paymentUri(invoice *db.Invoice) (string, error) {
+	return newPaymentUri(invoice, b.token(invoice.Coin), b.network)
+}
+
+func (b *baseCryptoProcessor[T, B]) token(coin db.CoinType) *db.Token {
+	token, ok := b.tokens[coin]
+	if !ok {
+		return nil
+	}
+
+	return &token
 }
 
 func (b *baseCryptoProcessor[T, B]) supportsCoin(coin db.CoinType) bool {
-	return b.coin == coin || b.supportedTokens[coin]
+	_, isToken := b.tokens[coin]
+	return b.coin == coin || isToken
+}
+
+func (b *baseCryptoProcessor[T, B]) decimals(coin db.CoinType) (int, bool) {
+	if token, ok := b.tokens[coin]; ok {
+		return int(token.Decimals), true
+	}
+
+	decimals, ok := coinDecimals[coin]
+	return decimals, ok && b.coin == coin
+}
+
+func (b *baseCryptoProcessor[T, B]) rateCoin(coin db.CoinType) db.CoinType {
+	return coin
+}
+
+func (b *baseCryptoProcessor[T, B]) supportedTokens() []db.Token {
+	tokens := make([]db.Token, 0, len(b.tokens))
+	for _, v := range b.tokens {
+		tokens = append(tokens, v)
+	}
+
+	return tokens
 }
 
 func newBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock](
 	log *zerolog.Logger,
 	dbConnPool *pgxpool.Pool,
 	invoiceCn chan<- db.Invoice,
+	invoiceConf *dto.InvoiceConfig,
 	daemon listener.SharedDaemonRpcClient[T, B],
-	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (float64, error),
+	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (*big.Int, error),
 	generateNextAddressHandler func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error),
-	supportedTokens []db.CoinType,
 ) (*baseCryptoProcessor[T, B], error) {
 	net, err := daemon.GetNetworkType()
 	if err != nil {
@@ -447,17 +1048,22 @@ func newBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock](
 	}
 
 	return &baseCryptoProcessor[T, B]{
-			log:                        log,
-			dbConnPool:                 dbConnPool,
-			invoiceCn:                  invoiceCn,
-			network:                    net,
-			daemon:                     daemon,
-			daemonEx:                   listener.NewBaseDaemonRpcClientExecutor(log, daemon),
-			coin:                       daemon.GetCoinType(),
-			supportedTokens:            util.SliceToSet(supportedTokens),
-			pendingInvoices:            new(util.SyncMapTypeSafe[string, pendingInvoice]),
+			log:               log,
+			dbConnPool:        dbConnPool,
+			invoiceCn:         invoiceCn,
+			network:           net,
+			daemon:            daemon,
+			daemonEx:          listener.NewBaseDaemonRpcClientExecutor(log, daemon),
+			coin:              daemon.GetCoinType(),
+			tokens:            map[db.CoinType]db.Token{},
+			pendingInvoices:   new(util.SyncMapTypeSafe[string, pendingInvoice]),
+			expiredInvoices:   new(util.SyncMapTypeSafe[string, pendingInvoice]),
+			latePaymentWindow: invoiceConf.LatePaymentWindow,
+			// A shorter cooldown would let a late payment be credited to the next invoice using the address.
+			addressReuseCooldown:       max(invoiceConf.AddressReuseCooldown, invoiceConf.LatePaymentWindow),
 			verifyTxHandler:            verifyTxHandler,
 			generateNextAddressHandler: generateNextAddressHandler,
+			paymentPolicy:              tolerancePaymentPolicy,
 		},
 		nil
 }
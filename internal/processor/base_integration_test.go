@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/big"
 	"os"
 	"sync/atomic"
 	"testing"
@@ -26,6 +27,7 @@ import (
 type TestTx struct {
 	TxId          string
 	Confirmations uint64
+	BlockHeight   uint64
 }
 
 func (t TestTx) GetTxId() string {
@@ -37,14 +39,25 @@ func (t TestTx) GetConfirmations() uint64 {
 func (t TestTx) IsDoubleSpendSeen() bool {
 	return false
 }
+func (t TestTx) GetBlockHeight() (uint64, bool) {
+	return t.BlockHeight, t.Confirmations > 0
+}
 
 type TestBlock struct {
-	Height uint64
+	Height     uint64
+	Hash       string
+	ParentHash string
 }
 
 func (b TestBlock) GetTxHashes() []string {
 	return nil
 }
+func (b TestBlock) GetHash() string {
+	return b.Hash
+}
+func (b TestBlock) GetParentHash() string {
+	return b.ParentHash
+}
 
 func getInvoiceOrFatal(ctx context.Context, q *db_test.Queries, id *pgtype.UUID) db.Invoice {
 	invoice, err := q.FindInvoiceById(ctx, *id)
@@ -53,24 +66,48 @@ func getInvoiceOrFatal(ctx context.Context, q *db_test.Queries, id *pgtype.UUID)
 	}
 
 	return db.Invoice{
-		ID:                    invoice.ID,
-		CryptoAddress:         invoice.CryptoAddress,
-		Coin:                  db.CoinType(invoice.Coin),
-		RequiredAmount:        invoice.RequiredAmount,
-		ActualAmount:          invoice.ActualAmount,
-		ConfirmationsRequired: invoice.ConfirmationsRequired,
-		CreatedAt:             invoice.CreatedAt,
-		ConfirmedAt:           invoice.ConfirmedAt,
-		Status:                db.InvoiceStatusType(invoice.Status),
-		ExpiresAt:             invoice.ExpiresAt,
-		TxID:                  invoice.TxID,
-		UserID:                invoice.UserID,
+		ID:                     invoice.ID,
+		CryptoAddress:          invoice.CryptoAddress,
+		Coin:                   db.CoinType(invoice.Coin),
+		RequiredAmount:         invoice.RequiredAmount,
+		ActualAmount:           invoice.ActualAmount,
+		ConfirmationsRequired:  invoice.ConfirmationsRequired,
+		CreatedAt:              invoice.CreatedAt,
+		ConfirmedAt:            invoice.ConfirmedAt,
+		Status:                 db.InvoiceStatusType(invoice.Status),
+		ExpiresAt:              invoice.ExpiresAt,
+		TxID:                   invoice.TxID,
+		UserID:                 invoice.UserID,
+		IdempotencyKey:         invoice.IdempotencyKey,
+		IdempotencyFingerprint: invoice.IdempotencyFingerprint,
+		ExternalID:             invoice.ExternalID,
+		Description:            invoice.Description,
+		Metadata:               invoice.Metadata,
+		FiatAmount:             invoice.FiatAmount,
+		FiatCurrency:           invoice.FiatCurrency,
+		ExchangeRate:           invoice.ExchangeRate,
+		RateSource:             invoice.RateSource,
+		TolerancePercent:       invoice.TolerancePercent,
+		ToleranceAmount:        invoice.ToleranceAmount,
+		PaymentOutcome:         db.NullPaymentOutcomeType{PaymentOutcomeType: db.PaymentOutcomeType(invoice.PaymentOutcome.PaymentOutcomeType), Valid: invoice.PaymentOutcome.Valid},
+		PaymentOutcomeAmount:   invoice.PaymentOutcomeAmount,
+		PaymentRequestID:       invoice.PaymentRequestID,
+		Confirmations:          invoice.Confirmations,
+	}
+}
+
+func newPgNumericOrFatal(amount string) pgtype.Numeric {
+	var n pgtype.Numeric
+	if err := n.Scan(amount); err != nil {
+		log.Fatal(err)
 	}
+
+	return n
 }
 
 func createNewTestBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBlock](
 	daemon listener.SharedDaemonRpcClient[T, B],
-	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (float64, error),
+	verifyTxHandler func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[T]) (*big.Int, error),
 	generateNextAddressHandler func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error),
 ) (chan db.Invoice, *baseCryptoProcessor[T, B], testcontainers.Container, func(ctx context.Context)) {
 	invoiceCn := make(chan db.Invoice)
@@ -80,10 +117,10 @@ func createNewTestBaseCryptoProcessor[T listener.SharedTx, B listener.SharedBloc
 		&zerolog.Logger{},
 		dbConn,
 		invoiceCn,
+		&dto.InvoiceConfig{},
 		daemon,
 		verifyTxHandler,
 		generateNextAddressHandler,
-		nil,
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -104,8 +141,8 @@ func TestBroadcastInvoice(t *testing.T) {
 
 		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -135,7 +172,7 @@ func TestBroadcastInvoice(t *testing.T) {
 		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(1)),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -165,8 +202,8 @@ func TestReleaseAddressHelper(t *testing.T) {
 
 		_, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -196,7 +233,7 @@ func TestReleaseAddressHelper(t *testing.T) {
 		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(1)),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -228,8 +265,8 @@ func TestExpireInvoice(t *testing.T) {
 		d.On("GetCoinType").Return(db.CoinTypeXMR)
 		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -260,7 +297,7 @@ func TestExpireInvoice(t *testing.T) {
 		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(1)),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -297,6 +334,369 @@ func TestExpireInvoice(t *testing.T) {
 	})
 }
 
+func TestCancelInvoice(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("Should Properly Cancel The Invoice And Release The Address", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		qTest := db_test.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		expectedAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         expectedAddr.Address,
+			Coin:                  expectedAddr.Coin,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(1)),
+			ConfirmationsRequired: 0,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoicePtr := &atomic.Pointer[db.Invoice]{}
+		invoicePtr.Store(&expectedPendingInvoice)
+		timeoutCtx, cancel := context.WithCancel(ctx)
+		p.pendingInvoices.Store(expectedPendingInvoice.CryptoAddress, pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
+
+		// When
+		cancelledInvoice, err := p.cancelInvoice(ctx, &expectedPendingInvoice)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, cancelledInvoice.Status)
+		assert.ErrorIs(t, timeoutCtx.Err(), context.Canceled)
+
+		_, ok := p.pendingInvoices.Load(expectedPendingInvoice.CryptoAddress)
+		assert.False(t, ok)
+
+		broadcastedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, expectedPendingInvoice.ID, broadcastedInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, broadcastedInvoice.Status)
+
+		events, err := q.FindUnpublishedInvoiceEventsAndLock(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, events[0].Status)
+
+		// wait for releaseAddressHelper
+		<-time.After(300 * time.Millisecond)
+
+		addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: expectedPendingInvoice.UserID, Coin: expectedPendingInvoice.Coin})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAddr, addr)
+
+		persistedInvoice := getInvoiceOrFatal(ctx, qTest, &expectedPendingInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, persistedInvoice.Status)
+	})
+
+	t.Run("Should Refuse To Cancel The PENDING_MEMPOOL Invoice", func(t *testing.T) {
+		// Given
+		d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+		d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+		d.On("GetCoinType").Return(db.CoinTypeXMR)
+		_, p, _, close := createNewTestBaseCryptoProcessor(
+			d,
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
+			},
+			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+				return db.CryptoAddress{}, nil
+			},
+		)
+		defer close(ctx)
+
+		q := db.New(p.dbConnPool)
+		qTest := db_test.New(p.dbConnPool)
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+			log.Fatal(err)
+		}
+		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         uuid.NewString(),
+			Coin:                  db.CoinTypeXMR,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(1)),
+			ConfirmationsRequired: 0,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoice, err = q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{
+			ID:           invoice.ID,
+			ActualAmount: util.BigIntToPgNumeric(big.NewInt(1)),
+			TxID:         pgtype.Text{String: uuid.NewString(), Valid: true},
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoicePtr := &atomic.Pointer[db.Invoice]{}
+		invoicePtr.Store(&invoice)
+		_, cancel := context.WithCancel(ctx)
+		p.pendingInvoices.Store(invoice.CryptoAddress, pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
+
+		// When
+		_, err = p.cancelInvoice(ctx, &invoice)
+
+		// Assert
+		assert.ErrorIs(t, err, InvoiceNotCancellableErr)
+
+		_, ok := p.pendingInvoices.Load(invoice.CryptoAddress)
+		assert.True(t, ok)
+
+		persistedInvoice := getInvoiceOrFatal(ctx, qTest, &invoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, persistedInvoice.Status)
+	})
+}
+
+func TestAddPayment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+	qTest := db_test.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+		log.Fatal(err)
+	}
+	invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		CryptoAddress:         uuid.NewString(),
+		Coin:                  db.CoinTypeXMR,
+		RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(10)),
+		ConfirmationsRequired: 1,
+		ExpiresAt:             expiresAt,
+		UserID:                userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoicePtr := &atomic.Pointer[db.Invoice]{}
+	invoicePtr.Store(&invoice)
+	timeoutCtx, cancel := context.WithCancel(ctx)
+	value := pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel}
+	p.pendingInvoices.Store(invoice.CryptoAddress, value)
+
+	firstTx := TestTx{TxId: uuid.NewString()}
+	secondTx := TestTx{TxId: uuid.NewString()}
+
+	t.Run("Should Mark The Invoice As PARTIALLY_PAID", func(t *testing.T) {
+		assert.True(t, p.addPayment(ctx, q, firstTx, big.NewInt(4), value))
+
+		updatedInvoice := value.invoice.Load()
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, updatedInvoice.Status)
+		assert.Equal(t, "4", util.PgNumericToString(updatedInvoice.ActualAmount))
+
+		persistedInvoice := getInvoiceOrFatal(ctx, qTest, &invoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, persistedInvoice.Status)
+	})
+
+	t.Run("Should Ignore Already Recorded Tx", func(t *testing.T) {
+		assert.False(t, p.addPayment(ctx, q, firstTx, big.NewInt(4), value))
+		assert.Equal(t, "4", util.PgNumericToString(value.invoice.Load().ActualAmount))
+	})
+
+	t.Run("Should Mark The Invoice As PENDING_MEMPOOL (sum reaches the required amount)", func(t *testing.T) {
+		assert.True(t, p.addPayment(ctx, q, secondTx, big.NewInt(6), value))
+
+		updatedInvoice := value.invoice.Load()
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, updatedInvoice.Status)
+		assert.Equal(t, "10", util.PgNumericToString(updatedInvoice.ActualAmount))
+		assert.Equal(t, secondTx.TxId, updatedInvoice.TxID.String)
+	})
+
+	t.Run("Should Wait For Every Payment To Be Confirmed", func(t *testing.T) {
+		d.On("GetTransactions", []string{firstTx.TxId, secondTx.TxId}).
+			Return([]TestTx{{TxId: secondTx.TxId, Confirmations: 1}, {TxId: firstTx.TxId, Confirmations: 0}}, error(nil)).
+			Once()
+
+		p.verifyConfirmations(ctx, value)
+
+		_, ok := p.pendingInvoices.Load(invoice.CryptoAddress)
+		assert.True(t, ok)
+		assert.NoError(t, timeoutCtx.Err())
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 2)
+		assert.Equal(t, int64(0), payments[0].Confirmations)
+		assert.Equal(t, int64(1), payments[1].Confirmations)
+	})
+
+	t.Run("Should Confirm The Invoice", func(t *testing.T) {
+		d.On("GetTransactions", []string{firstTx.TxId, secondTx.TxId}).
+			Return([]TestTx{{TxId: firstTx.TxId, Confirmations: 2}, {TxId: secondTx.TxId, Confirmations: 1}}, error(nil)).
+			Once()
+
+		p.verifyConfirmations(ctx, value)
+
+		_, ok := p.pendingInvoices.Load(invoice.CryptoAddress)
+		assert.False(t, ok)
+		assert.ErrorIs(t, timeoutCtx.Err(), context.Canceled)
+
+		broadcastedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, db.InvoiceStatusTypeCONFIRMED, broadcastedInvoice.Status)
+		assert.Equal(t, int32(1), broadcastedInvoice.Confirmations)
+	})
+
+	t.Run("Should Write An Event Per Status And Confirmations Change", func(t *testing.T) {
+		events, err := q.FindUnpublishedInvoiceEventsAndLock(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, events, 4)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, events[0].Status)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, events[1].Status)
+		assert.Equal(t, db.InvoiceEventTypeCONFIRMATIONPROGRESS, events[2].Type)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, events[2].Status)
+		assert.Equal(t, db.InvoiceEventTypeSTATUSCHANGED, events[3].Type)
+		assert.Equal(t, db.InvoiceStatusTypeCONFIRMED, events[3].Status)
+	})
+}
+
+func TestAddLatePayment(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+	p.latePaymentWindow = 1 * time.Hour
+
+	q := db.New(p.dbConnPool)
+	qTest := db_test.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+		log.Fatal(err)
+	}
+	invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		CryptoAddress:         uuid.NewString(),
+		Coin:                  db.CoinTypeXMR,
+		RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(10)),
+		ConfirmationsRequired: 0,
+		ExpiresAt:             expiresAt,
+		UserID:                userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoicePtr := &atomic.Pointer[db.Invoice]{}
+	invoicePtr.Store(&invoice)
+	_, cancel := context.WithCancel(ctx)
+	p.pendingInvoices.Store(invoice.CryptoAddress, pendingInvoice{invoice: invoicePtr, cancelTimeoutFunc: cancel})
+
+	p.expireInvoice(ctx, &invoice)
+	expiredInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+	assert.Equal(t, db.InvoiceStatusTypeEXPIRED, expiredInvoice.Status)
+
+	t.Run("Should Keep Watching The Expired Invoice", func(t *testing.T) {
+		_, ok := p.expiredInvoices.Load(invoice.CryptoAddress)
+		assert.True(t, ok)
+	})
+
+	t.Run("Should Mark The Invoice As PAID_AFTER_EXPIRY", func(t *testing.T) {
+		value, ok := p.expiredInvoices.Load(invoice.CryptoAddress)
+		if !ok {
+			log.Fatal("The expired invoice isn't watched")
+		}
+
+		assert.True(t, p.addLatePayment(ctx, q, TestTx{TxId: uuid.NewString()}, big.NewInt(4), value))
+
+		updatedInvoice := value.invoice.Load()
+		assert.Equal(t, db.InvoiceStatusTypePAIDAFTEREXPIRY, updatedInvoice.Status)
+		assert.Equal(t, "4", util.PgNumericToString(updatedInvoice.ActualAmount))
+		assert.Equal(t, db.NullPaymentOutcomeType{PaymentOutcomeType: db.PaymentOutcomeTypeUNDERPAID, Valid: true}, updatedInvoice.PaymentOutcome)
+		assert.Equal(t, "6", util.PgNumericToString(updatedInvoice.PaymentOutcomeAmount))
+
+		persistedInvoice := getInvoiceOrFatal(ctx, qTest, &invoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePAIDAFTEREXPIRY, persistedInvoice.Status)
+	})
+
+	t.Run("Should Stop Watching After The Window", func(t *testing.T) {
+		p.watchExpiredInvoice(ctx, invoice, time.Now().UTC())
+
+		otherInvoice := invoice
+		otherInvoice.CryptoAddress = uuid.NewString()
+		p.watchExpiredInvoice(ctx, otherInvoice, time.Now().UTC().Add(100*time.Millisecond))
+		_, ok := p.expiredInvoices.Load(otherInvoice.CryptoAddress)
+		assert.True(t, ok)
+
+		<-time.After(300 * time.Millisecond)
+		_, ok = p.expiredInvoices.Load(otherInvoice.CryptoAddress)
+		assert.False(t, ok)
+	})
+}
+
 func TestPersistCryptoCache(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -313,8 +713,8 @@ func TestPersistCryptoCache(t *testing.T) {
 
 		_, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -331,13 +731,18 @@ func TestPersistCryptoCache(t *testing.T) {
 		}
 		assert.False(t, cache.LastSyncedBlockHeight.Valid)
 
-		p.daemonEx.Start(expectedLastHeight)
+		expectedBlockHashes := []listener.BlockHash{{Height: expectedLastHeight - 2, Hash: uuid.NewString()}, {Height: expectedLastHeight - 1, Hash: uuid.NewString()}}
+		p.daemonEx.Start(expectedLastHeight, expectedBlockHashes)
 		p.persistCryptoCache(ctx)
 
 		cache, err = q.FindCryptoCacheByCoin(ctx, expectedCoin)
 		assert.NoError(t, err)
 		assert.True(t, cache.LastSyncedBlockHeight.Valid)
 		assert.Equal(t, int64(expectedLastHeight), cache.LastSyncedBlockHeight.Int64)
+
+		blockHashes, err := q.FindBlockHashesByCoin(ctx, expectedCoin)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBlockHashes, dbBlockHashesToListenerBlockHashes(blockHashes))
 	})
 }
 
@@ -353,8 +758,8 @@ func TestHandleInvoice(t *testing.T) {
 
 		invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -385,7 +790,7 @@ func TestHandleInvoice(t *testing.T) {
 		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(1)),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -422,8 +827,8 @@ func TestHandleInvoice(t *testing.T) {
 
 		_, p, _, close := createNewTestBaseCryptoProcessor(
 			d,
-			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-				return 0, nil
+			func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+				return big.NewInt(0), nil
 			},
 			func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 				return db.CryptoAddress{}, nil
@@ -454,7 +859,7 @@ func TestHandleInvoice(t *testing.T) {
 		expectedPendingInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
 			CryptoAddress:         expectedAddr.Address,
 			Coin:                  expectedAddr.Coin,
-			RequiredAmount:        1.0,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(1)),
 			ConfirmationsRequired: 0,
 			ExpiresAt:             expiresAt,
 			UserID:                userId,
@@ -497,8 +902,8 @@ func TestCreateInvoice(t *testing.T) {
 
 	_, p, _, close := createNewTestBaseCryptoProcessor(
 		d,
-		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-			return 0, nil
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
 		},
 		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 			return q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
@@ -520,7 +925,7 @@ func TestCreateInvoice(t *testing.T) {
 	createdInvoice, err := p.createInvoice(ctx, &dto.NewInvoiceRequest{
 		UserId:        util.PgUUIDToString(*expectedUserId),
 		Coin:          db.CoinTypeXMR,
-		Amount:        123,
+		Amount:        big.NewInt(123),
 		Timeout:       600,
 		Confirmations: 0,
 	})
@@ -528,7 +933,7 @@ func TestCreateInvoice(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, db.CoinTypeXMR, createdInvoice.Coin)
-	assert.EqualValues(t, 123, createdInvoice.RequiredAmount)
+	assert.Equal(t, "123", util.PgNumericToString(createdInvoice.RequiredAmount))
 	assert.EqualValues(t, 0, createdInvoice.ConfirmationsRequired)
 	assert.Equal(t, expectedAddress, createdInvoice.CryptoAddress)
 }
@@ -543,8 +948,8 @@ func TestHandleInvoicePbReq(t *testing.T) {
 	d.On("GetCoinType").Return(db.CoinTypeXMR)
 	invCn, p, _, close := createNewTestBaseCryptoProcessor(
 		d,
-		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-			return 0, nil
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
 		},
 		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 			return db.CryptoAddress{}, nil
@@ -559,7 +964,7 @@ func TestHandleInvoicePbReq(t *testing.T) {
 	req := &dto.NewInvoiceRequest{
 		UserId:        util.PgUUIDToString(userId),
 		Coin:          db.CoinTypeXMR,
-		Amount:        123,
+		Amount:        big.NewInt(123),
 		Timeout:       600,
 		Confirmations: 0,
 	}
@@ -582,21 +987,18 @@ func TestHandleInvoicePbReq(t *testing.T) {
 	assert.Equal(t, expectedInvoice, invoiceFromCn)
 }
 
-func TestLoad(t *testing.T) {
+func TestHandleInvoicePbReqIdempotency(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
 
 	// Given
-	expectedLastHeight := uint64(1124)
 	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
 	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
 	d.On("GetCoinType").Return(db.CoinTypeXMR)
-	d.On("GetLastBlockHeight").Return(expectedLastHeight, error(nil))
-	d.On("GetTransactionPool").Return([]string{}, error(nil)).Maybe()
 	_, p, _, close := createNewTestBaseCryptoProcessor(
 		d,
-		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (float64, error) {
-			return 0, nil
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
 		},
 		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
 			return db.CryptoAddress{}, nil
@@ -605,19 +1007,587 @@ func TestLoad(t *testing.T) {
 	defer close(ctx)
 
 	q := db.New(p.dbConnPool)
+	userId, _, _ := createUserWithXmrData(ctx, q)
 
-	// When
-	err := p.load(ctx)
-
-	// Assert
-	assert.NoError(t, err)
+	req := &dto.NewInvoiceRequest{
+		UserId:         util.PgUUIDToString(userId),
+		Coin:           db.CoinTypeXMR,
+		Amount:         big.NewInt(123),
+		Timeout:        600,
+		Confirmations:  0,
+		IdempotencyKey: uuid.NewString(),
+	}
 
-	<-time.After(300 * time.Millisecond)
-	cache, err := q.FindCryptoCacheByCoin(ctx, db.CoinTypeXMR)
+	invoice, err := p.handleInvoicePbReq(ctx, req)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	assert.True(t, cache.LastSyncedBlockHeight.Valid)
-	assert.Equal(t, int64(expectedLastHeight), cache.LastSyncedBlockHeight.Int64)
+	t.Run("Should Return Original Invoice (same parameters)", func(t *testing.T) {
+		repeatedInvoice, err := p.handleInvoicePbReq(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, invoice.ID, repeatedInvoice.ID)
+		assert.Equal(t, invoice.CryptoAddress, repeatedInvoice.CryptoAddress)
+	})
+
+	t.Run("Should Return IdempotencyKeyConflictErr (different parameters)", func(t *testing.T) {
+		conflictingReq := *req
+		conflictingReq.Amount = big.NewInt(321)
+
+		_, err := p.handleInvoicePbReq(ctx, &conflictingReq)
+		assert.ErrorIs(t, err, IdempotencyKeyConflictErr)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	expectedLastHeight := uint64(1124)
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	d.On("GetLastBlockHeight").Return(expectedLastHeight, error(nil))
+	d.On("GetTransactionPool").Return([]string{}, error(nil)).Maybe()
+	_, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+
+	// When
+	err := p.load(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+
+	<-time.After(300 * time.Millisecond)
+	cache, err := q.FindCryptoCacheByCoin(ctx, db.CoinTypeXMR)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	assert.True(t, cache.LastSyncedBlockHeight.Valid)
+	assert.Equal(t, int64(expectedLastHeight), cache.LastSyncedBlockHeight.Int64)
+}
+
+func TestUpdatePaymentConfirmations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	txId := uuid.NewString()
+
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	d.On("GetTransactions", []string{txId}).Return([]TestTx{{TxId: txId, Confirmations: 1, BlockHeight: 100}}, error(nil))
+	_, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeXMR, IsOccupied: true, UserID: userId})
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		CryptoAddress:         addr.Address,
+		Coin:                  addr.Coin,
+		RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(10)),
+		ConfirmationsRequired: 1,
+		ExpiresAt:             pgtype.Timestamptz{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+		UserID:                userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	payment, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: invoice.ID, TxID: txId, Amount: util.BigIntToPgNumeric(big.NewInt(10)), Confirmations: 0})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// When
+	payments := []db.InvoicePayment{payment}
+	accepted, err := p.updatePaymentConfirmations(ctx, q, payments)
+
+	// Assert
+	t.Run("Should Store The Height Of The Block With The Tx", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.True(t, accepted)
+		assert.Equal(t, int64(1), payments[0].Confirmations)
+		assert.Equal(t, pgtype.Int8{Int64: 100, Valid: true}, payments[0].BlockHeight)
+	})
+}
+
+func TestHandleReorg(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+	qTest := db_test.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+		Address:    uuid.NewString(),
+		Coin:       db.CoinTypeXMR,
+		IsOccupied: false,
+		UserID:     userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+		log.Fatal(err)
+	}
+	paymentRequest, err := q.CreatePaymentRequest(ctx, userId)
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		CryptoAddress:         addr.Address,
+		Coin:                  addr.Coin,
+		RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(10)),
+		ConfirmationsRequired: 1,
+		ExpiresAt:             expiresAt,
+		UserID:                userId,
+		PaymentRequestID:      paymentRequest.ID,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	txId := uuid.NewString()
+	payment, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: invoice.ID, TxID: txId, Amount: util.BigIntToPgNumeric(big.NewInt(10)), Confirmations: 0})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.UpdateInvoicePaymentConfirmationsById(ctx, db.UpdateInvoicePaymentConfirmationsByIdParams{ID: payment.ID, Confirmations: 3, BlockHeight: pgtype.Int8{Int64: 105, Valid: true}}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: invoice.ID, ActualAmount: util.BigIntToPgNumeric(big.NewInt(10)), TxID: pgtype.Text{String: txId, Valid: true}}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.UpdateInvoiceConfirmationsById(ctx, db.UpdateInvoiceConfirmationsByIdParams{ID: invoice.ID, Confirmations: 3}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.ConfirmInvoiceById(ctx, invoice.ID); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.MarkPaymentRequestPaidById(ctx, db.MarkPaymentRequestPaidByIdParams{ID: paymentRequest.ID, PaidInvoiceID: invoice.ID}); err != nil {
+		log.Fatal(err)
+	}
+
+	// The tx is back in the mempool after the reorg.
+	d.On("GetTransactions", []string{txId}).Return([]TestTx{{TxId: txId, Confirmations: 0}}, error(nil)).Maybe()
+
+	// When
+	p.handleReorg(ctx, listener.Reorg{ForkHeight: 100, Depth: 5})
+
+	// Assert
+	t.Run("Should Revert The Confirmed Invoice", func(t *testing.T) {
+		broadcastedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, invoice.ID, broadcastedInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, broadcastedInvoice.Status)
+
+		persistedInvoice := getInvoiceOrFatal(ctx, qTest, &invoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, persistedInvoice.Status)
+		assert.False(t, persistedInvoice.ConfirmedAt.Valid)
+		assert.Equal(t, int32(0), persistedInvoice.Confirmations)
+		assert.True(t, persistedInvoice.ExpiresAt.Time.After(time.Now().UTC()))
+	})
+
+	t.Run("Should Watch The Reverted Invoice Again", func(t *testing.T) {
+		value, ok := p.pendingInvoices.Load(addr.Address)
+		assert.True(t, ok)
+		assert.Equal(t, invoice.ID, value.invoice.Load().ID)
+
+		_, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: addr.Coin})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+
+	t.Run("Should Reset The Orphaned Payment", func(t *testing.T) {
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 1)
+		assert.Equal(t, int64(0), payments[0].Confirmations)
+		assert.False(t, payments[0].BlockHeight.Valid)
+	})
+
+	t.Run("Should Revert The Payment Request Paid By The Invoice", func(t *testing.T) {
+		persistedRequest, err := q.FindPaymentRequestById(ctx, paymentRequest.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, db.PaymentRequestStatusTypePENDING, persistedRequest.Status)
+		assert.False(t, persistedRequest.PaidInvoiceID.Valid)
+		assert.False(t, persistedRequest.PaidAt.Valid)
+	})
+}
+
+func TestDispatchInvoiceEventsSettlesPaymentRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+	pp := &PaymentProcessor{
+		ctx:              ctx,
+		log:              p.log,
+		dbConnPool:       p.dbConnPool,
+		newInvoicesCns:   &util.SyncMapTypeSafe[string, chan dto.InvoiceEvent]{},
+		cryptoProcessors: map[db.CoinType]cryptoProcessor{db.CoinTypeXMR: p},
+	}
+
+	q := db.New(p.dbConnPool)
+	qTest := db_test.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	paymentRequest, err := q.CreatePaymentRequest(ctx, userId)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+		log.Fatal(err)
+	}
+	invoices := make([]db.Invoice, 0, 2)
+	for i := 0; i < 2; i++ {
+		addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+			Address:    uuid.NewString(),
+			Coin:       db.CoinTypeXMR,
+			IsOccupied: true,
+			UserID:     userId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         addr.Address,
+			Coin:                  addr.Coin,
+			RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(10)),
+			ConfirmationsRequired: 0,
+			ExpiresAt:             expiresAt,
+			UserID:                userId,
+			PaymentRequestID:      paymentRequest.ID,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		invoices = append(invoices, invoice)
+	}
+	paidInvoice, siblingInvoice := invoices[0], invoices[1]
+	p.handleInvoice(ctx, siblingInvoice)
+
+	confirmedInvoice, err := q.ConfirmInvoiceById(ctx, paidInvoice.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := createInvoiceEvent(ctx, p.log, q, &confirmedInvoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+		log.Fatal(err)
+	}
+
+	// When
+	n, err := pp.dispatchInvoiceEventsBatch()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	t.Run("Should Mark The Payment Request Paid", func(t *testing.T) {
+		persistedRequest, err := q.FindPaymentRequestById(ctx, paymentRequest.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, db.PaymentRequestStatusTypePAID, persistedRequest.Status)
+		assert.Equal(t, paidInvoice.ID, persistedRequest.PaidInvoiceID)
+	})
+
+	t.Run("Should Cancel The Sibling Invoice", func(t *testing.T) {
+		broadcastedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, siblingInvoice.ID, broadcastedInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, broadcastedInvoice.Status)
+
+		persistedInvoice := getInvoiceOrFatal(ctx, qTest, &siblingInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, persistedInvoice.Status)
+
+		_, ok := p.pendingInvoices.Load(siblingInvoice.CryptoAddress)
+		assert.False(t, ok)
+
+		events, err := q.FindUnpublishedInvoiceEventsAndLock(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, siblingInvoice.ID, events[0].InvoiceID)
+		assert.Equal(t, db.InvoiceStatusTypeCANCELLED, events[0].Status)
+	})
+}
+
+func TestHandleNewPaymentRequestFailure(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	_, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+	pp := &PaymentProcessor{
+		ctx:              ctx,
+		log:              p.log,
+		dbConnPool:       p.dbConnPool,
+		cryptoProcessors: map[db.CoinType]cryptoProcessor{db.CoinTypeXMR: p},
+	}
+
+	q := db.New(p.dbConnPool)
+	qTest := db_test.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// When
+	_, _, err = pp.HandleNewPaymentRequest(&dto.NewPaymentRequest{
+		UserId:  util.PgUUIDToString(userId),
+		Coins:   []dto.PaymentRequestCoin{{Coin: db.CoinTypeBTC, Amount: big.NewInt(10)}},
+		Timeout: 3600,
+	})
+
+	// Assert
+	t.Run("Should Expire The Payment Request Without Invoices", func(t *testing.T) {
+		assert.ErrorIs(t, err, unimplementedError)
+
+		paymentRequests, err := qTest.FindPaymentRequestsByUserId(ctx, userId)
+		assert.NoError(t, err)
+		assert.Len(t, paymentRequests, 1)
+		assert.Equal(t, db_test.PaymentRequestStatusTypeEXPIRED, paymentRequests[0].Status)
+	})
+}
+
+func TestHandleReorgWatchesResetTxs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	txId := uuid.NewString()
+
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	// The tx didn't make it back into the mempool after the reorg.
+	d.On("GetTransactions", []string{txId}).Return([]TestTx{}, error(nil))
+	invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeXMR, IsOccupied: true, UserID: userId})
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		CryptoAddress:         addr.Address,
+		Coin:                  addr.Coin,
+		RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(10)),
+		ConfirmationsRequired: 6,
+		ExpiresAt:             pgtype.Timestamptz{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+		UserID:                userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	payment, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: invoice.ID, TxID: txId, Amount: util.BigIntToPgNumeric(big.NewInt(10)), Confirmations: 0})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.UpdateInvoicePaymentConfirmationsById(ctx, db.UpdateInvoicePaymentConfirmationsByIdParams{ID: payment.ID, Confirmations: 2, BlockHeight: pgtype.Int8{Int64: 103, Valid: true}}); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: invoice.ID, ActualAmount: util.BigIntToPgNumeric(big.NewInt(10)), TxID: pgtype.Text{String: txId, Valid: true}}); err != nil {
+		log.Fatal(err)
+	}
+
+	// When
+	p.handleReorg(ctx, listener.Reorg{ForkHeight: 100, Depth: 5})
+
+	// Assert
+	t.Run("Should Revert The Payment Of The Tx Missing After The Reorg", func(t *testing.T) {
+		broadcastedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+		assert.Equal(t, invoice.ID, broadcastedInvoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePENDING, broadcastedInvoice.Status)
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 0)
+	})
+}
+
+func TestHandleDroppedTx(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	// Given
+	txId := uuid.NewString()
+	replacementTxId := uuid.NewString()
+
+	d := listener.NewMockSharedDaemonRpcClient[TestTx, TestBlock](t)
+	d.On("GetNetworkType").Return(listener.StagenetXMR, error(nil))
+	d.On("GetCoinType").Return(db.CoinTypeXMR)
+	d.On("GetTransactions", []string{replacementTxId}).Return([]TestTx{{TxId: replacementTxId, Confirmations: 0}}, error(nil))
+	invoiceCn, p, _, close := createNewTestBaseCryptoProcessor(
+		d,
+		func(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[TestTx]) (*big.Int, error) {
+			if data.tx.TxId == replacementTxId {
+				return big.NewInt(10), nil
+			}
+			return big.NewInt(0), nil
+		},
+		func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+			return db.CryptoAddress{}, nil
+		},
+	)
+	defer close(ctx)
+
+	q := db.New(p.dbConnPool)
+	qTest := db_test.New(p.dbConnPool)
+	userId, err := q.CreateUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	addr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{
+		Address:    uuid.NewString(),
+		Coin:       db.CoinTypeXMR,
+		IsOccupied: true,
+		UserID:     userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if err := expiresAt.Scan(time.Now().UTC().Add(1 * time.Hour)); err != nil {
+		log.Fatal(err)
+	}
+	invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+		CryptoAddress:         addr.Address,
+		Coin:                  addr.Coin,
+		RequiredAmount:        util.BigIntToPgNumeric(big.NewInt(10)),
+		ConfirmationsRequired: 1,
+		ExpiresAt:             expiresAt,
+		UserID:                userId,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: invoice.ID, TxID: txId, Amount: util.BigIntToPgNumeric(big.NewInt(10)), Confirmations: 0}); err != nil {
+		log.Fatal(err)
+	}
+	invoice, err = q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: invoice.ID, ActualAmount: util.BigIntToPgNumeric(big.NewInt(10)), TxID: pgtype.Text{String: txId, Valid: true}})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	d.On("GetTransactions", []string{txId}).Return([]TestTx{{TxId: txId, Confirmations: 0}}, error(nil)).Maybe()
+	p.handleInvoice(ctx, invoice)
+
+	// When
+	p.handleDroppedTx(ctx, listener.DroppedTx{TxId: txId, ReplacedBy: replacementTxId})
+
+	// Assert
+	t.Run("Should Revert The Invoice And Track The Replacement Tx", func(t *testing.T) {
+		broadcastedInvoices := make(map[db.InvoiceStatusType]db.Invoice)
+		for i := 0; i < 2; i++ {
+			broadcastedInvoice := test.GetValueFromCnOrLogFatalWithTimeout(invoiceCn, util.MIN_SYNC_TIMEOUT, "Timeout expired")
+			broadcastedInvoices[broadcastedInvoice.Status] = broadcastedInvoice
+		}
+		assert.Contains(t, broadcastedInvoices, db.InvoiceStatusTypePENDING)
+		assert.Contains(t, broadcastedInvoices, db.InvoiceStatusTypePENDINGMEMPOOL)
+
+		persistedInvoice := getInvoiceOrFatal(ctx, qTest, &invoice.ID)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, persistedInvoice.Status)
+		assert.Equal(t, replacementTxId, persistedInvoice.TxID.String)
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 1)
+		assert.Equal(t, replacementTxId, payments[0].TxID)
+	})
+
+	t.Run("Should Write A MEMPOOL_DROPPED Event", func(t *testing.T) {
+		events, err := q.FindUnpublishedInvoiceEventsAndLock(ctx, 10)
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, db.InvoiceEventTypeMEMPOOLDROPPED, events[0].Type)
+		assert.Equal(t, db.InvoiceStatusTypePENDING, events[0].Status)
+		assert.Equal(t, db.InvoiceEventTypeSTATUSCHANGED, events[1].Type)
+		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, events[1].Status)
+	})
 }
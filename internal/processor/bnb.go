@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"math/big"
 	"unsafe"
 
 	"github.com/chekist32/goipay/internal/db"
@@ -14,7 +15,7 @@ import (
 	"github.com/rs/zerolog"
 )
 
-func verifyBNBTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BNBTx]) (float64, error) {
+func verifyBNBTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BNBTx]) (*big.Int, error) {
 	return verifyETHBasedTxHandler(ctx, q, (*verifyTxHandlerData[listener.ETHTx])(unsafe.Pointer(data)))
 }
 
@@ -48,24 +49,36 @@ type bnbProcessor struct {
 	baseCryptoProcessor[listener.BNBTx, listener.BNBBlock]
 }
 
-func newBnbProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*bnbProcessor, error) {
+func newBnbProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig, invoiceConf *dto.InvoiceConfig) (*bnbProcessor, error) {
 	client, err := ethclient.Dial(c.Bnb.Url)
 	if err != nil {
 		return nil, err
 	}
 
+	daemon := listener.NewSharedBNBDaemonRpcClient(client)
+
 	base, err := newBaseCryptoProcessor(
 		log,
 		dbConnPool,
 		invoiceCn,
-		listener.NewSharedBNBDaemonRpcClient(client),
+		invoiceConf,
+		daemon,
 		verifyBNBTxHandler,
 		generateNextBNBAddressHandler,
-		util.GetMapKeys(tokenDataETHCompatible[db.CoinTypeBNB]),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	chainId, ok := chainIdsETHCompatible[base.network]
+	if !ok {
+		return nil, util.InvalidNetworkTypeErr
+	}
+
+	base.tokens, err = loadTokensETHCompatible(context.Background(), log, dbConnPool, base.coin, chainId, daemon)
+	if err != nil {
+		return nil, err
+	}
+
 	return &bnbProcessor{baseCryptoProcessor: *base}, nil
 }
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"math/big"
 	"net/url"
 
 	"github.com/btcsuite/btcd/btcutil"
@@ -20,13 +21,17 @@ type btcProcessor struct {
 	baseCryptoProcessor[listener.BTCTx, listener.BTCBlock]
 }
 
-func verifyBTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BTCTx]) (float64, error) {
-	var amount float64 = 0
+func verifyBTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.BTCTx]) (*big.Int, error) {
+	amount := new(big.Int)
 	for i := 0; i < len(data.tx.Vout); i++ {
 		txOut := &data.tx.Vout[i]
 
 		if txOut.ScriptPubKey.Address == data.invoice.CryptoAddress {
-			amount += data.tx.Vout[i].Value
+			sat, err := btcutil.NewAmount(txOut.Value)
+			if err != nil {
+				return nil, err
+			}
+			amount.Add(amount, big.NewInt(int64(sat)))
 		}
 	}
 
@@ -97,7 +102,7 @@ func generateNextBTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 	return addr, nil
 }
 
-func newBtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*btcProcessor, error) {
+func newBtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig, invoiceConf *dto.InvoiceConfig) (*btcProcessor, error) {
 	u, err := url.Parse(c.Btc.Url)
 	if err != nil {
 		return nil, err
@@ -118,10 +123,10 @@ func newBtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		log,
 		dbConnPool,
 		invoiceCn,
-		listener.NewSharedBTCDaemonRpcClient(client),
+		invoiceConf,
+		listener.NewSharedBTCDaemonRpcClient(log, client, listener.ZMQConfig{RawTx: c.Btc.ZmqPubRawTx, HashBlock: c.Btc.ZmqPubHashBlock}),
 		verifyBTCTxHandler,
 		generateNextBTCAddressHandler,
-		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -11,10 +11,12 @@ import (
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
 	test_db "github.com/chekist32/goipay/test/db"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -119,7 +121,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 	dbConn, _, close := getPostgresWithDbConn()
 	defer close(ctx)
 
-	daemon := listener.NewSharedBTCDaemonRpcClient(createNewTestBtcDaemon())
+	daemon := listener.NewSharedBTCDaemonRpcClient(&zerolog.Logger{}, createNewTestBtcDaemon(), listener.ZMQConfig{})
 
 	t.Run("Should Return Right Amount (Valid Tx)", func(t *testing.T) {
 		test.RunInTransaction(t, dbConn, func(t *testing.T, tx pgx.Tx) {
@@ -132,7 +134,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeBTC,
 				CryptoAddress:         "bc1q8e8qkxqtgfypwwnh6zf5msx82yw2p4l9sy26ey",
-				RequiredAmount:        0.00480740,
+				RequiredAmount:        newPgNumericOrFatal("480740"),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -152,7 +154,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, expectedInvoice.RequiredAmount, amount)
+			assert.Equal(t, util.PgNumericToString(expectedInvoice.RequiredAmount), amount.String())
 		})
 	})
 
@@ -167,7 +169,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeBTC,
 				CryptoAddress:         "bc1q8e8qkxqtgfypwwnh6zf5msx82yw2p4l9sy26ey",
-				RequiredAmount:        0.00480740,
+				RequiredAmount:        newPgNumericOrFatal("480740"),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -187,7 +189,7 @@ func TestVerifyBTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, float64(0), amount)
+			assert.Equal(t, 0, amount.Sign())
 		})
 	})
 }
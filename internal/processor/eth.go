@@ -43,24 +43,36 @@ func generateNextETHAddressHandler(ctx context.Context, q *db.Queries, data *gen
 	return addr, nil
 }
 
-func newEthProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*ethProcessor, error) {
+func newEthProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig, invoiceConf *dto.InvoiceConfig) (*ethProcessor, error) {
 	client, err := ethclient.Dial(c.Eth.Url)
 	if err != nil {
 		return nil, err
 	}
 
+	daemon := listener.NewSharedETHDaemonRpcClient(client)
+
 	base, err := newBaseCryptoProcessor(
 		log,
 		dbConnPool,
 		invoiceCn,
-		listener.NewSharedETHDaemonRpcClient(client),
+		invoiceConf,
+		daemon,
 		verifyETHBasedTxHandler,
 		generateNextETHAddressHandler,
-		util.GetMapKeys(tokenDataETHCompatible[db.CoinTypeETH]),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	chainId, ok := chainIdsETHCompatible[base.network]
+	if !ok {
+		return nil, util.InvalidNetworkTypeErr
+	}
+
+	base.tokens, err = loadTokensETHCompatible(context.Background(), log, dbConnPool, base.coin, chainId, daemon)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ethProcessor{baseCryptoProcessor: *base}, nil
 }
@@ -0,0 +1,93 @@
+package processor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTransferCalldata(recipient common.Address, amount *big.Int) []byte {
+	data := hexutil.MustDecode(transferMethodIdETHCompatible)
+	data = append(data, common.LeftPadBytes(recipient.Bytes(), common.HashLength)...)
+	return append(data, common.LeftPadBytes(amount.Bytes(), common.HashLength)...)
+}
+
+func TestDecodeTransferCalldataETHCompatible(t *testing.T) {
+	recipient := common.HexToAddress("0x35df6C0ECA8AE63D489cd28ECfeA811fA8Fc5Bb1")
+
+	t.Run("Should Decode The Transfer Call", func(t *testing.T) {
+		decodedRecipient, amount, ok := decodeTransferCalldataETHCompatible(newTransferCalldata(recipient, big.NewInt(2169080917)))
+		assert.True(t, ok)
+		assert.Equal(t, recipient, decodedRecipient)
+		assert.Equal(t, big.NewInt(2169080917), amount)
+	})
+
+	t.Run("Should Reject Other Calls", func(t *testing.T) {
+		data := newTransferCalldata(recipient, big.NewInt(1))
+		copy(data, hexutil.MustDecode("0x095ea7b3"))
+
+		_, _, ok := decodeTransferCalldataETHCompatible(data)
+		assert.False(t, ok)
+
+		_, _, ok = decodeTransferCalldataETHCompatible(data[:10])
+		assert.False(t, ok)
+	})
+}
+
+func TestVerifyETHBasedTxHandlerPendingTx(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0x35df6C0ECA8AE63D489cd28ECfeA811fA8Fc5Bb1")
+	usdt := &db.Token{Coin: db.CoinTypeUSDTERC20, ContractAddress: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6}
+	usdc := &db.Token{Coin: db.CoinTypeUSDCERC20, ContractAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6}
+	contract := common.HexToAddress(usdt.ContractAddress)
+
+	newPendingTx := func(to common.Address, value *big.Int, data []byte) listener.ETHTx {
+		return listener.ETHTx{Tx: types.NewTx(&types.LegacyTx{To: &to, Value: value, Data: data}), Pending: true}
+	}
+
+	t.Run("Should Return The Amount Of The Pending Native Transfer", func(t *testing.T) {
+		amount, err := verifyETHBasedTxHandler(ctx, nil, &verifyTxHandlerData[listener.ETHTx]{
+			invoice: db.Invoice{Coin: db.CoinTypeETH, CryptoAddress: address.Hex()},
+			tx:      newPendingTx(address, big.NewInt(1000), nil),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(1000), amount)
+	})
+
+	t.Run("Should Return The Amount Of The Pending Token Transfer", func(t *testing.T) {
+		amount, err := verifyETHBasedTxHandler(ctx, nil, &verifyTxHandlerData[listener.ETHTx]{
+			invoice: db.Invoice{Coin: db.CoinTypeUSDTERC20, CryptoAddress: address.Hex()},
+			tx:      newPendingTx(contract, big.NewInt(0), newTransferCalldata(address, big.NewInt(2169080917))),
+			token:   usdt,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(2169080917), amount)
+	})
+
+	t.Run("Should Return 0 For The Transfer Of Another Token", func(t *testing.T) {
+		amount, err := verifyETHBasedTxHandler(ctx, nil, &verifyTxHandlerData[listener.ETHTx]{
+			invoice: db.Invoice{Coin: db.CoinTypeUSDCERC20, CryptoAddress: address.Hex()},
+			tx:      newPendingTx(contract, big.NewInt(0), newTransferCalldata(address, big.NewInt(2169080917))),
+			token:   usdc,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), amount.Int64())
+	})
+
+	t.Run("Should Return 0 For The Transfer To Another Address", func(t *testing.T) {
+		amount, err := verifyETHBasedTxHandler(ctx, nil, &verifyTxHandlerData[listener.ETHTx]{
+			invoice: db.Invoice{Coin: db.CoinTypeUSDTERC20, CryptoAddress: address.Hex()},
+			tx:      newPendingTx(contract, big.NewInt(0), newTransferCalldata(common.HexToAddress("0x06Ac0C1C504218af3448E00ba1924455183D042C"), big.NewInt(1))),
+			token:   usdt,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), amount.Int64())
+	})
+}
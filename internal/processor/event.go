@@ -0,0 +1,50 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/internal/webhook"
+	"github.com/rs/zerolog"
+)
+
+// createInvoiceEvent writes the invoice event to the outbox.
+// It must run in the same tx as the invoice update, so a committed update always has an event.
+func createInvoiceEvent(ctx context.Context, log *zerolog.Logger, q *db.Queries, invoice *db.Invoice, eventType db.InvoiceEventType) error {
+	payload, err := json.Marshal(invoice)
+	if err != nil {
+		log.Err(err).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while serializing the invoice event.")
+		return err
+	}
+
+	if _, err := q.CreateInvoiceEvent(ctx, db.CreateInvoiceEventParams{InvoiceID: invoice.ID, Status: invoice.Status, Type: eventType, Payload: payload}); err != nil {
+		log.Err(err).Str("queryName", "CreateInvoiceEvent").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	return nil
+}
+
+// createWebhookDeliveries queues the webhook deliveries of a published event, so the payload carries its published sequence.
+// Status changes and dropped mempool payments get webhook deliveries, confirmation progress is only streamed.
+func createWebhookDeliveries(ctx context.Context, log *zerolog.Logger, q *db.Queries, event *dto.InvoiceEvent) error {
+	if event.Type == db.InvoiceEventTypeCONFIRMATIONPROGRESS {
+		return nil
+	}
+	invoice := &event.Invoice
+
+	webhookPayload, err := webhook.NewPayload(event)
+	if err != nil {
+		log.Err(err).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while serializing the webhook payload.")
+		return err
+	}
+	if _, err := q.CreateWebhookDeliveriesByUserId(ctx, db.CreateWebhookDeliveriesByUserIdParams{InvoiceID: invoice.ID, Payload: webhookPayload, UserID: invoice.UserID}); err != nil {
+		log.Err(err).Str("queryName", "CreateWebhookDeliveriesByUserId").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package processor
+
+import (
+	"context"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+// evm_native_decimals are the decimals of the native coin of every EVM chain.
+const evm_native_decimals int = 18
+
+// evmProcessor accepts the native coin and the tokens of an EVM chain declared in the config.
+// The txs are verified and the addresses derived the same way as on ETH.
+type evmProcessor struct {
+	baseCryptoProcessor[listener.ETHTx, listener.ETHBlock]
+
+	chainId uint64
+	// symbol is the native coin of the chain (e.g. ETH on Arbitrum), whose exchange rate is used for the fiat invoices.
+	symbol db.CoinType
+}
+
+func generateNextEVMAddressHandler(coin db.CoinType) func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+	return func(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
+		var addr db.CryptoAddress
+
+		keysAndIndices, err := q.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain(ctx, db.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainParams{UserID: data.userId, Chain: coin})
+		if err != nil {
+			return addr, err
+		}
+
+		pubKey, err := deriveNextETHBasedECPubKeyHelper(indices{major: uint32(keysAndIndices.LastMajorIndex), minor: uint32(keysAndIndices.LastMinorIndex)}, keysAndIndices.MasterPubKey)
+		if err != nil {
+			return addr, err
+		}
+
+		addr, err = q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: crypto.PubkeyToAddress(*pubKey.ToECDSA()).Hex(), Coin: coin, IsOccupied: true, UserID: data.userId})
+		if err != nil {
+			return addr, err
+		}
+
+		return addr, nil
+	}
+}
+
+func (p *evmProcessor) paymentUri(invoice *db.Invoice) (string, error) {
+	amount, err := util.PgNumericToBigInt(invoice.RequiredAmount)
+	if err != nil {
+		return "", err
+	}
+
+	return newPaymentUriETHCompatible(invoice.CryptoAddress, amount, p.token(invoice.Coin), p.chainId), nil
+}
+
+func (p *evmProcessor) decimals(coin db.CoinType) (int, bool) {
+	if coin == p.coin {
+		return evm_native_decimals, true
+	}
+
+	return p.baseCryptoProcessor.decimals(coin)
+}
+
+func (p *evmProcessor) rateCoin(coin db.CoinType) db.CoinType {
+	if coin == p.coin {
+		return p.symbol
+	}
+
+	return coin
+}
+
+// createCryptoCache adds the crypto_cache row of the chain the first time it's synced.
+func createCryptoCache(ctx context.Context, log *zerolog.Logger, dbConnPool *pgxpool.Pool, coin db.CoinType) error {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, dbConnPool)
+	if err != nil {
+		log.Err(err).Str("coin", string(coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := q.CreateCryptoCacheIfNotExists(ctx, coin); err != nil {
+		log.Err(err).Str("coin", string(coin)).Str("queryName", "CreateCryptoCacheIfNotExists").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func newEvmProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.EVMChainConfig, invoiceConf *dto.InvoiceConfig) (*evmProcessor, error) {
+	client, err := ethclient.Dial(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	daemon := listener.NewSharedEVMDaemonRpcClient(client, c.Coin, c.ChainId, c.BlockTime)
+
+	base, err := newBaseCryptoProcessor(
+		log,
+		dbConnPool,
+		invoiceCn,
+		invoiceConf,
+		daemon,
+		verifyETHBasedTxHandler,
+		generateNextEVMAddressHandler(c.Coin),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := createCryptoCache(context.Background(), log, dbConnPool, base.coin); err != nil {
+		return nil, err
+	}
+
+	base.tokens, err = loadTokensETHCompatible(context.Background(), log, dbConnPool, base.coin, c.ChainId, daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	return &evmProcessor{baseCryptoProcessor: *base, chainId: c.ChainId, symbol: db.CoinType(c.Symbol)}, nil
+}
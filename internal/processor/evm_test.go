@@ -0,0 +1,57 @@
+package processor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvmProcessor(t *testing.T) {
+	usdc := db.Token{Coin: "USDC_POLYGON", Chain: "POLYGON", ChainID: 137, ContractAddress: "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", Decimals: 6}
+	evm := &evmProcessor{
+		baseCryptoProcessor: baseCryptoProcessor[listener.ETHTx, listener.ETHBlock]{
+			coin:    "POLYGON",
+			network: listener.EVM,
+			tokens:  map[db.CoinType]db.Token{usdc.Coin: usdc},
+		},
+		chainId: 137,
+		symbol:  "POL",
+	}
+	p := &PaymentProcessor{cryptoProcessors: map[db.CoinType]cryptoProcessor{evm.coin: evm}}
+
+	newInvoice := func(coin db.CoinType, amount int64) *db.Invoice {
+		return &db.Invoice{Coin: coin, CryptoAddress: "0x305c30dDc9DBCd1E831D8c894790AE0835B9D65d", RequiredAmount: util.BigIntToPgNumeric(big.NewInt(amount))}
+	}
+
+	t.Run("Should Return EIP-681 URI With The Declared Chain Id", func(t *testing.T) {
+		uri, err := p.PaymentUri(newInvoice("POLYGON", 1000000000000000000))
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0x305c30dDc9DBCd1E831D8c894790AE0835B9D65d@137?value=1000000000000000000", uri)
+
+		uri, err = p.PaymentUri(newInvoice(usdc.Coin, 25000000))
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359@137/transfer?address=0x305c30dDc9DBCd1E831D8c894790AE0835B9D65d&uint256=25000000", uri)
+	})
+
+	t.Run("Should Return Decimals Of The Native Coin And The Tokens", func(t *testing.T) {
+		decimals, ok := p.coinDecimals("POLYGON")
+		assert.True(t, ok)
+		assert.Equal(t, 18, decimals)
+
+		decimals, ok = p.coinDecimals(usdc.Coin)
+		assert.True(t, ok)
+		assert.Equal(t, 6, decimals)
+
+		_, ok = p.coinDecimals(db.CoinTypeETH)
+		assert.False(t, ok)
+	})
+
+	t.Run("Should Price The Native Coin By Its Symbol", func(t *testing.T) {
+		assert.Equal(t, db.CoinType("POL"), p.rateCoin("POLYGON"))
+		assert.Equal(t, usdc.Coin, p.rateCoin(usdc.Coin))
+	})
+}
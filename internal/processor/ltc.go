@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"math/big"
 	"net/url"
 
 	"github.com/btcsuite/btcd/rpcclient"
@@ -21,14 +22,18 @@ type ltcProcessor struct {
 	baseCryptoProcessor[listener.LTCTx, listener.LTCBlock]
 }
 
-func verifyLTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.LTCTx]) (float64, error) {
-	var amount float64 = 0
+func verifyLTCTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.LTCTx]) (*big.Int, error) {
+	amount := new(big.Int)
 	for i := 0; i < len(data.tx.Vout); i++ {
 		txOut := &data.tx.Vout[i]
 
 		if txOut.ScriptPubKey.Address == data.invoice.CryptoAddress ||
 			(len(txOut.ScriptPubKey.Addresses) == 1 && txOut.ScriptPubKey.Addresses[0] == data.invoice.CryptoAddress) {
-			amount += data.tx.Vout[i].Value
+			lit, err := ltcutil.NewAmount(txOut.Value)
+			if err != nil {
+				return nil, err
+			}
+			amount.Add(amount, big.NewInt(int64(lit)))
 		}
 	}
 
@@ -99,7 +104,7 @@ func generateNextLTCAddressHandler(ctx context.Context, q *db.Queries, data *gen
 	return addr, nil
 }
 
-func newLtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*ltcProcessor, error) {
+func newLtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig, invoiceConf *dto.InvoiceConfig) (*ltcProcessor, error) {
 	u, err := url.Parse(c.Ltc.Url)
 	if err != nil {
 		return nil, err
@@ -131,10 +136,10 @@ func newLtcProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		log,
 		dbConnPool,
 		invoiceCn,
-		listener.NewSharedLTCDaemonRpcClient(client, ltcClient),
+		invoiceConf,
+		listener.NewSharedLTCDaemonRpcClient(log, client, ltcClient, listener.ZMQConfig{RawTx: c.Ltc.ZmqPubRawTx, HashBlock: c.Ltc.ZmqPubHashBlock}),
 		verifyLTCTxHandler,
 		generateNextLTCAddressHandler,
-		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -11,11 +11,13 @@ import (
 	"github.com/btcsuite/btcd/rpcclient"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
 	test_db "github.com/chekist32/goipay/test/db"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	ltcrpc "github.com/ltcsuite/ltcd/rpcclient"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -136,7 +138,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 	dbConn, _, close := getPostgresWithDbConn()
 	defer close(ctx)
 
-	daemon := listener.NewSharedLTCDaemonRpcClient(createNewTestLtcDaemon1(), createNewTestLtcDaemon2())
+	daemon := listener.NewSharedLTCDaemonRpcClient(&zerolog.Logger{}, createNewTestLtcDaemon1(), createNewTestLtcDaemon2(), listener.ZMQConfig{})
 
 	t.Run("Should Return Right Amount (Valid Tx)", func(t *testing.T) {
 		test.RunInTransaction(t, dbConn, func(t *testing.T, tx pgx.Tx) {
@@ -149,7 +151,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeLTC,
 				CryptoAddress:         "ltc1qa9fetyxs65t03w32vfyen4w2nph9uq9wr7pmg4",
-				RequiredAmount:        3.05398200,
+				RequiredAmount:        newPgNumericOrFatal("305398200"),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -169,7 +171,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, expectedInvoice.RequiredAmount, amount)
+			assert.Equal(t, util.PgNumericToString(expectedInvoice.RequiredAmount), amount.String())
 		})
 	})
 
@@ -184,7 +186,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeLTC,
 				CryptoAddress:         "ltc1qa9fetyxs65t03w32vfyen4w2nph9uq9wr7pmg4",
-				RequiredAmount:        3.05398200,
+				RequiredAmount:        newPgNumericOrFatal("305398200"),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -204,7 +206,7 @@ func TestVerifyLTCTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, float64(0), amount)
+			assert.Equal(t, 0, amount.Sign())
 		})
 	})
 }
@@ -1,26 +1,58 @@
 package processor
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"math"
+	"math/big"
+	"slices"
 	"time"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/rate"
 	"github.com/chekist32/goipay/internal/util"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rs/zerolog"
 )
 
 const (
 	persist_cache_timeout time.Duration = 1 * time.Minute
+
+	outbox_dispatch_interval time.Duration = 1 * time.Second
+	outbox_batch_size        int32         = 100
+
+	fiat_conversion_max_decimals int = 8
 )
 
 var (
 	unimplementedError error = errors.New("coin is either unimplemented or not set up")
+
+	RateProviderNotConfiguredErr error = errors.New("exchange rate provider is not configured")
+
+	duplicateChainErr error = errors.New("the chain is declared more than once")
 )
 
+var coinDecimals map[db.CoinType]int = map[db.CoinType]int{
+	db.CoinTypeXMR: 12,
+	db.CoinTypeBTC: 8,
+	db.CoinTypeLTC: 8,
+	db.CoinTypeETH: 18,
+	db.CoinTypeBNB: 18,
+}
+
+// convertFiatToCoinAmount returns the coin amount in atomic units.
+// It's rounded up to fiat_conversion_max_decimals so the invoice is never underpriced.
+func convertFiatToCoinAmount(fiatAmount float64, rate float64, decimals int) *big.Int {
+	precision := min(decimals, fiat_conversion_max_decimals)
+
+	amount, _ := big.NewFloat(math.Ceil(fiatAmount / rate * math.Pow10(precision))).Int(nil)
+	return amount.Mul(amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-precision)), nil))
+}
+
 type PaymentProcessor struct {
 	dbConnPool *pgxpool.Pool
 
@@ -28,9 +60,12 @@ type PaymentProcessor struct {
 	log *zerolog.Logger
 
 	invoiceCn      chan db.Invoice
-	newInvoicesCns *util.SyncMapTypeSafe[string, chan db.Invoice]
+	newInvoicesCns *util.SyncMapTypeSafe[string, chan dto.InvoiceEvent]
 
 	cryptoProcessors map[db.CoinType]cryptoProcessor
+	rateProvider     rate.RateProvider
+
+	latePaymentWindow time.Duration
 }
 
 func (p *PaymentProcessor) loadPersistedPendingInvoices() error {
@@ -52,6 +87,15 @@ func (p *PaymentProcessor) loadPersistedPendingInvoices() error {
 		return err
 	}
 
+	var expiredInvoices []db.Invoice
+	if p.latePaymentWindow > 0 {
+		expiredInvoices, err = q.FindExpiredInvoicesSince(p.ctx, pgtype.Timestamptz{Time: time.Now().UTC().Add(-p.latePaymentWindow), Valid: true})
+		if err != nil {
+			p.log.Err(err).Str("queryName", "FindExpiredInvoicesSince").Msg(util.DefaultFailedSqlQueryMsg)
+			return err
+		}
+	}
+
 	tx.Commit(p.ctx)
 
 	// TODO: Add implementation for TON
@@ -63,31 +107,129 @@ func (p *PaymentProcessor) loadPersistedPendingInvoices() error {
 		}
 	}
 
+	for i := 0; i < len(expiredInvoices); i++ {
+		for _, cp := range p.cryptoProcessors {
+			if cp.supportsCoin(expiredInvoices[i].Coin) {
+				cp.handleExpiredInvoice(p.ctx, expiredInvoices[i])
+			}
+		}
+	}
+
 	return nil
 }
 
+// publishInvoiceEvent hands the event off to every subscriber in order.
+// A subscriber which doesn't take it within SEND_TIMEOUT is dropped and its channel closed, so it can resume from the last received sequence.
+func (p *PaymentProcessor) publishInvoiceEvent(event *dto.InvoiceEvent) {
+	p.newInvoicesCns.Range(func(key string, cn chan dto.InvoiceEvent) bool {
+		select {
+		case cn <- *event:
+		case <-time.After(util.SEND_TIMEOUT):
+			p.newInvoicesCns.Delete(key)
+			close(cn)
+		case <-p.ctx.Done():
+			return false
+		}
+
+		return true
+	})
+
+	p.log.Info().Msgf("Transaction %v changed status to %v", util.PgUUIDToString(event.Invoice.ID), event.Invoice.Status)
+}
+
+// dispatchInvoiceEventsBatch publishes the oldest unpublished events from the outbox.
+// The dispatchers publish one batch at a time, so the published sequences follow the commit order.
+// The events are handed off to the subscribers only once the batch is committed, and a single goroutine dispatches them, so they arrive in sequence order.
+// It returns the number of dispatched events.
+func (p *PaymentProcessor) dispatchInvoiceEventsBatch() (int, error) {
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return 0, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	if err := q.LockInvoiceEventsPublishing(p.ctx); err != nil {
+		p.log.Err(err).Str("queryName", "LockInvoiceEventsPublishing").Msg(util.DefaultFailedSqlQueryMsg)
+		return 0, err
+	}
+
+	dbEvents, err := q.FindUnpublishedInvoiceEventsAndLock(p.ctx, outbox_batch_size)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindUnpublishedInvoiceEventsAndLock").Msg(util.DefaultFailedSqlQueryMsg)
+		return 0, err
+	}
+	if len(dbEvents) < 1 {
+		return 0, nil
+	}
+
+	events := make([]*dto.InvoiceEvent, 0, len(dbEvents))
+	cancelledInvoices := make([]db.Invoice, 0)
+	for i := 0; i < len(dbEvents); i++ {
+		dbEvent, err := q.PublishInvoiceEvent(p.ctx, dbEvents[i].Sequence)
+		if err != nil {
+			p.log.Err(err).Str("queryName", "PublishInvoiceEvent").Msg(util.DefaultFailedSqlQueryMsg)
+			return 0, err
+		}
+
+		event, err := util.DbInvoiceEventToDtoInvoiceEvent(&dbEvent)
+		if err != nil {
+			p.log.Err(err).Int64("sequence", dbEvent.Sequence).Msg("An error occurred while deserializing the invoice event.")
+			continue
+		}
+
+		if err := createWebhookDeliveries(p.ctx, p.log, q, event); err != nil {
+			return 0, err
+		}
+
+		invoices, err := p.handlePaymentRequestUpdate(q, event)
+		if err != nil {
+			return 0, err
+		}
+		cancelledInvoices = append(cancelledInvoices, invoices...)
+
+		events = append(events, event)
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return 0, err
+	}
+
+	for i := 0; i < len(events); i++ {
+		p.publishInvoiceEvent(events[i])
+	}
+
+	for i := 0; i < len(cancelledInvoices); i++ {
+		for _, cp := range p.cryptoProcessors {
+			if cp.supportsCoin(cancelledInvoices[i].Coin) {
+				cp.dropCancelledInvoice(p.ctx, &cancelledInvoices[i])
+			}
+		}
+	}
+
+	return len(dbEvents), nil
+}
+
+func (p *PaymentProcessor) dispatchInvoiceEvents() {
+	for {
+		n, err := p.dispatchInvoiceEventsBatch()
+		if err != nil || n < int(outbox_batch_size) {
+			return
+		}
+	}
+}
+
 func (p *PaymentProcessor) load() error {
+	// Status changes only nudge the dispatcher, the outbox is the source of truth.
+	// Polling picks up events whose nudge was lost, e.g. the ones committed before a restart.
 	go func() {
 		for {
 			select {
-			case tx := <-p.invoiceCn:
-				p.newInvoicesCns.Range(func(key string, cn chan db.Invoice) bool {
-					go func() {
-						select {
-						case cn <- tx:
-							return
-						case <-time.After(util.SEND_TIMEOUT):
-							p.newInvoicesCns.Delete(key)
-							return
-						case <-p.ctx.Done():
-							return
-						}
-					}()
-
-					return true
-				})
-
-				p.log.Info().Msgf("Transaction %v changed status to %v", util.PgUUIDToString(tx.ID), tx.Status)
+			case <-p.invoiceCn:
+				p.dispatchInvoiceEvents()
+			case <-time.After(outbox_dispatch_interval):
+				p.dispatchInvoiceEvents()
 			case <-p.ctx.Done():
 				return
 			}
@@ -107,7 +249,37 @@ func (p *PaymentProcessor) load() error {
 	return nil
 }
 
+func (p *PaymentProcessor) lockFiatConversion(req *dto.NewInvoiceRequest) error {
+	if p.rateProvider == nil {
+		return RateProviderNotConfiguredErr
+	}
+
+	decimals, ok := p.coinDecimals(req.Coin)
+	if !ok {
+		return unimplementedError
+	}
+
+	r, err := p.rateProvider.GetRate(p.ctx, p.rateCoin(req.Coin), req.FiatCurrency)
+	if err != nil {
+		p.log.Err(err).Str("coin", string(req.Coin)).Str("currency", req.FiatCurrency).Msg("An error occurred while fetching the exchange rate.")
+		return err
+	}
+
+	req.Conversion = &dto.FiatConversion{
+		CoinAmount: convertFiatToCoinAmount(req.FiatAmount, r.Value, decimals),
+		Rate:       *r,
+	}
+
+	return nil
+}
+
 func (p *PaymentProcessor) HandleNewInvoice(req *dto.NewInvoiceRequest) (*db.Invoice, error) {
+	if req.FiatCurrency != "" {
+		if err := p.lockFiatConversion(req); err != nil {
+			return nil, err
+		}
+	}
+
 	// TODO: Add implementation for TON
 	for _, cp := range p.cryptoProcessors {
 		if cp.supportsCoin(req.Coin) {
@@ -118,59 +290,135 @@ func (p *PaymentProcessor) HandleNewInvoice(req *dto.NewInvoiceRequest) (*db.Inv
 	return nil, unimplementedError
 }
 
-func (p *PaymentProcessor) NewInvoicesChan() <-chan db.Invoice {
-	cn := make(chan db.Invoice)
-	p.newInvoicesCns.Store(uuid.NewString(), cn)
-	return cn
+func (p *PaymentProcessor) CancelInvoice(invoice *db.Invoice) (*db.Invoice, error) {
+	for _, cp := range p.cryptoProcessors {
+		if cp.supportsCoin(invoice.Coin) {
+			return cp.cancelInvoice(p.ctx, invoice)
+		}
+	}
+
+	return nil, unimplementedError
+}
+
+func (p *PaymentProcessor) coinDecimals(coin db.CoinType) (int, bool) {
+	for _, cp := range p.cryptoProcessors {
+		if decimals, ok := cp.decimals(coin); ok {
+			return decimals, true
+		}
+	}
+
+	return 0, false
 }
 
-func NewPaymentProcessor(ctx context.Context, dbConnPool *pgxpool.Pool, c *dto.DaemonsConfig, log *zerolog.Logger) (*PaymentProcessor, error) {
+func (p *PaymentProcessor) rateCoin(coin db.CoinType) db.CoinType {
+	for _, cp := range p.cryptoProcessors {
+		if cp.supportsCoin(coin) {
+			return cp.rateCoin(coin)
+		}
+	}
+
+	return coin
+}
+
+// Tokens returns the tokens of the registry which are accepted by the configured chains.
+func (p *PaymentProcessor) Tokens() []db.Token {
+	tokens := make([]db.Token, 0)
+	for _, cp := range p.cryptoProcessors {
+		tokens = append(tokens, cp.supportedTokens()...)
+	}
+
+	slices.SortFunc(tokens, func(a, b db.Token) int {
+		return cmp.Or(cmp.Compare(a.Chain, b.Chain), cmp.Compare(a.Coin, b.Coin))
+	})
+
+	return tokens
+}
+
+// PaymentUri returns a wallet URI which pays the invoice.
+func (p *PaymentProcessor) PaymentUri(invoice *db.Invoice) (string, error) {
+	for _, cp := range p.cryptoProcessors {
+		if cp.supportsCoin(invoice.Coin) {
+			return cp.paymentUri(invoice)
+		}
+	}
+
+	return "", unimplementedError
+}
+
+// NewInvoicesChan subscribes to the published invoice events. The channel is closed if the subscriber falls behind.
+// The returned func unsubscribes.
+func (p *PaymentProcessor) NewInvoicesChan() (<-chan dto.InvoiceEvent, func()) {
+	key := uuid.NewString()
+	cn := make(chan dto.InvoiceEvent, outbox_batch_size)
+	p.newInvoicesCns.Store(key, cn)
+
+	return cn, func() { p.newInvoicesCns.Delete(key) }
+}
+
+func NewPaymentProcessor(ctx context.Context, dbConnPool *pgxpool.Pool, c *dto.DaemonsConfig, invoiceConf *dto.InvoiceConfig, rateProvider rate.RateProvider, log *zerolog.Logger) (*PaymentProcessor, error) {
 	invoiceCn := make(chan db.Invoice)
 	cryptoProcessors := make(map[db.CoinType]cryptoProcessor, 0)
 
+	if err := registerTokens(ctx, log, dbConnPool, c.Tokens); err != nil {
+		return nil, err
+	}
+
 	if c.Xmr.Url != "" {
-		xmr, err := newXmrProcessor(log, dbConnPool, invoiceCn, c)
+		xmr, err := newXmrProcessor(log, dbConnPool, invoiceCn, c, invoiceConf)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[xmr.coin] = xmr
 	}
 	if c.Btc.Url != "" {
-		btc, err := newBtcProcessor(log, dbConnPool, invoiceCn, c)
+		btc, err := newBtcProcessor(log, dbConnPool, invoiceCn, c, invoiceConf)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[btc.coin] = btc
 	}
 	if c.Ltc.Url != "" {
-		ltc, err := newLtcProcessor(log, dbConnPool, invoiceCn, c)
+		ltc, err := newLtcProcessor(log, dbConnPool, invoiceCn, c, invoiceConf)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[ltc.coin] = ltc
 	}
 	if c.Eth.Url != "" {
-		eth, err := newEthProcessor(log, dbConnPool, invoiceCn, c)
+		eth, err := newEthProcessor(log, dbConnPool, invoiceCn, c, invoiceConf)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[eth.coin] = eth
 	}
 	if c.Bnb.Url != "" {
-		bnb, err := newBnbProcessor(log, dbConnPool, invoiceCn, c)
+		bnb, err := newBnbProcessor(log, dbConnPool, invoiceCn, c, invoiceConf)
 		if err != nil {
 			return nil, err
 		}
 		cryptoProcessors[bnb.coin] = bnb
 	}
+	for i := 0; i < len(c.Evm); i++ {
+		if _, ok := cryptoProcessors[c.Evm[i].Coin]; ok {
+			return nil, duplicateChainErr
+		}
+
+		evm, err := newEvmProcessor(log, dbConnPool, invoiceCn, &c.Evm[i], invoiceConf)
+		if err != nil {
+			return nil, err
+		}
+		cryptoProcessors[evm.coin] = evm
+	}
 
 	pp := &PaymentProcessor{
-		dbConnPool:       dbConnPool,
-		invoiceCn:        invoiceCn,
-		newInvoicesCns:   &util.SyncMapTypeSafe[string, chan db.Invoice]{},
-		cryptoProcessors: cryptoProcessors,
-		ctx:              ctx,
-		log:              log,
+		dbConnPool:        dbConnPool,
+		invoiceCn:         invoiceCn,
+		newInvoicesCns:    &util.SyncMapTypeSafe[string, chan dto.InvoiceEvent]{},
+		cryptoProcessors:  cryptoProcessors,
+		rateProvider:      rateProvider,
+		latePaymentWindow: invoiceConf.LatePaymentWindow,
+		ctx:               ctx,
+		log:               log,
 	}
 	if err := pp.load(); err != nil {
 		return nil, err
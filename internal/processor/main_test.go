@@ -0,0 +1,95 @@
+package processor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoinDecimals(t *testing.T) {
+	p := &PaymentProcessor{cryptoProcessors: map[db.CoinType]cryptoProcessor{
+		db.CoinTypeXMR: &baseCryptoProcessor[listener.XMRTx, listener.XMRBlock]{coin: db.CoinTypeXMR},
+		db.CoinTypeBTC: &baseCryptoProcessor[listener.BTCTx, listener.BTCBlock]{coin: db.CoinTypeBTC},
+		db.CoinTypeETH: &baseCryptoProcessor[listener.ETHTx, listener.ETHBlock]{
+			coin: db.CoinTypeETH,
+			tokens: map[db.CoinType]db.Token{
+				db.CoinTypeUSDTERC20: {Coin: db.CoinTypeUSDTERC20, Decimals: 6},
+				db.CoinTypeWBTCERC20: {Coin: db.CoinTypeWBTCERC20, Decimals: 8},
+				"EURC_ERC20":         {Coin: "EURC_ERC20", Decimals: 6},
+			},
+		},
+	}}
+
+	t.Run("Should Return Coin Decimals", func(t *testing.T) {
+		expected := map[db.CoinType]int{
+			db.CoinTypeXMR:       12,
+			db.CoinTypeBTC:       8,
+			db.CoinTypeETH:       18,
+			db.CoinTypeUSDTERC20: 6,
+			db.CoinTypeWBTCERC20: 8,
+			"EURC_ERC20":         6,
+		}
+
+		for coin, decimals := range expected {
+			d, ok := p.coinDecimals(coin)
+			assert.True(t, ok)
+			assert.Equal(t, decimals, d, string(coin))
+		}
+	})
+
+	t.Run("Should Return False (unsupported coin)", func(t *testing.T) {
+		_, ok := p.coinDecimals(db.CoinTypeTON)
+		assert.False(t, ok)
+
+		// The chain isn't configured.
+		_, ok = p.coinDecimals(db.CoinTypeLTC)
+		assert.False(t, ok)
+
+		// The token isn't in the registry.
+		_, ok = p.coinDecimals(db.CoinTypeSHIBERC20)
+		assert.False(t, ok)
+	})
+}
+
+func TestConvertFiatToCoinAmount(t *testing.T) {
+	t.Run("Should Round Up Coin Amount", func(t *testing.T) {
+		assert.Equal(t, big.NewInt(166112960000), convertFiatToCoinAmount(25, 150.5, 12))
+		assert.Equal(t, big.NewInt(16611296), convertFiatToCoinAmount(25, 150.5, 8))
+		assert.Equal(t, big.NewInt(25000000), convertFiatToCoinAmount(25, 1, 6))
+		assert.Equal(t, big.NewInt(333334), convertFiatToCoinAmount(1, 3, 6))
+	})
+}
+
+func TestPublishInvoiceEvent(t *testing.T) {
+	log := zerolog.Nop()
+	p := &PaymentProcessor{ctx: context.Background(), log: &log, newInvoicesCns: &util.SyncMapTypeSafe[string, chan dto.InvoiceEvent]{}}
+
+	t.Run("Should Hand Off The Events In Order", func(t *testing.T) {
+		cn, unsubscribe := p.NewInvoicesChan()
+		defer unsubscribe()
+
+		for i := int64(1); i <= 3; i++ {
+			p.publishInvoiceEvent(&dto.InvoiceEvent{Sequence: i})
+		}
+
+		for i := int64(1); i <= 3; i++ {
+			assert.Equal(t, i, (<-cn).Sequence)
+		}
+	})
+
+	t.Run("Should Not Hand Off The Events After Unsubscribing", func(t *testing.T) {
+		cn, unsubscribe := p.NewInvoicesChan()
+		unsubscribe()
+
+		p.publishInvoiceEvent(&dto.InvoiceEvent{Sequence: 1})
+
+		assert.Equal(t, 0, len(cn))
+	})
+}
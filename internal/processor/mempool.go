@@ -0,0 +1,204 @@
+package processor
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// settlingPaymentTxId returns the tx of the payment which made the sum of the invoice payments accepted by the payment policy,
+// the way it was recorded when the payments arrived. It returns false if the payments don't settle the invoice.
+func (b *baseCryptoProcessor[T, B]) settlingPaymentTxId(invoice *db.Invoice, payments []db.InvoicePayment) (string, bool, error) {
+	paidAmount := big.NewInt(0)
+	for i := 0; i < len(payments); i++ {
+		amount, err := util.PgNumericToBigInt(payments[i].Amount)
+		if err != nil {
+			return "", false, err
+		}
+		paidAmount.Add(paidAmount, amount)
+
+		evaluation, err := b.paymentPolicy(invoice, paidAmount)
+		if err != nil {
+			return "", false, err
+		}
+		if evaluation.accepted {
+			return payments[i].TxID, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// revertMempoolPayment removes the dropped tx from the payments of the locked invoice and re-evaluates the remaining ones.
+// The invoice goes back to PENDING, or PARTIALLY_PAID if other payments remain, unless they are still accepted by the payment policy.
+// The tx of the invoice is then the one of the payment which settles it.
+func (b *baseCryptoProcessor[T, B]) revertMempoolPayment(ctx context.Context, q *db.Queries, invoice *db.Invoice, txId string) (*db.Invoice, error) {
+	if err := q.DeleteInvoicePaymentByInvoiceIdAndTxId(ctx, db.DeleteInvoicePaymentByInvoiceIdAndTxIdParams{InvoiceID: invoice.ID, TxID: txId}); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "DeleteInvoicePaymentByInvoiceIdAndTxId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	total, err := q.SumInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "SumInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	paidAmount, err := util.PgNumericToBigInt(total)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while reading the invoice paid amount.")
+		return nil, err
+	}
+
+	status := db.InvoiceStatusTypePENDING
+	if len(payments) > 0 {
+		status = db.InvoiceStatusTypePARTIALLYPAID
+	}
+
+	revertedInvoice, err := q.RevertInvoiceToPendingById(ctx, db.RevertInvoiceToPendingByIdParams{ID: invoice.ID, Status: status, ActualAmount: total})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "RevertInvoiceToPendingById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+	if len(payments) < 1 {
+		return &revertedInvoice, nil
+	}
+
+	evaluation, err := b.paymentPolicy(&revertedInvoice, paidAmount)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while evaluating the invoice payment.")
+		return nil, err
+	}
+	if !evaluation.accepted {
+		return &revertedInvoice, nil
+	}
+	settlingTxId, ok, err := b.settlingPaymentTxId(&revertedInvoice, payments)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(invoice.ID)).Msg("An error occurred while evaluating the invoice payment.")
+		return nil, err
+	}
+	if !ok {
+		return &revertedInvoice, nil
+	}
+
+	revertedInvoice, err = q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{
+		ID:                   invoice.ID,
+		ActualAmount:         total,
+		TxID:                 pgtype.Text{String: settlingTxId, Valid: true},
+		PaymentOutcome:       evaluation.outcome,
+		PaymentOutcomeAmount: evaluation.outcomeAmount(),
+	})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ConfirmInvoiceStatusMempoolById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	return &revertedInvoice, nil
+}
+
+// dropMempoolPayment reverts every awaiting invoice paid by the dropped tx and records a MEMPOOL_DROPPED event for each of them.
+func (b *baseCryptoProcessor[T, B]) dropMempoolPayment(ctx context.Context, txId string) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	invoices, err := q.FindInvoicesByPaymentTxIdAndLock(ctx, db.FindInvoicesByPaymentTxIdAndLockParams{Coin: b.coin, TxID: txId})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoicesByPaymentTxIdAndLock").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	revertedInvoices := make([]db.Invoice, 0, len(invoices))
+	for i := 0; i < len(invoices); i++ {
+		revertedInvoice, err := b.revertMempoolPayment(ctx, q, &invoices[i], txId)
+		if err != nil {
+			return
+		}
+		if err := createInvoiceEvent(ctx, b.log, q, revertedInvoice, db.InvoiceEventTypeMEMPOOLDROPPED); err != nil {
+			return
+		}
+
+		revertedInvoices = append(revertedInvoices, *revertedInvoice)
+	}
+
+	tx.Commit(ctx)
+
+	for i := 0; i < len(revertedInvoices); i++ {
+		b.log.Warn().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(revertedInvoices[i].ID)).Msgf("Tx %v paying the invoice was dropped from the mempool", txId)
+
+		if value, ok := b.pendingInvoices.Load(revertedInvoices[i].CryptoAddress); ok && value.invoice.Load().ID == revertedInvoices[i].ID {
+			value.invoice.Store(&revertedInvoices[i])
+		}
+		b.broadcastUpdatedInvoice(ctx, &revertedInvoices[i])
+	}
+}
+
+// handleDroppedTx reverts the payments of the dropped tx. A replacement tx is verified again
+// after that, so it is recorded instead if it still pays the invoice address.
+func (b *baseCryptoProcessor[T, B]) handleDroppedTx(ctx context.Context, droppedTx listener.DroppedTx) {
+	b.dropMempoolPayment(ctx, droppedTx.TxId)
+
+	if droppedTx.ReplacedBy == "" {
+		return
+	}
+
+	txs, err := b.daemon.GetTransactions([]string{droppedTx.ReplacedBy})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("method", "GetTransactions").Msg(util.DefaultFailedFetchingDaemonMsg)
+		return
+	}
+	for i := 0; i < len(txs); i++ {
+		b.verifyTxOnMempool(ctx, txs[i])
+	}
+}
+
+// watchInvoiceTxs makes the executor watch the unconfirmed payments of the invoice loaded after a restart.
+// The payments whose txs are no longer known to the daemon are dropped right away.
+func (b *baseCryptoProcessor[T, B]) watchInvoiceTxs(ctx context.Context, invoice db.Invoice) {
+	payments, err := db.New(b.dbConnPool).FindInvoicePaymentsByInvoiceId(ctx, invoice.ID)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoicePaymentsByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	txIds := make([]string, 0, len(payments))
+	for i := 0; i < len(payments); i++ {
+		if payments[i].Confirmations == 0 {
+			txIds = append(txIds, payments[i].TxID)
+		}
+	}
+	if len(txIds) < 1 {
+		return
+	}
+
+	txs, err := b.daemon.GetTransactions(txIds)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("method", "GetTransactions").Msg(util.DefaultFailedFetchingDaemonMsg)
+		return
+	}
+
+	found := make(map[string]bool, len(txs))
+	for i := 0; i < len(txs); i++ {
+		found[txs[i].GetTxId()] = true
+		if txs[i].GetConfirmations() == 0 {
+			b.daemonEx.WatchTx(txs[i])
+		}
+	}
+
+	for i := 0; i < len(txIds); i++ {
+		if !found[txIds[i]] {
+			b.handleDroppedTx(ctx, listener.DroppedTx{TxId: txIds[i]})
+		}
+	}
+}
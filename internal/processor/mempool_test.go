@@ -0,0 +1,40 @@
+package processor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettlingPaymentTxId(t *testing.T) {
+	p := &baseCryptoProcessor[listener.BTCTx, listener.BTCBlock]{paymentPolicy: tolerancePaymentPolicy}
+	invoice := &db.Invoice{RequiredAmount: util.BigIntToPgNumeric(big.NewInt(1000))}
+	newPayment := func(txId string, amount int64) db.InvoicePayment {
+		return db.InvoicePayment{TxID: txId, Amount: util.BigIntToPgNumeric(big.NewInt(amount))}
+	}
+
+	t.Run("Should Return The Payment Which Reached The Required Amount", func(t *testing.T) {
+		txId, ok, err := p.settlingPaymentTxId(invoice, []db.InvoicePayment{
+			newPayment("tx1", 400),
+			newPayment("tx2", 600),
+			newPayment("tx3", 100),
+		})
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "tx2", txId)
+	})
+
+	t.Run("Should Not Return A Tx If The Payments Don't Cover The Required Amount", func(t *testing.T) {
+		txId, ok, err := p.settlingPaymentTxId(invoice, []db.InvoicePayment{
+			newPayment("tx1", 400),
+			newPayment("tx3", 100),
+		})
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.Empty(t, txId)
+	})
+}
@@ -0,0 +1,173 @@
+package processor
+
+import (
+	"errors"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (p *PaymentProcessor) createPaymentRequest(userId string) (*db.PaymentRequest, error) {
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(p.ctx)
+
+	var userIdUUID pgtype.UUID
+	if err := userIdUUID.Scan(userId); err != nil {
+		return nil, err
+	}
+
+	paymentRequest, err := q.CreatePaymentRequest(p.ctx, userIdUUID)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "CreatePaymentRequest").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return nil, err
+	}
+
+	return &paymentRequest, nil
+}
+
+// HandleNewPaymentRequest creates an invoice per requested coin through the coin's crypto processor.
+// If any of them fails, the already created invoices are cancelled.
+func (p *PaymentProcessor) HandleNewPaymentRequest(req *dto.NewPaymentRequest) (*db.PaymentRequest, []db.Invoice, error) {
+	paymentRequest, err := p.createPaymentRequest(req.UserId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invoices := make([]db.Invoice, 0, len(req.Coins))
+	for i := 0; i < len(req.Coins); i++ {
+		invoiceReq := &dto.NewInvoiceRequest{
+			UserId:           req.UserId,
+			Coin:             req.Coins[i].Coin,
+			Amount:           req.Coins[i].Amount,
+			Timeout:          req.Timeout,
+			Confirmations:    req.Confirmations,
+			ExternalId:       req.ExternalId,
+			Description:      req.Description,
+			Metadata:         req.Metadata,
+			PaymentRequestId: util.PgUUIDToString(paymentRequest.ID),
+		}
+		if req.Coins[i].Amount == nil {
+			invoiceReq.FiatAmount = req.FiatAmount
+			invoiceReq.FiatCurrency = req.FiatCurrency
+		}
+
+		invoice, err := p.HandleNewInvoice(invoiceReq)
+		if err != nil {
+			for j := 0; j < len(invoices); j++ {
+				if _, err := p.CancelInvoice(&invoices[j]); err != nil {
+					p.log.Err(err).Str("invoiceId", util.PgUUIDToString(invoices[j].ID)).Msg("An error occurred while cancelling the payment request invoice.")
+				}
+			}
+			p.abandonPaymentRequest(paymentRequest.ID)
+
+			return nil, nil, err
+		}
+
+		invoices = append(invoices, *invoice)
+	}
+
+	return paymentRequest, invoices, nil
+}
+
+// abandonPaymentRequest expires the payment request whose invoices couldn't all be created, so no request without
+// invoices is left pending. A request with an invoice which couldn't be cancelled stays pending until that invoice settles.
+func (p *PaymentProcessor) abandonPaymentRequest(paymentRequestId pgtype.UUID) {
+	q, tx, err := util.InitDbQueriesWithTx(p.ctx, p.dbConnPool)
+	if err != nil {
+		p.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(p.ctx)
+
+	if err := p.expirePaymentRequest(q, paymentRequestId); err != nil {
+		return
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		p.log.Err(err).Str("paymentRequestId", util.PgUUIDToString(paymentRequestId)).Msg(util.DefaultFailedSqlTxCommitMsg)
+	}
+}
+
+// handlePaymentRequestUpdate marks the payment request paid once one of its invoices is confirmed and cancels the others.
+// When none of its invoices can be paid anymore, the request expires. It runs in the tx publishing the invoice event,
+// so a failure leaves the event to be published and the request to be updated again. It returns the cancelled invoices.
+func (p *PaymentProcessor) handlePaymentRequestUpdate(q *db.Queries, event *dto.InvoiceEvent) ([]db.Invoice, error) {
+	if event.Type != db.InvoiceEventTypeSTATUSCHANGED || !event.Invoice.PaymentRequestID.Valid {
+		return nil, nil
+	}
+
+	switch event.Invoice.Status {
+	case db.InvoiceStatusTypeCONFIRMED:
+		return p.settlePaymentRequest(q, &event.Invoice)
+	case db.InvoiceStatusTypeEXPIRED, db.InvoiceStatusTypeCANCELLED:
+		return nil, p.expirePaymentRequest(q, event.Invoice.PaymentRequestID)
+	}
+
+	return nil, nil
+}
+
+func (p *PaymentProcessor) settlePaymentRequest(q *db.Queries, invoice *db.Invoice) ([]db.Invoice, error) {
+	if _, err := q.MarkPaymentRequestPaidById(p.ctx, db.MarkPaymentRequestPaidByIdParams{ID: invoice.PaymentRequestID, PaidInvoiceID: invoice.ID}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Warn().Str("invoiceId", util.PgUUIDToString(invoice.ID)).Str("paymentRequestId", util.PgUUIDToString(invoice.PaymentRequestID)).Msg("The payment request has already been paid by another invoice")
+			return nil, nil
+		}
+
+		p.log.Err(err).Str("queryName", "MarkPaymentRequestPaidById").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	siblings, err := q.FindInvoicesByPaymentRequestId(p.ctx, invoice.PaymentRequestID)
+	if err != nil {
+		p.log.Err(err).Str("queryName", "FindInvoicesByPaymentRequestId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	// Siblings which have already received a payment are left to complete, so the payment isn't lost.
+	cancelledInvoices := make([]db.Invoice, 0)
+	for i := 0; i < len(siblings); i++ {
+		if siblings[i].ID == invoice.ID || siblings[i].Status != db.InvoiceStatusTypePENDING {
+			continue
+		}
+
+		// The status check in the query guards against a tx hitting the mempool concurrently.
+		cancelledInvoice, err := q.CancelInvoiceById(p.ctx, siblings[i].ID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+
+			p.log.Err(err).Str("queryName", "CancelInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+			return nil, err
+		}
+		if err := createInvoiceEvent(p.ctx, p.log, q, &cancelledInvoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+			return nil, err
+		}
+
+		cancelledInvoices = append(cancelledInvoices, cancelledInvoice)
+	}
+
+	return cancelledInvoices, nil
+}
+
+func (p *PaymentProcessor) expirePaymentRequest(q *db.Queries, paymentRequestId pgtype.UUID) error {
+	// No rows means the request is already settled or still has payable invoices.
+	if _, err := q.ExpirePaymentRequestById(p.ctx, paymentRequestId); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		p.log.Err(err).Str("queryName", "ExpirePaymentRequestById").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package processor
+
+import (
+	"math/big"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type paymentEvaluation struct {
+	// accepted is true if the paid amount settles the invoice.
+	accepted bool
+	outcome  db.NullPaymentOutcomeType
+	// difference is the surplus or the shortfall depending on the outcome.
+	difference *big.Int
+}
+
+// outcomeAmount returns the surplus or the shortfall to be stored with the invoice.
+func (e *paymentEvaluation) outcomeAmount() pgtype.Numeric {
+	if !e.outcome.Valid {
+		return pgtype.Numeric{}
+	}
+
+	return util.BigIntToPgNumeric(e.difference)
+}
+
+// paymentPolicy decides whether the paid amount settles the invoice.
+type paymentPolicy func(invoice *db.Invoice, paidAmount *big.Int) (*paymentEvaluation, error)
+
+// toleranceAmount returns the shortfall accepted for the invoice, rounded down to an atomic unit.
+func toleranceAmount(invoice *db.Invoice, requiredAmount *big.Int) (*big.Int, error) {
+	if invoice.ToleranceAmount.Valid {
+		return util.PgNumericToBigInt(invoice.ToleranceAmount)
+	}
+	if !invoice.TolerancePercent.Valid || invoice.TolerancePercent.Float64 <= 0 {
+		return big.NewInt(0), nil
+	}
+
+	tolerance := new(big.Rat).SetFloat64(invoice.TolerancePercent.Float64)
+	if tolerance == nil {
+		return big.NewInt(0), nil
+	}
+	tolerance.Mul(tolerance, new(big.Rat).SetInt(requiredAmount))
+	tolerance.Quo(tolerance, big.NewRat(100, 1))
+
+	return new(big.Int).Quo(tolerance.Num(), tolerance.Denom()), nil
+}
+
+// tolerancePaymentPolicy accepts any overpayment and an underpayment within the invoice tolerance.
+func tolerancePaymentPolicy(invoice *db.Invoice, paidAmount *big.Int) (*paymentEvaluation, error) {
+	requiredAmount, err := util.PgNumericToBigInt(invoice.RequiredAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	difference := new(big.Int).Sub(paidAmount, requiredAmount)
+	switch difference.Sign() {
+	case 0:
+		return &paymentEvaluation{accepted: true, difference: difference}, nil
+	case 1:
+		return &paymentEvaluation{
+			accepted:   true,
+			outcome:    db.NullPaymentOutcomeType{PaymentOutcomeType: db.PaymentOutcomeTypeOVERPAID, Valid: true},
+			difference: difference,
+		}, nil
+	}
+
+	tolerance, err := toleranceAmount(invoice, requiredAmount)
+	if err != nil {
+		return nil, err
+	}
+	shortfall := difference.Neg(difference)
+
+	return &paymentEvaluation{
+		accepted:   shortfall.Cmp(tolerance) <= 0,
+		outcome:    db.NullPaymentOutcomeType{PaymentOutcomeType: db.PaymentOutcomeTypeUNDERPAID, Valid: true},
+		difference: shortfall,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTolerancePaymentPolicy(t *testing.T) {
+	newInvoice := func(tolerancePercent pgtype.Float8, toleranceAmount pgtype.Numeric) *db.Invoice {
+		return &db.Invoice{
+			RequiredAmount:   util.BigIntToPgNumeric(big.NewInt(1000)),
+			TolerancePercent: tolerancePercent,
+			ToleranceAmount:  toleranceAmount,
+		}
+	}
+
+	t.Run("Should Accept Exact Payment", func(t *testing.T) {
+		evaluation, err := tolerancePaymentPolicy(newInvoice(pgtype.Float8{}, pgtype.Numeric{}), big.NewInt(1000))
+		assert.NoError(t, err)
+		assert.True(t, evaluation.accepted)
+		assert.False(t, evaluation.outcome.Valid)
+	})
+
+	t.Run("Should Accept Overpayment", func(t *testing.T) {
+		evaluation, err := tolerancePaymentPolicy(newInvoice(pgtype.Float8{}, pgtype.Numeric{}), big.NewInt(1200))
+		assert.NoError(t, err)
+		assert.True(t, evaluation.accepted)
+		assert.Equal(t, db.PaymentOutcomeTypeOVERPAID, evaluation.outcome.PaymentOutcomeType)
+		assert.Equal(t, big.NewInt(200), evaluation.difference)
+	})
+
+	t.Run("Should Reject Underpayment (no tolerance)", func(t *testing.T) {
+		evaluation, err := tolerancePaymentPolicy(newInvoice(pgtype.Float8{}, pgtype.Numeric{}), big.NewInt(999))
+		assert.NoError(t, err)
+		assert.False(t, evaluation.accepted)
+		assert.Equal(t, db.PaymentOutcomeTypeUNDERPAID, evaluation.outcome.PaymentOutcomeType)
+		assert.Equal(t, big.NewInt(1), evaluation.difference)
+	})
+
+	t.Run("Should Accept Underpayment Within Percent Tolerance", func(t *testing.T) {
+		invoice := newInvoice(pgtype.Float8{Float64: 0.5, Valid: true}, pgtype.Numeric{})
+
+		evaluation, err := tolerancePaymentPolicy(invoice, big.NewInt(995))
+		assert.NoError(t, err)
+		assert.True(t, evaluation.accepted)
+		assert.Equal(t, db.PaymentOutcomeTypeUNDERPAID, evaluation.outcome.PaymentOutcomeType)
+		assert.Equal(t, big.NewInt(5), evaluation.difference)
+
+		evaluation, err = tolerancePaymentPolicy(invoice, big.NewInt(994))
+		assert.NoError(t, err)
+		assert.False(t, evaluation.accepted)
+	})
+
+	t.Run("Should Prefer Absolute Tolerance", func(t *testing.T) {
+		invoice := newInvoice(pgtype.Float8{Float64: 50, Valid: true}, util.BigIntToPgNumeric(big.NewInt(10)))
+
+		evaluation, err := tolerancePaymentPolicy(invoice, big.NewInt(990))
+		assert.NoError(t, err)
+		assert.True(t, evaluation.accepted)
+
+		evaluation, err = tolerancePaymentPolicy(invoice, big.NewInt(989))
+		assert.NoError(t, err)
+		assert.False(t, evaluation.accepted)
+	})
+}
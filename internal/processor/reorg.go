@@ -0,0 +1,142 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reorg_expiry_extension is the minimum time a reverted invoice is given to get its txs confirmed again.
+const reorg_expiry_extension time.Duration = 1 * time.Hour
+
+func dbBlockHashesToListenerBlockHashes(hashes []db.CryptoBlockHash) []listener.BlockHash {
+	blockHashes := make([]listener.BlockHash, len(hashes))
+	for i := 0; i < len(hashes); i++ {
+		blockHashes[i] = listener.BlockHash{Height: uint64(hashes[i].Height), Hash: hashes[i].Hash}
+	}
+
+	return blockHashes
+}
+
+// persistBlockHashes replaces the stored block hashes of the coin with the ones recently synced by the executor.
+func (b *baseCryptoProcessor[T, B]) persistBlockHashes(ctx context.Context, q *db.Queries) error {
+	blockHashes := b.daemonEx.RecentBlockHashes()
+
+	heights := make([]int64, len(blockHashes))
+	hashes := make([]string, len(blockHashes))
+	for i := 0; i < len(blockHashes); i++ {
+		heights[i] = int64(blockHashes[i].Height)
+		hashes[i] = blockHashes[i].Hash
+	}
+
+	if err := q.DeleteBlockHashesByCoin(ctx, b.coin); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "DeleteBlockHashesByCoin").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+	if err := q.CreateBlockHashes(ctx, db.CreateBlockHashesParams{Coin: string(b.coin), Heights: heights, Hashes: hashes}); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "CreateBlockHashes").Msg(util.DefaultFailedSqlQueryMsg)
+		return err
+	}
+
+	return nil
+}
+
+// revertConfirmedInvoices moves the CONFIRMED invoices back to PENDING_MEMPOOL and occupies their addresses again,
+// unless another invoice holds them by now.
+// A payment request paid by a reverted invoice goes back to PENDING until the invoice is confirmed again. Its cancelled invoices stay cancelled.
+func (b *baseCryptoProcessor[T, B]) revertConfirmedInvoices(ctx context.Context, q *db.Queries, invoices []db.Invoice) ([]db.Invoice, error) {
+	expiresAt := pgtype.Timestamptz{Time: time.Now().UTC().Add(reorg_expiry_extension), Valid: true}
+
+	revertedInvoices := make([]db.Invoice, 0, len(invoices))
+	for i := 0; i < len(invoices); i++ {
+		if invoices[i].Status != db.InvoiceStatusTypeCONFIRMED {
+			continue
+		}
+
+		revertedInvoice, err := q.RevertConfirmedInvoiceById(ctx, db.RevertConfirmedInvoiceByIdParams{ID: invoices[i].ID, ExpiresAt: expiresAt})
+		if err != nil {
+			b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "RevertConfirmedInvoiceById").Msg(util.DefaultFailedSqlQueryMsg)
+			return nil, err
+		}
+		// The address released on confirmation may have been handed to another invoice meanwhile.
+		if _, err := q.OccupyCryptoAddressNotHeldByOtherInvoice(ctx, db.OccupyCryptoAddressNotHeldByOtherInvoiceParams{Address: revertedInvoice.CryptoAddress, InvoiceID: revertedInvoice.ID}); err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "OccupyCryptoAddressNotHeldByOtherInvoice").Msg(util.DefaultFailedSqlQueryMsg)
+				return nil, err
+			}
+
+			b.log.Warn().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(revertedInvoice.ID)).Msg("The address of the reverted invoice is held by another invoice")
+		}
+		if revertedInvoice.PaymentRequestID.Valid {
+			if err := q.RevertPaymentRequestPaidByInvoiceId(ctx, revertedInvoice.ID); err != nil {
+				b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "RevertPaymentRequestPaidByInvoiceId").Msg(util.DefaultFailedSqlQueryMsg)
+				return nil, err
+			}
+		}
+		if err := createInvoiceEvent(ctx, b.log, q, &revertedInvoice, db.InvoiceEventTypeSTATUSCHANGED); err != nil {
+			return nil, err
+		}
+
+		revertedInvoices = append(revertedInvoices, revertedInvoice)
+	}
+
+	return revertedInvoices, nil
+}
+
+// handleReorg re-evaluates the invoices paid by txs from the orphaned blocks. Their payments lose the confirmations
+// and the confirmed ones go back to PENDING_MEMPOOL until the txs are confirmed again on the new chain.
+// The reset txs are watched again, so the ones which don't make it back into the mempool revert their payments.
+func (b *baseCryptoProcessor[T, B]) handleReorg(ctx context.Context, reorg listener.Reorg) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, b.dbConnPool)
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	forkHeight := int64(reorg.ForkHeight)
+
+	invoices, err := q.FindInvoicesWithPaymentsAboveBlockHeightAndLock(ctx, db.FindInvoicesWithPaymentsAboveBlockHeightAndLockParams{Coin: b.coin, BlockHeight: forkHeight})
+	if err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "FindInvoicesWithPaymentsAboveBlockHeightAndLock").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	if err := q.ResetInvoicePaymentsAboveBlockHeight(ctx, db.ResetInvoicePaymentsAboveBlockHeightParams{Coin: b.coin, BlockHeight: forkHeight}); err != nil {
+		b.log.Err(err).Str("coin", string(b.coin)).Str("queryName", "ResetInvoicePaymentsAboveBlockHeight").Msg(util.DefaultFailedSqlQueryMsg)
+		return
+	}
+
+	revertedInvoices, err := b.revertConfirmedInvoices(ctx, q, invoices)
+	if err != nil {
+		return
+	}
+
+	tx.Commit(ctx)
+
+	for i := 0; i < len(revertedInvoices); i++ {
+		b.log.Warn().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(revertedInvoices[i].ID)).Msg("The confirmed invoice was reverted by a chain reorganisation")
+
+		if value, ok := b.pendingInvoices.Load(revertedInvoices[i].CryptoAddress); ok && value.invoice.Load().ID != revertedInvoices[i].ID {
+			b.log.Warn().Str("coin", string(b.coin)).Str("invoiceId", util.PgUUIDToString(revertedInvoices[i].ID)).Msg("The address of the reverted invoice is used by another pending invoice")
+			continue
+		}
+
+		b.handleInvoice(ctx, revertedInvoices[i])
+		b.broadcastUpdatedInvoice(ctx, &revertedInvoices[i])
+	}
+	// The reverted invoices are watched again by handleInvoice.
+	for i := 0; i < len(invoices); i++ {
+		if invoices[i].Status != db.InvoiceStatusTypeCONFIRMED {
+			b.watchInvoiceTxs(ctx, invoices[i])
+		}
+	}
+
+	b.verifyTxOnNewBlock(ctx)
+}
@@ -0,0 +1,118 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+)
+
+const (
+	token_decimals_max_attempts  int           = 5
+	token_decimals_retry_backoff time.Duration = 1 * time.Second
+)
+
+// registerTokens upserts the tokens declared in the config into the token registry.
+func registerTokens(ctx context.Context, log *zerolog.Logger, dbConnPool *pgxpool.Pool, tokens []dto.TokenConfig) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	q, tx, err := util.InitDbQueriesWithTx(ctx, dbConnPool)
+	if err != nil {
+		log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for i := 0; i < len(tokens); i++ {
+		_, err := q.UpsertToken(ctx, db.UpsertTokenParams{
+			Coin:            tokens[i].Coin,
+			Symbol:          tokens[i].Symbol,
+			Chain:           tokens[i].Chain,
+			ChainID:         int64(tokens[i].ChainId),
+			ContractAddress: common.HexToAddress(tokens[i].ContractAddress).Hex(),
+			Decimals:        tokens[i].Decimals,
+			Enabled:         tokens[i].Enabled,
+		})
+		if err != nil {
+			log.Err(err).Str("coin", string(tokens[i].Coin)).Str("queryName", "UpsertToken").Msg(util.DefaultFailedSqlQueryMsg)
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// getTokenDecimals calls decimals() of the token contract. The failed calls are retried with a doubling backoff,
+// except the ones of a contract which returned invalid decimals.
+func getTokenDecimals(log *zerolog.Logger, token *db.Token, daemon listener.TokenDecimalsGetter, retryBackoff time.Duration) (uint8, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		var decimals uint8
+		decimals, err = daemon.GetTokenDecimals(token.ContractAddress)
+		if err == nil || errors.Is(err, listener.InvalidTokenDecimalsErr) || attempt >= token_decimals_max_attempts {
+			return decimals, err
+		}
+
+		log.Warn().Err(err).Str("coin", string(token.Chain)).Str("token", string(token.Coin)).Msgf("Failed fetching the token decimals. Retrying in %v.", retryBackoff)
+		time.Sleep(retryBackoff)
+		retryBackoff *= 2
+	}
+}
+
+// verifyTokenDecimals drops the tokens whose decimals don't match decimals() of their contract,
+// as the amounts of their invoices would be off by orders of magnitude. It fails if the decimals of a token
+// can't be fetched, so the token isn't silently dropped because of an unavailable daemon.
+func verifyTokenDecimals(log *zerolog.Logger, tokens []db.Token, daemon listener.TokenDecimalsGetter, retryBackoff time.Duration) (map[db.CoinType]db.Token, error) {
+	verified := make(map[db.CoinType]db.Token, len(tokens))
+
+	for _, token := range tokens {
+		decimals, err := getTokenDecimals(log, &token, daemon, retryBackoff)
+		if errors.Is(err, listener.InvalidTokenDecimalsErr) {
+			log.Error().Str("coin", string(token.Chain)).Str("token", string(token.Coin)).Msg("The contract doesn't report valid decimals. The token is disabled.")
+			continue
+		}
+		if err != nil {
+			log.Err(err).Str("coin", string(token.Chain)).Str("token", string(token.Coin)).Str("method", "GetTokenDecimals").Msg(util.DefaultFailedFetchingDaemonMsg)
+			return nil, err
+		}
+		if int32(decimals) != token.Decimals {
+			log.Error().Str("coin", string(token.Chain)).Str("token", string(token.Coin)).Msgf("The contract reports %v decimals instead of %v. The token is disabled.", decimals, token.Decimals)
+			continue
+		}
+
+		// The addresses of the txs are compared in the checksummed form.
+		token.ContractAddress = common.HexToAddress(token.ContractAddress).Hex()
+		verified[token.Coin] = token
+	}
+
+	return verified, nil
+}
+
+// loadTokensETHCompatible returns the enabled tokens of the chain with the given id.
+func loadTokensETHCompatible(ctx context.Context, log *zerolog.Logger, dbConnPool *pgxpool.Pool, chain db.CoinType, chainId uint64, daemon listener.TokenDecimalsGetter) (map[db.CoinType]db.Token, error) {
+	q, tx, err := util.InitDbQueriesWithTx(ctx, dbConnPool)
+	if err != nil {
+		log.Err(err).Str("coin", string(chain)).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	tokens, err := q.FindEnabledTokensByChainAndChainId(ctx, db.FindEnabledTokensByChainAndChainIdParams{Chain: chain, ChainID: int64(chainId)})
+	if err != nil {
+		log.Err(err).Str("coin", string(chain)).Str("queryName", "FindEnabledTokensByChainAndChainId").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	tx.Commit(ctx)
+
+	return verifyTokenDecimals(log, tokens, daemon, token_decimals_retry_backoff)
+}
@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTokenDecimalsGetter map[string]uint8
+
+func (g testTokenDecimalsGetter) GetTokenDecimals(contractAddress string) (uint8, error) {
+	decimals, ok := g[contractAddress]
+	if !ok {
+		return 0, listener.InvalidTokenDecimalsErr
+	}
+
+	return decimals, nil
+}
+
+// testFlakyTokenDecimalsGetter fails the first failures calls.
+type testFlakyTokenDecimalsGetter struct {
+	failures int
+	calls    int
+	decimals uint8
+}
+
+func (g *testFlakyTokenDecimalsGetter) GetTokenDecimals(contractAddress string) (uint8, error) {
+	g.calls++
+	if g.calls <= g.failures {
+		return 0, errors.New("connection refused")
+	}
+
+	return g.decimals, nil
+}
+
+func TestVerifyTokenDecimals(t *testing.T) {
+	usdt := db.Token{Coin: db.CoinTypeUSDTERC20, Chain: db.CoinTypeETH, ContractAddress: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6}
+	dai := db.Token{Coin: db.CoinTypeDAIERC20, Chain: db.CoinTypeETH, ContractAddress: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 6}
+	eurc := db.Token{Coin: "EURC_ERC20", Chain: db.CoinTypeETH, ContractAddress: "0x08210f9170f89ab7658f0b5e3ff39b0e03c594d4", Decimals: 6}
+
+	daemon := testTokenDecimalsGetter{
+		usdt.ContractAddress: 6,
+		dai.ContractAddress:  18,
+		eurc.ContractAddress: 6,
+	}
+
+	t.Run("Should Keep Only The Tokens With Matching Decimals", func(t *testing.T) {
+		tokens, err := verifyTokenDecimals(&zerolog.Logger{}, []db.Token{usdt, dai, eurc}, daemon, time.Millisecond)
+
+		assert.NoError(t, err)
+		assert.Len(t, tokens, 2)
+		assert.Equal(t, usdt, tokens[db.CoinTypeUSDTERC20])
+		assert.NotContains(t, tokens, db.CoinTypeDAIERC20)
+		// The contract address is checksummed.
+		assert.Equal(t, "0x08210F9170F89Ab7658F0B5E3fF39b0E03C594D4", tokens["EURC_ERC20"].ContractAddress)
+	})
+
+	t.Run("Should Drop The Token If The Contract Doesn't Report Valid Decimals", func(t *testing.T) {
+		tokens, err := verifyTokenDecimals(&zerolog.Logger{}, []db.Token{usdt}, testTokenDecimalsGetter{}, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Empty(t, tokens)
+	})
+
+	t.Run("Should Retry Fetching The Decimals", func(t *testing.T) {
+		getter := &testFlakyTokenDecimalsGetter{failures: token_decimals_max_attempts - 1, decimals: 6}
+
+		tokens, err := verifyTokenDecimals(&zerolog.Logger{}, []db.Token{usdt}, getter, time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, usdt, tokens[db.CoinTypeUSDTERC20])
+		assert.Equal(t, token_decimals_max_attempts, getter.calls)
+	})
+
+	t.Run("Should Fail If The Decimals Can't Be Fetched", func(t *testing.T) {
+		getter := &testFlakyTokenDecimalsGetter{failures: token_decimals_max_attempts, decimals: 6}
+
+		_, err := verifyTokenDecimals(&zerolog.Logger{}, []db.Token{usdt}, getter, time.Millisecond)
+		assert.Error(t, err)
+		assert.Equal(t, token_decimals_max_attempts, getter.calls)
+	})
+}
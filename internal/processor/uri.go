@@ -0,0 +1,95 @@
+package processor
+
+import (
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+)
+
+var (
+	uriSchemes map[db.CoinType]string = map[db.CoinType]string{
+		db.CoinTypeXMR: "monero",
+		db.CoinTypeBTC: "bitcoin",
+		db.CoinTypeLTC: "litecoin",
+	}
+
+	chainIdsETHCompatible map[listener.NetworkType]uint64 = map[listener.NetworkType]uint64{
+		listener.MainnetETH: 1,
+		listener.GoerliETH:  5,
+		listener.SepoliaETH: 11155111,
+		listener.PrivateETH: 1337,
+		listener.MainnetBNB: 56,
+		listener.TestnetBNB: 97,
+		listener.PrivateBNB: 714,
+	}
+)
+
+// formatAtomicAmount converts the amount in atomic units to a decimal string without trailing zeros.
+func formatAtomicAmount(amount *big.Int, decimals int) string {
+	if decimals <= 0 {
+		return amount.String()
+	}
+
+	digits := amount.String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	integer, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fraction == "" {
+		return integer
+	}
+
+	return integer + "." + fraction
+}
+
+// newPaymentUriETHCompatible builds an EIP-681 URI paying the amount of the native coin or the token to the address.
+func newPaymentUriETHCompatible(address string, amount *big.Int, token *db.Token, chainId uint64) string {
+	if token != nil {
+		return fmt.Sprintf("ethereum:%v@%v/transfer?address=%v&uint256=%v", token.ContractAddress, chainId, address, amount)
+	}
+
+	return fmt.Sprintf("ethereum:%v@%v?value=%v", address, chainId, amount)
+}
+
+// newPaymentUri builds a wallet URI for the invoice: BIP21 for BTC/LTC, monero: for XMR and EIP-681 for ETH/BNB and their tokens.
+// The token is set if the invoice is paid in one of them.
+func newPaymentUri(invoice *db.Invoice, token *db.Token, network listener.NetworkType) (string, error) {
+	amount, err := util.PgNumericToBigInt(invoice.RequiredAmount)
+	if err != nil {
+		return "", err
+	}
+
+	if token != nil || invoice.Coin == db.CoinTypeETH || invoice.Coin == db.CoinTypeBNB {
+		chainId, ok := chainIdsETHCompatible[network]
+		if !ok {
+			return "", util.InvalidNetworkTypeErr
+		}
+
+		return newPaymentUriETHCompatible(invoice.CryptoAddress, amount, token, chainId), nil
+	}
+
+	scheme, ok := uriSchemes[invoice.Coin]
+	if !ok {
+		return "", unimplementedError
+	}
+
+	switch invoice.Coin {
+	case db.CoinTypeXMR:
+		return fmt.Sprintf("%v:%v?tx_amount=%v", scheme, invoice.CryptoAddress, formatAtomicAmount(amount, coinDecimals[invoice.Coin])), nil
+	default:
+		params := url.Values{}
+		params.Set("amount", formatAtomicAmount(amount, coinDecimals[invoice.Coin]))
+		if invoice.Description.Valid && invoice.Description.String != "" {
+			params.Set("message", invoice.Description.String)
+		}
+
+		// BIP21 expects spaces to be percent-encoded.
+		return fmt.Sprintf("%v:%v?%v", scheme, invoice.CryptoAddress, strings.ReplaceAll(params.Encode(), "+", "%20")), nil
+	}
+}
@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAtomicAmount(t *testing.T) {
+	t.Run("Should Properly Format The Amount", func(t *testing.T) {
+		assert.Equal(t, "0.001", formatAtomicAmount(big.NewInt(100000), 8))
+		assert.Equal(t, "1", formatAtomicAmount(big.NewInt(100000000), 8))
+		assert.Equal(t, "12.5", formatAtomicAmount(big.NewInt(1250000000), 8))
+		assert.Equal(t, "0.000000000001", formatAtomicAmount(big.NewInt(1), 12))
+		assert.Equal(t, "42", formatAtomicAmount(big.NewInt(42), 0))
+	})
+}
+
+func TestNewPaymentUri(t *testing.T) {
+	newInvoice := func(coin db.CoinType, address string, amount int64) *db.Invoice {
+		return &db.Invoice{Coin: coin, CryptoAddress: address, RequiredAmount: util.BigIntToPgNumeric(big.NewInt(amount))}
+	}
+
+	t.Run("Should Return BIP21 URI", func(t *testing.T) {
+		invoice := newInvoice(db.CoinTypeBTC, "bc1qaddr", 150000)
+		invoice.Description = pgtype.Text{String: "Order 42", Valid: true}
+
+		uri, err := newPaymentUri(invoice, nil, listener.MainnetBTC)
+		assert.NoError(t, err)
+		assert.Equal(t, "bitcoin:bc1qaddr?amount=0.0015&message=Order%2042", uri)
+
+		uri, err = newPaymentUri(newInvoice(db.CoinTypeLTC, "ltc1qaddr", 100000000), nil, listener.MainnetLTC)
+		assert.NoError(t, err)
+		assert.Equal(t, "litecoin:ltc1qaddr?amount=1", uri)
+	})
+
+	t.Run("Should Return Monero URI", func(t *testing.T) {
+		uri, err := newPaymentUri(newInvoice(db.CoinTypeXMR, "4addr", 500000000000), nil, listener.MainnetXMR)
+		assert.NoError(t, err)
+		assert.Equal(t, "monero:4addr?tx_amount=0.5", uri)
+	})
+
+	t.Run("Should Return EIP-681 URI", func(t *testing.T) {
+		uri, err := newPaymentUri(newInvoice(db.CoinTypeETH, "0xaddr", 1000), nil, listener.SepoliaETH)
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0xaddr@11155111?value=1000", uri)
+
+		uri, err = newPaymentUri(newInvoice(db.CoinTypeUSDTERC20, "0xaddr", 2500000), &db.Token{ContractAddress: "0xdAC17F958D2ee523a2206206994597C13D831ec7"}, listener.MainnetETH)
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0xdAC17F958D2ee523a2206206994597C13D831ec7@1/transfer?address=0xaddr&uint256=2500000", uri)
+
+		uri, err = newPaymentUri(newInvoice(db.CoinTypeUSDCBEP20, "0xaddr", 1), &db.Token{ContractAddress: "0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d"}, listener.MainnetBNB)
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d@56/transfer?address=0xaddr&uint256=1", uri)
+
+		uri, err = newPaymentUri(newInvoice("EURC_ERC20", "0xaddr", 1), &db.Token{ContractAddress: "0x08210F9170F89Ab7658F0B5E3fF39b0E03C594D4"}, listener.SepoliaETH)
+		assert.NoError(t, err)
+		assert.Equal(t, "ethereum:0x08210F9170F89Ab7658F0B5E3fF39b0E03C594D4@11155111/transfer?address=0xaddr&uint256=1", uri)
+	})
+
+	t.Run("Should Return Error (invalid network)", func(t *testing.T) {
+		_, err := newPaymentUri(newInvoice(db.CoinTypeETH, "0xaddr", 1), nil, listener.MainnetBTC)
+		assert.ErrorIs(t, err, util.InvalidNetworkTypeErr)
+	})
+}
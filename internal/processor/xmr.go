@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"errors"
+	"math/big"
 	"net/url"
 
 	"github.com/chekist32/go-monero/daemon"
@@ -18,53 +19,53 @@ type xmrProcessor struct {
 	baseCryptoProcessor[listener.XMRTx, listener.XMRBlock]
 }
 
-func verifyXMRTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.XMRTx]) (float64, error) {
+func verifyXMRTxHandler(ctx context.Context, q *db.Queries, data *verifyTxHandlerData[listener.XMRTx]) (*big.Int, error) {
 	cryptoData, err := q.FindCryptoDataByUserId(ctx, data.invoice.UserID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	xmrKeys, err := q.FindKeysXMRCryptoDataById(ctx, cryptoData.XmrID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	privView, err := utils.NewPrivateKey(xmrKeys.PrivViewKey)
 	if err != nil {
-		return 0, errors.New("error occurred while creating the XMR private view key")
+		return nil, errors.New("error occurred while creating the XMR private view key")
 	}
 
 	addr, err := utils.NewAddress(data.invoice.CryptoAddress)
 	if err != nil {
-		return 0, errors.New("error occurred while generating a new XMR subaddress")
+		return nil, errors.New("error occurred while generating a new XMR subaddress")
 	}
 	pubSpend := addr.PublicSpendKey()
 
 	txPub, err := utils.GetTxPublicKeyFromExtra(data.tx.TxInfo.Extra)
 	if err != nil {
-		return 0, errors.New("error occurred while extracting the tx public key from the extra field")
+		return nil, errors.New("error occurred while extracting the tx public key from the extra field")
 	}
 
-	var amount uint64 = 0
+	amount := new(big.Int)
 	for i := 0; i < len(data.tx.TxInfo.Vout); i++ {
 		out := &data.tx.TxInfo.Vout[i]
 		ecdh := &data.tx.TxInfo.RctSignatures.EcdhInfo[i]
 
 		outKey, err := utils.NewPublicKey(out.Target.TaggedKey.Key)
 		if err != nil {
-			return 0, errors.New("error occurred while creating the XMR tx outKey")
+			return nil, errors.New("error occurred while creating the XMR tx outKey")
 		}
 
 		res, am, err := utils.DecryptOutputPublicSpendKey(pubSpend, uint32(i), outKey, ecdh.Amount, txPub, privView)
 		if err != nil {
-			return 0, errors.New("error occurred while decrypting the XMR tx output")
+			return nil, errors.New("error occurred while decrypting the XMR tx output")
 		}
 		if res {
-			amount += am
+			amount.Add(amount, new(big.Int).SetUint64(am))
 		}
 	}
 
-	return utils.XMRToFloat64(amount), nil
+	return amount, nil
 }
 
 func generateNextXMRAddressHandler(ctx context.Context, q *db.Queries, data *generateNextAddressHandlerData) (db.CryptoAddress, error) {
@@ -119,7 +120,7 @@ func generateNextXMRAddressHandler(ctx context.Context, q *db.Queries, data *gen
 	return addr, nil
 }
 
-func newXmrProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig) (*xmrProcessor, error) {
+func newXmrProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn chan<- db.Invoice, c *dto.DaemonsConfig, invoiceConf *dto.InvoiceConfig) (*xmrProcessor, error) {
 	u, err := url.Parse(c.Xmr.Url)
 	if err != nil {
 		return nil, err
@@ -129,10 +130,10 @@ func newXmrProcessor(log *zerolog.Logger, dbConnPool *pgxpool.Pool, invoiceCn ch
 		log,
 		dbConnPool,
 		invoiceCn,
+		invoiceConf,
 		listener.NewSharedXMRDaemonRpcClient(daemon.NewDaemonRpcClient(daemon.NewRpcConnection(u, c.Xmr.User, c.Xmr.Pass))),
 		verifyXMRTxHandler,
 		generateNextXMRAddressHandler,
-		nil,
 	)
 	if err != nil {
 		return nil, err
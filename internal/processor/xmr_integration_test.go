@@ -13,6 +13,7 @@ import (
 	"github.com/chekist32/go-monero/daemon"
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/listener"
+	"github.com/chekist32/goipay/internal/util"
 	"github.com/chekist32/goipay/test"
 	test_db "github.com/chekist32/goipay/test/db"
 	"github.com/jackc/pgx/v5"
@@ -133,7 +134,7 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeXMR,
 				CryptoAddress:         "74xhb5sXRsnDZv8RKFEv7LAMfUq5AmGEEB77SVvsUJf8bLvFMSEfc8YYyJHF6xNNnjAZQmgqZp76AjT8bD6qKkLZLeR42oi",
-				RequiredAmount:        0.00101,
+				RequiredAmount:        newPgNumericOrFatal("1010000000"),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -153,7 +154,7 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, expectedInvoice.RequiredAmount, amount)
+			assert.Equal(t, util.PgNumericToString(expectedInvoice.RequiredAmount), amount.String())
 		})
 	})
 
@@ -168,7 +169,7 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 				UserID:                userId,
 				Coin:                  db.CoinTypeXMR,
 				CryptoAddress:         "74xhb5sXRsnDZv8RKFEv7LAMfUq5AmGEEB77SVvsUJf8bLvFMSEfc8YYyJHF6xNNnjAZQmgqZp76AjT8bD6qKkLZLeR42oi",
-				RequiredAmount:        0.00101,
+				RequiredAmount:        newPgNumericOrFatal("1010000000"),
 				ExpiresAt:             pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
 				ConfirmationsRequired: 0,
 			})
@@ -188,7 +189,7 @@ func TestVerifyXMRTxHandler(t *testing.T) {
 
 			// Assert
 			assert.NoError(t, err)
-			assert.Equal(t, float64(0), amount)
+			assert.Equal(t, 0, amount.Sign())
 		})
 	})
 }
@@ -0,0 +1,76 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+type Format uint8
+
+const (
+	PNG Format = iota
+	SVG
+)
+
+type Level = qrcode.RecoveryLevel
+
+const (
+	LowLevel      Level = qrcode.Low
+	MediumLevel   Level = qrcode.Medium
+	QuartileLevel Level = qrcode.High
+	HighLevel     Level = qrcode.Highest
+)
+
+var (
+	UnsupportedFormatErr error = errors.New("unsupported qr code format")
+)
+
+// ContentType returns the MIME type of the rendered image.
+func ContentType(format Format) string {
+	switch format {
+	case SVG:
+		return "image/svg+xml"
+	default:
+		return "image/png"
+	}
+}
+
+// Render encodes the content as a QR code image of size x size pixels (including the quiet zone).
+func Render(content string, format Format, size int, level Level) ([]byte, error) {
+	code, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case PNG:
+		return code.PNG(size)
+	case SVG:
+		return renderSVG(code.Bitmap(), size), nil
+	default:
+		return nil, UnsupportedFormatErr
+	}
+}
+
+// renderSVG draws one unit square per dark module and lets the viewBox scale them to the requested size.
+func renderSVG(bitmap [][]bool, size int) []byte {
+	modules := len(bitmap)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&b, `<rect width="%v" height="%v" fill="#ffffff"/>`, modules, modules)
+	b.WriteString(`<path fill="#000000" d="`)
+	for y := 0; y < modules; y++ {
+		for x := 0; x < len(bitmap[y]); x++ {
+			if bitmap[y][x] {
+				fmt.Fprintf(&b, "M%v %vh1v1h-1z", x, y)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+
+	return []byte(b.String())
+}
@@ -0,0 +1,39 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	content := "bitcoin:bc1qaddr?amount=0.0015"
+
+	t.Run("Should Render PNG", func(t *testing.T) {
+		data, err := Render(content, PNG, 256, MediumLevel)
+		assert.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(data))
+		assert.NoError(t, err)
+		assert.Equal(t, 256, img.Bounds().Dx())
+		assert.Equal(t, 256, img.Bounds().Dy())
+	})
+
+	t.Run("Should Render SVG", func(t *testing.T) {
+		data, err := Render(content, SVG, 300, HighLevel)
+		assert.NoError(t, err)
+
+		svg := string(data)
+		assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="300" height="300"`))
+		assert.True(t, strings.HasSuffix(svg, "</svg>"))
+		assert.Contains(t, svg, "h1v1h-1z")
+	})
+
+	t.Run("Should Return Error (unsupported format)", func(t *testing.T) {
+		_, err := Render(content, Format(255), 256, MediumLevel)
+		assert.ErrorIs(t, err, UnsupportedFormatErr)
+	})
+}
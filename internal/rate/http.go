@@ -0,0 +1,84 @@
+package rate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+)
+
+const (
+	http_rate_request_timeout time.Duration = 10 * time.Second
+
+	CoinPlaceholder     string = "{coin}"
+	CurrencyPlaceholder string = "{currency}"
+)
+
+type httpRateResponse struct {
+	Rate float64 `json:"rate"`
+}
+
+// HttpRateProvider fetches rates from an HTTP endpoint.
+// The url may contain {coin} and {currency} placeholders, the response must be a JSON object like {"rate": 150.5}.
+type HttpRateProvider struct {
+	urlTemplate string
+	source      string
+	client      *http.Client
+}
+
+func (p *HttpRateProvider) GetRate(ctx context.Context, coin db.CoinType, currency string) (*Rate, error) {
+	reqUrl := strings.NewReplacer(
+		CoinPlaceholder, url.PathEscape(string(coin)),
+		CurrencyPlaceholder, url.PathEscape(NormalizeCurrency(currency)),
+	).Replace(p.urlTemplate)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, UnsupportedPairErr
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status code %v", res.StatusCode)
+	}
+
+	var body httpRateResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if body.Rate <= 0 {
+		return nil, InvalidRateErr
+	}
+
+	return &Rate{Value: body.Rate, Source: p.source}, nil
+}
+
+func NewHttpRateProvider(urlTemplate string) (*HttpRateProvider, error) {
+	u, err := url.Parse(urlTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid rate provider url %v", urlTemplate)
+	}
+
+	return &HttpRateProvider{
+		urlTemplate: urlTemplate,
+		source:      u.Host,
+		client:      &http.Client{Timeout: http_rate_request_timeout},
+	}, nil
+}
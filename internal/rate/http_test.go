@@ -0,0 +1,54 @@
+package rate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpRateProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rates/XMR/USD":
+			w.Write([]byte(`{"rate": 150.5}`))
+		case "/rates/XMR/EUR":
+			w.Write([]byte(`{"rate": 0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p, err := NewHttpRateProvider(server.URL + "/rates/" + CoinPlaceholder + "/" + CurrencyPlaceholder)
+	assert.NoError(t, err)
+
+	t.Run("Should Return Rate", func(t *testing.T) {
+		r, err := p.GetRate(context.Background(), db.CoinTypeXMR, "usd")
+		assert.NoError(t, err)
+		assert.Equal(t, 150.5, r.Value)
+
+		u, err := url.Parse(server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, u.Host, r.Source)
+	})
+
+	t.Run("Should Return UnsupportedPairErr", func(t *testing.T) {
+		_, err := p.GetRate(context.Background(), db.CoinTypeBTC, "USD")
+		assert.ErrorIs(t, err, UnsupportedPairErr)
+	})
+
+	t.Run("Should Return InvalidRateErr", func(t *testing.T) {
+		_, err := p.GetRate(context.Background(), db.CoinTypeXMR, "EUR")
+		assert.ErrorIs(t, err, InvalidRateErr)
+	})
+
+	t.Run("Should Return Error (invalid url)", func(t *testing.T) {
+		_, err := NewHttpRateProvider("ftp://localhost/" + CoinPlaceholder)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,28 @@
+package rate
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/chekist32/goipay/internal/db"
+)
+
+var (
+	UnsupportedPairErr error = errors.New("exchange rate for the coin/currency pair is not available")
+	InvalidRateErr     error = errors.New("exchange rate must be greater than 0")
+)
+
+// Rate is the price of one coin unit in a fiat currency.
+type Rate struct {
+	Value  float64
+	Source string
+}
+
+type RateProvider interface {
+	GetRate(ctx context.Context, coin db.CoinType, currency string) (*Rate, error)
+}
+
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
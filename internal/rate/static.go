@@ -0,0 +1,61 @@
+package rate
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/chekist32/goipay/internal/db"
+	"gopkg.in/yaml.v3"
+)
+
+const static_rate_source string = "static"
+
+// StaticRateProvider serves rates from the config (or a YAML file) and works offline.
+type StaticRateProvider struct {
+	rates map[db.CoinType]map[string]float64
+}
+
+func (p *StaticRateProvider) GetRate(ctx context.Context, coin db.CoinType, currency string) (*Rate, error) {
+	value, ok := p.rates[coin][NormalizeCurrency(currency)]
+	if !ok {
+		return nil, UnsupportedPairErr
+	}
+
+	return &Rate{Value: value, Source: static_rate_source}, nil
+}
+
+// NewStaticRateProvider expects rates in the form coin -> currency -> rate, e.g. {"XMR": {"USD": 150.5}}.
+func NewStaticRateProvider(rates map[string]map[string]float64) (*StaticRateProvider, error) {
+	p := &StaticRateProvider{rates: make(map[db.CoinType]map[string]float64, len(rates))}
+
+	for coin, currencies := range rates {
+		c := db.CoinType(strings.ToUpper(coin))
+		if _, ok := p.rates[c]; !ok {
+			p.rates[c] = make(map[string]float64, len(currencies))
+		}
+
+		for currency, value := range currencies {
+			if value <= 0 {
+				return nil, InvalidRateErr
+			}
+			p.rates[c][NormalizeCurrency(currency)] = value
+		}
+	}
+
+	return p, nil
+}
+
+func NewStaticRateProviderFromFile(path string) (*StaticRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates map[string]map[string]float64
+	if err := yaml.Unmarshal(data, &rates); err != nil {
+		return nil, err
+	}
+
+	return NewStaticRateProvider(rates)
+}
@@ -0,0 +1,53 @@
+package rate
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRateProvider(t *testing.T) {
+	t.Run("Should Return Rate (case insensitive)", func(t *testing.T) {
+		p, err := NewStaticRateProvider(map[string]map[string]float64{"xmr": {"usd": 150.5}})
+		assert.NoError(t, err)
+
+		r, err := p.GetRate(context.Background(), db.CoinTypeXMR, " Usd ")
+		assert.NoError(t, err)
+		assert.Equal(t, 150.5, r.Value)
+		assert.Equal(t, static_rate_source, r.Source)
+	})
+
+	t.Run("Should Return UnsupportedPairErr", func(t *testing.T) {
+		p, err := NewStaticRateProvider(map[string]map[string]float64{"XMR": {"USD": 150.5}})
+		assert.NoError(t, err)
+
+		_, err = p.GetRate(context.Background(), db.CoinTypeBTC, "USD")
+		assert.ErrorIs(t, err, UnsupportedPairErr)
+		_, err = p.GetRate(context.Background(), db.CoinTypeXMR, "EUR")
+		assert.ErrorIs(t, err, UnsupportedPairErr)
+	})
+
+	t.Run("Should Return InvalidRateErr", func(t *testing.T) {
+		_, err := NewStaticRateProvider(map[string]map[string]float64{"XMR": {"USD": 0}})
+		assert.ErrorIs(t, err, InvalidRateErr)
+	})
+
+	t.Run("Should Load Rates From File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "rates.yml")
+		if err := os.WriteFile(path, []byte("BTC:\n  USD: 65000\n  EUR: 60000\n"), 0600); err != nil {
+			log.Fatal(err)
+		}
+
+		p, err := NewStaticRateProviderFromFile(path)
+		assert.NoError(t, err)
+
+		r, err := p.GetRate(context.Background(), db.CoinTypeBTC, "EUR")
+		assert.NoError(t, err)
+		assert.Equal(t, float64(60000), r.Value)
+	})
+}
@@ -13,8 +13,38 @@ const (
 	HEALTH_CHECK_TIEMOUT time.Duration = 5 * time.Second
 )
 
+const (
+	LIST_INVOICES_DEFAULT_LIMIT uint32 = 50
+	LIST_INVOICES_MAX_LIMIT     uint32 = 1000
+
+	LIST_WEBHOOK_DELIVERIES_DEFAULT_LIMIT uint32 = 50
+	LIST_WEBHOOK_DELIVERIES_MAX_LIMIT     uint32 = 1000
+
+	INVOICE_EVENTS_REPLAY_BATCH_SIZE int32 = 500
+
+	IDEMPOTENCY_KEY_MAX_LENGTH int = 255
+
+	// COIN_ID_MAX_LENGTH is the length of the coin column of the invoices.
+	COIN_ID_MAX_LENGTH int = 64
+
+	EXTERNAL_ID_MAX_LENGTH    int = 255
+	DESCRIPTION_MAX_LENGTH    int = 1024
+	METADATA_MAX_ENTRIES      int = 50
+	METADATA_KEY_MAX_LENGTH   int = 64
+	METADATA_VALUE_MAX_LENGTH int = 512
+
+	TOLERANCE_PERCENT_MAX float64 = 100
+
+	PAYMENT_REQUEST_MAX_COINS int = 10
+
+	QR_CODE_DEFAULT_SIZE uint32 = 256
+	QR_CODE_MIN_SIZE     uint32 = 64
+	QR_CODE_MAX_SIZE     uint32 = 2048
+)
+
 const (
 	DefaultFailedSqlTxInitMsg                    string = "An error occurred while initiating an SQL transaction."
+	DefaultFailedSqlTxCommitMsg                  string = "An error occurred while committing an SQL transaction."
 	DefaultFailedSqlQueryMsg                     string = "An error occurred while executing a SQL query."
 	DefaultFailedScanningToPostgresqlDataTypeMsg string = "An error occurred while scanning the value into a PostgreSQL data type."
 	DefaultFailedFetchingDaemonMsg               string = "An error occurred while fetching."
@@ -25,10 +55,52 @@ const (
 	InvalidUserIdUserExistsMsg       string = "Invalid userId (user exists)."
 	InvalidUserIdUserDoesNotExistMsg string = "Invalid userId (user does not exist)."
 
-	InvoiceAmountBelow0ErrorMsg      string = "Invoice amount can't be below 0."
-	InvoiceErrorWhileHandlingMsg     string = "An error occurred while handling invoice."
-	InvoiceStreamSendingDataErrorMsg string = "An error occurred while sending data."
-	InvoiceStreamClosedErrorMsg      string = "Stream has been closed."
+	InvoiceAmountBelow0ErrorMsg         string = "Invoice amount can't be below 0."
+	InvalidInvoiceAmountMsg             string = "Invalid invoice amount (must be a base 10 integer in atomic units)."
+	InvoiceErrorWhileHandlingMsg        string = "An error occurred while handling invoice."
+	InvoiceStreamSendingDataErrorMsg    string = "An error occurred while sending data."
+	InvoiceStreamClosedErrorMsg         string = "Stream has been closed."
+	InvoiceStreamLaggedErrorMsg         string = "Stream has fallen behind. Resubscribe with fromSequence."
+	InvoiceNotCancellableMsg            string = "Invoice can't be cancelled (only pending invoices can be cancelled)."
+	InvoiceEventDeserializationErrorMsg string = "An error occurred while deserializing the invoice event."
+	InvalidFromSequenceMsg              string = "Invalid fromSequence (too large)."
+	InvalidIdempotencyKeyMsg            string = "Invalid idempotencyKey (too long)."
+	IdempotencyKeyConflictMsg           string = "Idempotency key has already been used with different parameters."
+	InvalidExternalIdMsg                string = "Invalid externalId (too long)."
+	InvalidDescriptionMsg               string = "Invalid description (too long)."
+	InvalidMetadataMsg                  string = "Invalid metadata (too many entries, empty key or too long key/value)."
+	InvoiceAmountWithFiatMsg            string = "Invoice amount must not be set for fiat-denominated invoices."
+	InvalidFiatAmountMsg                string = "Invalid fiat amount (must be greater than 0)."
+	InvalidFiatCurrencyMsg              string = "Invalid fiat currency (must not be empty)."
+	UnsupportedFiatCurrencyMsg          string = "Exchange rate for the coin/currency pair is not available."
+	RateProviderNotConfiguredMsg        string = "Exchange rate provider is not configured."
+	InvalidTolerancePercentMsg          string = "Invalid tolerance percent (must be in range [0, 100))."
+	InvalidToleranceAmountMsg           string = "Invalid tolerance amount (must be a non-negative base 10 integer in atomic units)."
+	InvalidPaymentRequestCoinsMsg       string = "Invalid coins (must be a non-empty list of at most 10 distinct coins)."
+	PaymentRequestCoinAmountMissingMsg  string = "Coin amount must be set unless the payment request has a fiat amount."
+	InvalidQrCodeFormatMsg              string = "Invalid QR code format."
+	InvalidQrCodeErrorCorrectionMsg     string = "Invalid QR code error correction level."
+	InvalidQrCodeSizeMsg                string = "Invalid QR code size (must be in range [64, 2048])."
+	QrCodeRenderingErrorMsg             string = "An error occurred while rendering the QR code."
+
+	InvalidWebhookUrlMsg                    string = "Invalid webhook url (only absolute http/https urls are supported)."
+	InvalidWebhookSecretMsg                 string = "Invalid webhook secret (must not be empty)."
+	InvalidWebhookDeliveryStatusTypeMsg     string = "Invalid webhook delivery status type."
+	InvalidWebhookDeliveryIdInvalidUUIDMsg  string = "Invalid webhook delivery id (invalid UUID)."
+	InvalidWebhookDeliveryIdDoesNotExistMsg string = "Invalid webhook delivery id (delivery does not exist)."
+	InvalidListWebhookDeliveriesLimitMsg    string = "Invalid webhook deliveries limit (exceeds the maximum page size)."
+	InvalidListWebhookDeliveriesOffsetMsg   string = "Invalid webhook deliveries offset (too large)."
+
+	InvalidInvoiceIdInvalidUUIDMsg         string = "Invalid invoice id (invalid UUID)."
+	InvalidInvoiceIdInvoiceDoesNotExistMsg string = "Invalid invoice id (invoice does not exist)."
+	InvalidPaymentRequestIdInvalidUUIDMsg  string = "Invalid payment request id (invalid UUID)."
+	InvalidPaymentRequestIdDoesNotExistMsg string = "Invalid payment request id (payment request does not exist)."
+	InvalidCoinTypeMsg                     string = "Invalid coin type."
+	InvalidEvmChainMsg                     string = "Invalid EVM chain (must be the coin id of a chain declared in the config)."
+	InvalidInvoiceStatusTypeMsg            string = "Invalid invoice status type."
+	InvalidListInvoicesLimitMsg            string = "Invalid limit (exceeds the maximum page size)."
+	InvalidListInvoicesOffsetMsg           string = "Invalid offset (too large)."
+	InvalidMetadataValueFilterMsg          string = "Invalid metadataValue (metadataKey must be set)."
 )
 
 const (
@@ -42,8 +114,21 @@ const (
 
 var (
 	invalidProtoBufCoinTypeErr error = errors.New("invalid protoBuf coin type")
+	invalidCoinIdErr           error = errors.New("invalid coin id")
 	invalidDbCoinTypeErr       error = errors.New("invalid db coin type")
 	invalidDbStatusTypeErr     error = errors.New("invalid db status type")
 
+	invalidProtoBufStatusTypeErr error = errors.New("invalid protoBuf status type")
+
+	invalidDbPaymentOutcomeTypeErr error = errors.New("invalid db payment outcome type")
+
+	invalidDbInvoiceEventTypeErr error = errors.New("invalid db invoice event type")
+
+	invalidProtoBufQrCodeFormatErr          error = errors.New("invalid protoBuf qr code format")
+	invalidProtoBufQrCodeErrorCorrectionErr error = errors.New("invalid protoBuf qr code error correction")
+
+	invalidNumericErr error = errors.New("invalid numeric (must be a finite integer)")
+	invalidAmountErr  error = errors.New("invalid amount (must be a base 10 integer)")
+
 	InvalidNetworkTypeErr error = errors.New("invalid network type")
 )
@@ -1,11 +1,16 @@
 package util
 
 import (
+	"encoding/json"
 	"math"
+	"math/big"
+	"strings"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/chekist32/goipay/internal/qr"
+	"github.com/chekist32/goipay/internal/rate"
 	"github.com/jackc/pgx/v5/pgtype"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -28,6 +33,72 @@ func PgUUIDToString(uuid pgtype.UUID) string {
 	return string(str[1 : len(str)-1])
 }
 
+func BigIntToPgNumeric(amount *big.Int) pgtype.Numeric {
+	if amount == nil {
+		return pgtype.Numeric{}
+	}
+
+	return pgtype.Numeric{Int: new(big.Int).Set(amount), Exp: 0, Valid: true}
+}
+
+// PgNumericToBigInt converts an integer NUMERIC (atomic units) to *big.Int.
+func PgNumericToBigInt(amount pgtype.Numeric) (*big.Int, error) {
+	if !amount.Valid || amount.NaN || amount.InfinityModifier != pgtype.Finite || amount.Int == nil {
+		return nil, invalidNumericErr
+	}
+
+	res := new(big.Int).Set(amount.Int)
+	if amount.Exp == 0 {
+		return res, nil
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(absInt32(amount.Exp))), nil)
+	if amount.Exp > 0 {
+		return res.Mul(res, scale), nil
+	}
+
+	quo, rem := new(big.Int).QuoRem(res, scale, new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, invalidNumericErr
+	}
+
+	return quo, nil
+}
+
+func PgNumericToString(amount pgtype.Numeric) string {
+	res, err := PgNumericToBigInt(amount)
+	if err != nil {
+		return ""
+	}
+
+	return res.String()
+}
+
+// StringToBigInt parses a base 10 integer amount in atomic units.
+func StringToBigInt(amount string) (*big.Int, error) {
+	res, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return nil, invalidAmountErr
+	}
+
+	return res, nil
+}
+
+func absInt32(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func PbTimestampToPgTimestamptz(ts *timestamppb.Timestamp) pgtype.Timestamptz {
+	if ts == nil {
+		return pgtype.Timestamptz{}
+	}
+
+	return pgtype.Timestamptz{Time: ts.AsTime(), Valid: true}
+}
+
 func PbCoinToDbCoin(coin pb_v1.CoinType) (db.CoinType, error) {
 	switch coin {
 	case pb_v1.CoinType_XMR:
@@ -123,6 +194,25 @@ func PbCoinToDbCoin(coin pb_v1.CoinType) (db.CoinType, error) {
 	return "", invalidProtoBufCoinTypeErr
 }
 
+// PbCoinIdToDbCoin returns the coin identified by coinId. The coin enum is used if coinId isn't set.
+func PbCoinIdToDbCoin(coin pb_v1.CoinType, coinId *string) (db.CoinType, error) {
+	if coinId == nil {
+		return PbCoinToDbCoin(coin)
+	}
+
+	return CoinIdToDbCoin(*coinId)
+}
+
+// CoinIdToDbCoin normalizes the coin id of a registry token or an EVM chain.
+func CoinIdToDbCoin(coinId string) (db.CoinType, error) {
+	id := strings.ToUpper(strings.TrimSpace(coinId))
+	if id == "" || len(id) > COIN_ID_MAX_LENGTH {
+		return "", invalidCoinIdErr
+	}
+
+	return db.CoinType(id), nil
+}
+
 func DbCoinToPbCoin(coin db.CoinType) (pb_v1.CoinType, error) {
 	switch coin {
 	case db.CoinTypeXMR:
@@ -228,39 +318,329 @@ func DbInvoiceStatusToPbInvoiceStatus(status db.InvoiceStatusType) (pb_v1.Invoic
 		return pb_v1.InvoiceStatusType_CONFIRMED, nil
 	case db.InvoiceStatusTypeEXPIRED:
 		return pb_v1.InvoiceStatusType_EXPIRED, nil
+	case db.InvoiceStatusTypeCANCELLED:
+		return pb_v1.InvoiceStatusType_CANCELLED, nil
+	case db.InvoiceStatusTypePARTIALLYPAID:
+		return pb_v1.InvoiceStatusType_PARTIALLY_PAID, nil
+	case db.InvoiceStatusTypePAIDAFTEREXPIRY:
+		return pb_v1.InvoiceStatusType_PAID_AFTER_EXPIRY, nil
 	}
 
 	return math.MaxInt32, invalidDbStatusTypeErr
 }
 
+func PbInvoiceStatusToDbInvoiceStatus(status pb_v1.InvoiceStatusType) (db.InvoiceStatusType, error) {
+	switch status {
+	case pb_v1.InvoiceStatusType_PENDING:
+		return db.InvoiceStatusTypePENDING, nil
+	case pb_v1.InvoiceStatusType_PENDING_MEMPOOL:
+		return db.InvoiceStatusTypePENDINGMEMPOOL, nil
+	case pb_v1.InvoiceStatusType_CONFIRMED:
+		return db.InvoiceStatusTypeCONFIRMED, nil
+	case pb_v1.InvoiceStatusType_EXPIRED:
+		return db.InvoiceStatusTypeEXPIRED, nil
+	case pb_v1.InvoiceStatusType_CANCELLED:
+		return db.InvoiceStatusTypeCANCELLED, nil
+	case pb_v1.InvoiceStatusType_PARTIALLY_PAID:
+		return db.InvoiceStatusTypePARTIALLYPAID, nil
+	case pb_v1.InvoiceStatusType_PAID_AFTER_EXPIRY:
+		return db.InvoiceStatusTypePAIDAFTEREXPIRY, nil
+	}
+
+	return "", invalidProtoBufStatusTypeErr
+}
+
+func DbPaymentOutcomeToPbPaymentOutcome(outcome db.PaymentOutcomeType) (pb_v1.PaymentOutcomeType, error) {
+	switch outcome {
+	case db.PaymentOutcomeTypeOVERPAID:
+		return pb_v1.PaymentOutcomeType_OVERPAID, nil
+	case db.PaymentOutcomeTypeUNDERPAID:
+		return pb_v1.PaymentOutcomeType_UNDERPAID, nil
+	}
+
+	return math.MaxInt32, invalidDbPaymentOutcomeTypeErr
+}
+
+func PbQrCodeFormatToQrFormat(format pb_v1.QrCodeFormat) (qr.Format, error) {
+	switch format {
+	case pb_v1.QrCodeFormat_PNG:
+		return qr.PNG, nil
+	case pb_v1.QrCodeFormat_SVG:
+		return qr.SVG, nil
+	}
+
+	return 0, invalidProtoBufQrCodeFormatErr
+}
+
+func PbQrCodeErrorCorrectionToQrLevel(level pb_v1.QrCodeErrorCorrection) (qr.Level, error) {
+	switch level {
+	case pb_v1.QrCodeErrorCorrection_LOW:
+		return qr.LowLevel, nil
+	case pb_v1.QrCodeErrorCorrection_MEDIUM:
+		return qr.MediumLevel, nil
+	case pb_v1.QrCodeErrorCorrection_QUARTILE:
+		return qr.QuartileLevel, nil
+	case pb_v1.QrCodeErrorCorrection_HIGH:
+		return qr.HighLevel, nil
+	}
+
+	return 0, invalidProtoBufQrCodeErrorCorrectionErr
+}
+
 func DbInvoiceToPbInvoice(invoice *db.Invoice) *pb_v1.Invoice {
 	coin, _ := DbCoinToPbCoin(invoice.Coin)
 	status, _ := DbInvoiceStatusToPbInvoiceStatus(invoice.Status)
 
-	return &pb_v1.Invoice{
+	pbInvoice := &pb_v1.Invoice{
 		Id:                    PgUUIDToString(invoice.ID),
 		CryptoAddress:         invoice.CryptoAddress,
 		Coin:                  coin,
-		RequiredAmount:        invoice.RequiredAmount,
-		ActualAmount:          invoice.ActualAmount.Float64,
+		RequiredAmount:        PgNumericToString(invoice.RequiredAmount),
+		ActualAmount:          PgNumericToString(invoice.ActualAmount),
 		ConfirmationsRequired: uint32(invoice.ConfirmationsRequired),
+		Confirmations:         uint32(invoice.Confirmations),
 		CreatedAt:             timestamppb.New(invoice.CreatedAt.Time),
 		ConfirmedAt:           timestamppb.New(invoice.ConfirmedAt.Time),
 		Status:                status,
 		ExpiresAt:             timestamppb.New(invoice.ExpiresAt.Time),
 		TxId:                  invoice.TxID.String,
 		UserId:                PgUUIDToString(invoice.UserID),
+		CoinId:                string(invoice.Coin),
+	}
+	if invoice.ExternalID.Valid {
+		pbInvoice.ExternalId = &invoice.ExternalID.String
+	}
+	if invoice.Description.Valid {
+		pbInvoice.Description = &invoice.Description.String
+	}
+	if invoice.FiatAmount.Valid {
+		pbInvoice.FiatAmount = &invoice.FiatAmount.Float64
+	}
+	if invoice.FiatCurrency.Valid {
+		pbInvoice.FiatCurrency = &invoice.FiatCurrency.String
+	}
+	if invoice.ExchangeRate.Valid {
+		pbInvoice.ExchangeRate = &invoice.ExchangeRate.Float64
+	}
+	if invoice.RateSource.Valid {
+		pbInvoice.RateSource = &invoice.RateSource.String
+	}
+	if invoice.TolerancePercent.Valid {
+		pbInvoice.TolerancePercent = &invoice.TolerancePercent.Float64
+	}
+	if invoice.ToleranceAmount.Valid {
+		toleranceAmount := PgNumericToString(invoice.ToleranceAmount)
+		pbInvoice.ToleranceAmount = &toleranceAmount
+	}
+	if invoice.PaymentOutcome.Valid {
+		if outcome, err := DbPaymentOutcomeToPbPaymentOutcome(invoice.PaymentOutcome.PaymentOutcomeType); err == nil {
+			pbInvoice.PaymentOutcome = &outcome
+		}
+	}
+	if invoice.PaymentOutcomeAmount.Valid {
+		outcomeAmount := PgNumericToString(invoice.PaymentOutcomeAmount)
+		pbInvoice.PaymentOutcomeAmount = &outcomeAmount
+	}
+	if invoice.PaymentRequestID.Valid {
+		paymentRequestId := PgUUIDToString(invoice.PaymentRequestID)
+		pbInvoice.PaymentRequestId = &paymentRequestId
+	}
+	if len(invoice.Metadata) > 0 {
+		var metadata map[string]string
+		if err := json.Unmarshal(invoice.Metadata, &metadata); err == nil && len(metadata) > 0 {
+			pbInvoice.Metadata = metadata
+		}
+	}
+
+	return pbInvoice
+}
+
+func DbInvoiceEventToDtoInvoiceEvent(event *db.InvoiceEvent) (*dto.InvoiceEvent, error) {
+	var invoice db.Invoice
+	if err := json.Unmarshal(event.Payload, &invoice); err != nil {
+		return nil, err
+	}
+
+	return &dto.InvoiceEvent{Sequence: event.PublishedSequence.Int64, Type: event.Type, Invoice: invoice}, nil
+}
+
+func DbInvoiceEventTypeToPbInvoiceEventType(eventType db.InvoiceEventType) (pb_v1.InvoiceEventType, error) {
+	switch eventType {
+	case db.InvoiceEventTypeSTATUSCHANGED:
+		return pb_v1.InvoiceEventType_STATUS_CHANGED, nil
+	case db.InvoiceEventTypeCONFIRMATIONPROGRESS:
+		return pb_v1.InvoiceEventType_CONFIRMATION_PROGRESS, nil
+	case db.InvoiceEventTypeMEMPOOLDROPPED:
+		return pb_v1.InvoiceEventType_MEMPOOL_DROPPED, nil
+	}
+
+	return math.MaxInt32, invalidDbInvoiceEventTypeErr
+}
+
+func DtoInvoiceEventToPbInvoiceStatusStreamResponse(event *dto.InvoiceEvent) *pb_v1.InvoiceStatusStreamResponse {
+	eventType, _ := DbInvoiceEventTypeToPbInvoiceEventType(event.Type)
+
+	return &pb_v1.InvoiceStatusStreamResponse{Invoice: DbInvoiceToPbInvoice(&event.Invoice), Sequence: uint64(event.Sequence), Type: eventType}
+}
+
+func DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(status db.WebhookDeliveryStatusType) (pb_v1.WebhookDeliveryStatusType, error) {
+	switch status {
+	case db.WebhookDeliveryStatusTypePENDING:
+		return pb_v1.WebhookDeliveryStatusType_PENDING, nil
+	case db.WebhookDeliveryStatusTypeDELIVERED:
+		return pb_v1.WebhookDeliveryStatusType_DELIVERED, nil
+	case db.WebhookDeliveryStatusTypeDEAD:
+		return pb_v1.WebhookDeliveryStatusType_DEAD, nil
+	}
+
+	return math.MaxInt32, invalidDbStatusTypeErr
+}
+
+func PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(status pb_v1.WebhookDeliveryStatusType) (db.WebhookDeliveryStatusType, error) {
+	switch status {
+	case pb_v1.WebhookDeliveryStatusType_PENDING:
+		return db.WebhookDeliveryStatusTypePENDING, nil
+	case pb_v1.WebhookDeliveryStatusType_DELIVERED:
+		return db.WebhookDeliveryStatusTypeDELIVERED, nil
+	case pb_v1.WebhookDeliveryStatusType_DEAD:
+		return db.WebhookDeliveryStatusTypeDEAD, nil
+	}
+
+	return "", invalidProtoBufStatusTypeErr
+}
+
+func DbWebhookDeliveryToPbWebhookDelivery(delivery *db.WebhookDelivery) *pb_v1.WebhookDelivery {
+	status, _ := DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(delivery.Status)
+
+	pbDelivery := &pb_v1.WebhookDelivery{
+		Id:            PgUUIDToString(delivery.ID),
+		WebhookId:     PgUUIDToString(delivery.WebhookID),
+		InvoiceId:     PgUUIDToString(delivery.InvoiceID),
+		Status:        status,
+		Attempts:      uint32(delivery.Attempts),
+		NextAttemptAt: timestamppb.New(delivery.NextAttemptAt.Time),
+		CreatedAt:     timestamppb.New(delivery.CreatedAt.Time),
+		DeliveredAt:   timestamppb.New(delivery.DeliveredAt.Time),
+	}
+	if delivery.LastResponseCode.Valid {
+		code := uint32(delivery.LastResponseCode.Int32)
+		pbDelivery.LastResponseCode = &code
+	}
+	if delivery.LastError.Valid {
+		pbDelivery.LastError = &delivery.LastError.String
+	}
+
+	return pbDelivery
+}
+
+func DbPaymentRequestStatusToPbPaymentRequestStatus(status db.PaymentRequestStatusType) (pb_v1.PaymentRequestStatusType, error) {
+	switch status {
+	case db.PaymentRequestStatusTypePENDING:
+		return pb_v1.PaymentRequestStatusType_PAYMENT_REQUEST_PENDING, nil
+	case db.PaymentRequestStatusTypePAID:
+		return pb_v1.PaymentRequestStatusType_PAYMENT_REQUEST_PAID, nil
+	case db.PaymentRequestStatusTypeEXPIRED:
+		return pb_v1.PaymentRequestStatusType_PAYMENT_REQUEST_EXPIRED, nil
+	}
+
+	return math.MaxInt32, invalidDbStatusTypeErr
+}
+
+func DbPaymentRequestToPbPaymentRequest(paymentRequest *db.PaymentRequest, invoices []db.Invoice) *pb_v1.PaymentRequest {
+	status, _ := DbPaymentRequestStatusToPbPaymentRequestStatus(paymentRequest.Status)
+
+	pbPaymentRequest := &pb_v1.PaymentRequest{
+		Id:        PgUUIDToString(paymentRequest.ID),
+		UserId:    PgUUIDToString(paymentRequest.UserID),
+		Status:    status,
+		Invoices:  make([]*pb_v1.Invoice, 0, len(invoices)),
+		CreatedAt: timestamppb.New(paymentRequest.CreatedAt.Time),
+	}
+	if paymentRequest.PaidInvoiceID.Valid {
+		paidInvoiceId := PgUUIDToString(paymentRequest.PaidInvoiceID)
+		pbPaymentRequest.PaidInvoiceId = &paidInvoiceId
+	}
+	if paymentRequest.PaidAt.Valid {
+		pbPaymentRequest.PaidAt = timestamppb.New(paymentRequest.PaidAt.Time)
+	}
+	for i := 0; i < len(invoices); i++ {
+		pbPaymentRequest.Invoices = append(pbPaymentRequest.Invoices, DbInvoiceToPbInvoice(&invoices[i]))
+	}
+
+	return pbPaymentRequest
+}
+
+func DbTokenToPbToken(token *db.Token) *pb_v1.Token {
+	chain, _ := DbCoinToPbCoin(token.Chain)
+
+	return &pb_v1.Token{
+		Coin:            string(token.Coin),
+		Symbol:          token.Symbol,
+		Chain:           chain,
+		ChainId:         uint64(token.ChainID),
+		ContractAddress: token.ContractAddress,
+		Decimals:        uint32(token.Decimals),
+		ChainCoinId:     string(token.Chain),
+	}
+}
+
+func PbNewPaymentRequestToProcessorNewPaymentRequest(req *pb_v1.CreatePaymentRequestRequest) *dto.NewPaymentRequest {
+	coins := make([]dto.PaymentRequestCoin, 0, len(req.Coins))
+	for i := 0; i < len(req.Coins); i++ {
+		coin, _ := PbCoinIdToDbCoin(req.Coins[i].Coin, req.Coins[i].CoinId)
+
+		var amount *big.Int
+		if req.Coins[i].Amount != nil {
+			amount, _ = StringToBigInt(req.Coins[i].GetAmount())
+		}
+
+		coins = append(coins, dto.PaymentRequestCoin{Coin: coin, Amount: amount})
+	}
+
+	return &dto.NewPaymentRequest{
+		UserId:        req.UserId,
+		Coins:         coins,
+		Timeout:       req.Timeout,
+		Confirmations: req.Confirmations,
+
+		ExternalId:  req.GetExternalId(),
+		Description: req.GetDescription(),
+		Metadata:    req.Metadata,
+
+		FiatAmount:   req.GetFiat().GetAmount(),
+		FiatCurrency: rate.NormalizeCurrency(req.GetFiat().GetCurrency()),
 	}
 }
 
 func PbNewInvoiceToProcessorNewInvoice(req *pb_v1.CreateInvoiceRequest) *dto.NewInvoiceRequest {
-	coin, _ := PbCoinToDbCoin(req.Coin)
+	coin, _ := PbCoinIdToDbCoin(req.Coin, req.CoinId)
+	amount, _ := StringToBigInt(req.Amount)
+
+	var tolerance *dto.PaymentTolerance
+	switch v := req.GetTolerance().GetValue().(type) {
+	case *pb_v1.PaymentTolerance_Percent:
+		tolerance = &dto.PaymentTolerance{Percent: v.Percent}
+	case *pb_v1.PaymentTolerance_Amount:
+		toleranceAmount, _ := StringToBigInt(v.Amount)
+		tolerance = &dto.PaymentTolerance{Amount: toleranceAmount}
+	}
 
 	return &dto.NewInvoiceRequest{
 		UserId:        req.UserId,
 		Coin:          coin,
-		Amount:        req.Amount,
+		Amount:        amount,
 		Timeout:       req.Timeout,
 		Confirmations: req.Confirmations,
+
+		IdempotencyKey: req.GetIdempotencyKey(),
+
+		ExternalId:  req.GetExternalId(),
+		Description: req.GetDescription(),
+		Metadata:    req.Metadata,
+
+		FiatAmount:   req.GetFiat().GetAmount(),
+		FiatCurrency: rate.NormalizeCurrency(req.GetFiat().GetCurrency()),
+
+		Tolerance: tolerance,
 	}
 }
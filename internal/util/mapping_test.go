@@ -1,16 +1,20 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/internal/dto"
 	pb_v1 "github.com/chekist32/goipay/internal/pb/v1"
+	"github.com/chekist32/goipay/internal/qr"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
@@ -110,8 +114,8 @@ var (
 		db.CoinTypeAVAXBEP20,
 		db.CoinTypeCAKEBEP20,
 	}
-	dbInvoiceStatuses []db.InvoiceStatusType    = []db.InvoiceStatusType{db.InvoiceStatusTypePENDING, db.InvoiceStatusTypePENDINGMEMPOOL, db.InvoiceStatusTypeEXPIRED, db.InvoiceStatusTypeCONFIRMED}
-	pbInvoiceStatuses []pb_v1.InvoiceStatusType = []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_PENDING, pb_v1.InvoiceStatusType_PENDING_MEMPOOL, pb_v1.InvoiceStatusType_EXPIRED, pb_v1.InvoiceStatusType_CONFIRMED}
+	dbInvoiceStatuses []db.InvoiceStatusType    = []db.InvoiceStatusType{db.InvoiceStatusTypePENDING, db.InvoiceStatusTypePENDINGMEMPOOL, db.InvoiceStatusTypeEXPIRED, db.InvoiceStatusTypeCONFIRMED, db.InvoiceStatusTypeCANCELLED, db.InvoiceStatusTypePARTIALLYPAID, db.InvoiceStatusTypePAIDAFTEREXPIRY}
+	pbInvoiceStatuses []pb_v1.InvoiceStatusType = []pb_v1.InvoiceStatusType{pb_v1.InvoiceStatusType_PENDING, pb_v1.InvoiceStatusType_PENDING_MEMPOOL, pb_v1.InvoiceStatusType_EXPIRED, pb_v1.InvoiceStatusType_CONFIRMED, pb_v1.InvoiceStatusType_CANCELLED, pb_v1.InvoiceStatusType_PARTIALLY_PAID, pb_v1.InvoiceStatusType_PAID_AFTER_EXPIRY}
 )
 
 func TestStringToPgUUID(t *testing.T) {
@@ -178,6 +182,30 @@ func TestPbCoinToDbCoin(t *testing.T) {
 
 }
 
+func TestPbCoinIdToDbCoin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Should Fall Back To PbCoin", func(t *testing.T) {
+		dbCoin, err := PbCoinIdToDbCoin(pb_v1.CoinType_USDT_ERC20, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinTypeUSDTERC20, dbCoin)
+	})
+
+	t.Run("Should Return Normalized CoinId", func(t *testing.T) {
+		coinId := " eurc_erc20 "
+		dbCoin, err := PbCoinIdToDbCoin(pb_v1.CoinType_XMR, &coinId)
+		assert.NoError(t, err)
+		assert.Equal(t, db.CoinType("EURC_ERC20"), dbCoin)
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		for _, coinId := range []string{"", "  ", strings.Repeat("A", COIN_ID_MAX_LENGTH+1)} {
+			_, err := PbCoinIdToDbCoin(pb_v1.CoinType_XMR, &coinId)
+			assert.ErrorIs(t, err, invalidCoinIdErr)
+		}
+	})
+}
+
 func TestDbCoinToPbCoin(t *testing.T) {
 	t.Parallel()
 
@@ -221,9 +249,29 @@ func TestDbInvoiceStatusToPbInvoiceStatus(t *testing.T) {
 	})
 }
 
+func TestPbInvoiceStatusToDbInvoiceStatus(t *testing.T) {
+	t.Run("Should Return Valid DbInvoiceStatus For PbInvoiceStatus", func(t *testing.T) {
+		for i := 0; i < len(pbInvoiceStatuses); i++ {
+			t.Run(fmt.Sprintf("Should Return Valid DbInvoiceStatus For PbInvoiceStatus(%v)", pbInvoiceStatuses[i]), func(t *testing.T) {
+				expectedDbInvoiceStatus := dbInvoiceStatuses[i]
+
+				dbInvoiceStatus, err := PbInvoiceStatusToDbInvoiceStatus(pbInvoiceStatuses[i])
+				assert.NoError(t, err)
+				assert.Equal(t, expectedDbInvoiceStatus, dbInvoiceStatus)
+			})
+		}
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := PbInvoiceStatusToDbInvoiceStatus(math.MaxInt32)
+		assert.Error(t, err)
+		assert.ErrorIs(t, err, invalidProtoBufStatusTypeErr)
+	})
+}
+
 func TestDbInvoiceToPbInvoice(t *testing.T) {
 	idStr := uuid.NewString()
-	actualAmountFloat64 := rand.Float64()
+	actualAmountInt := new(big.Int).Mul(big.NewInt(rand.Int63()), big.NewInt(rand.Int63()))
 	createdAtTime := time.Now().UTC()
 	expiresAtTime := createdAtTime.Add(time.Duration(rand.Intn(math.MaxInt)+1) * time.Minute)
 	txIdStr := uuid.NewString()
@@ -233,10 +281,7 @@ func TestDbInvoiceToPbInvoice(t *testing.T) {
 	if err := id.Scan(idStr); err != nil {
 		log.Fatal(err)
 	}
-	var actualAmount pgtype.Float8
-	if err := actualAmount.Scan(actualAmountFloat64); err != nil {
-		log.Fatal(err)
-	}
+	actualAmount := BigIntToPgNumeric(actualAmountInt)
 	var createdAt pgtype.Timestamptz
 	if err := createdAt.Scan(createdAtTime); err != nil {
 		log.Fatal(err)
@@ -258,7 +303,7 @@ func TestDbInvoiceToPbInvoice(t *testing.T) {
 		ID:                    id,
 		CryptoAddress:         uuid.NewString(),
 		Coin:                  db.CoinTypeBTC,
-		RequiredAmount:        rand.Float64(),
+		RequiredAmount:        BigIntToPgNumeric(big.NewInt(rand.Int63())),
 		ActualAmount:          actualAmount,
 		ConfirmationsRequired: int16(rand.Intn(math.MaxInt16)),
 		CreatedAt:             createdAt,
@@ -267,14 +312,22 @@ func TestDbInvoiceToPbInvoice(t *testing.T) {
 		ExpiresAt:             expiresAt,
 		TxID:                  txId,
 		UserID:                userId,
+		ExternalID:            pgtype.Text{String: "order-1", Valid: true},
+		Metadata:              []byte(`{"customer":"42"}`),
+		FiatAmount:            pgtype.Float8{Float64: 25, Valid: true},
+		FiatCurrency:          pgtype.Text{String: "USD", Valid: true},
+		ExchangeRate:          pgtype.Float8{Float64: 150.5, Valid: true},
+		RateSource:            pgtype.Text{String: "static", Valid: true},
 	}
 
-	expectedPbInvoice := pb_v1.Invoice{
+	externalId := "order-1"
+	expectedPbInvoice := &pb_v1.Invoice{
 		Id:                    idStr,
 		CryptoAddress:         dbInv.CryptoAddress,
 		Coin:                  pb_v1.CoinType_BTC,
-		RequiredAmount:        dbInv.RequiredAmount,
-		ActualAmount:          actualAmountFloat64,
+		CoinId:                string(db.CoinTypeBTC),
+		RequiredAmount:        dbInv.RequiredAmount.Int.String(),
+		ActualAmount:          actualAmountInt.String(),
 		ConfirmationsRequired: uint32(dbInv.ConfirmationsRequired),
 		CreatedAt:             timestamppb.New(createdAtTime),
 		ConfirmedAt:           timestamppb.New(dbInv.ConfirmedAt.Time),
@@ -282,21 +335,69 @@ func TestDbInvoiceToPbInvoice(t *testing.T) {
 		ExpiresAt:             timestamppb.New(expiresAtTime),
 		TxId:                  txIdStr,
 		UserId:                userIdStr,
+		ExternalId:            &externalId,
+		Metadata:              map[string]string{"customer": "42"},
+		FiatAmount:            &dbInv.FiatAmount.Float64,
+		FiatCurrency:          &dbInv.FiatCurrency.String,
+		ExchangeRate:          &dbInv.ExchangeRate.Float64,
+		RateSource:            &dbInv.RateSource.String,
 	}
 
-	assert.Equal(t, expectedPbInvoice, *DbInvoiceToPbInvoice(&dbInv))
+	assert.Equal(t, expectedPbInvoice, DbInvoiceToPbInvoice(&dbInv))
+}
+
+func TestDbInvoiceEventToDtoInvoiceEvent(t *testing.T) {
+	t.Run("Should Return Valid DtoInvoiceEvent", func(t *testing.T) {
+		var id pgtype.UUID
+		if err := id.Scan(uuid.NewString()); err != nil {
+			log.Fatal(err)
+		}
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+
+		expectedInvoice := db.Invoice{
+			ID:            id,
+			CryptoAddress: uuid.NewString(),
+			Coin:          db.CoinTypeXMR,
+			Status:        db.InvoiceStatusTypeEXPIRED,
+			ExpiresAt:     expiresAt,
+		}
+		payload, err := json.Marshal(expectedInvoice)
+		if err != nil {
+			log.Fatal(err)
+		}
+		expectedSequence := rand.Int63()
+
+		event, err := DbInvoiceEventToDtoInvoiceEvent(&db.InvoiceEvent{Sequence: rand.Int63(), PublishedSequence: pgtype.Int8{Int64: expectedSequence, Valid: true}, InvoiceID: id, Status: expectedInvoice.Status, Type: db.InvoiceEventTypeCONFIRMATIONPROGRESS, Payload: payload})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedSequence, event.Sequence)
+		assert.Equal(t, db.InvoiceEventTypeCONFIRMATIONPROGRESS, event.Type)
+		assert.Equal(t, expectedInvoice.ID, event.Invoice.ID)
+		assert.Equal(t, expectedInvoice.CryptoAddress, event.Invoice.CryptoAddress)
+		assert.Equal(t, expectedInvoice.Coin, event.Invoice.Coin)
+		assert.Equal(t, expectedInvoice.Status, event.Invoice.Status)
+		assert.True(t, expectedInvoice.ExpiresAt.Time.Equal(event.Invoice.ExpiresAt.Time))
+		assert.False(t, event.Invoice.ConfirmedAt.Valid)
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := DbInvoiceEventToDtoInvoiceEvent(&db.InvoiceEvent{Payload: []byte("{")})
+		assert.Error(t, err)
+	})
 }
 
 func TestPbNewInvoiceToProcessorNewInvoice(t *testing.T) {
 	userId := uuid.NewString()
-	amount := rand.Float64()
+	amount := new(big.Int).Mul(big.NewInt(rand.Int63()), big.NewInt(rand.Int63()))
 	timeout := rand.Uint64()
 	confirmations := rand.Uint32()
 
 	newInv := pb_v1.CreateInvoiceRequest{
 		UserId:        userId,
 		Coin:          pb_v1.CoinType_BTC,
-		Amount:        amount,
+		Amount:        amount.String(),
 		Timeout:       timeout,
 		Confirmations: confirmations,
 	}
@@ -310,4 +411,216 @@ func TestPbNewInvoiceToProcessorNewInvoice(t *testing.T) {
 	}
 
 	assert.Equal(t, expectedProcessorNewInvoice, *PbNewInvoiceToProcessorNewInvoice(&newInv))
+
+	t.Run("Should Map Tolerance", func(t *testing.T) {
+		newInv.Tolerance = &pb_v1.PaymentTolerance{Value: &pb_v1.PaymentTolerance_Percent{Percent: 0.5}}
+		assert.Equal(t, &dto.PaymentTolerance{Percent: 0.5}, PbNewInvoiceToProcessorNewInvoice(&newInv).Tolerance)
+
+		newInv.Tolerance = &pb_v1.PaymentTolerance{Value: &pb_v1.PaymentTolerance_Amount{Amount: "1000"}}
+		assert.Equal(t, &dto.PaymentTolerance{Amount: big.NewInt(1000)}, PbNewInvoiceToProcessorNewInvoice(&newInv).Tolerance)
+	})
+}
+
+func TestPbNewPaymentRequestToProcessorNewPaymentRequest(t *testing.T) {
+	userId := uuid.NewString()
+	amount := "1000"
+
+	req := pb_v1.CreatePaymentRequestRequest{
+		UserId: userId,
+		Coins: []*pb_v1.PaymentRequestCoin{
+			{Coin: pb_v1.CoinType_BTC, Amount: &amount},
+			{Coin: pb_v1.CoinType_XMR},
+		},
+		Fiat:    &pb_v1.FiatAmount{Amount: 25, Currency: "usd"},
+		Timeout: 600,
+	}
+
+	expected := dto.NewPaymentRequest{
+		UserId: userId,
+		Coins: []dto.PaymentRequestCoin{
+			{Coin: db.CoinTypeBTC, Amount: big.NewInt(1000)},
+			{Coin: db.CoinTypeXMR},
+		},
+		Timeout:      600,
+		FiatAmount:   25,
+		FiatCurrency: "USD",
+	}
+
+	assert.Equal(t, expected, *PbNewPaymentRequestToProcessorNewPaymentRequest(&req))
+}
+
+func TestDbPaymentRequestToPbPaymentRequest(t *testing.T) {
+	id, err := StringToPgUUID(uuid.NewString())
+	if err != nil {
+		log.Fatal(err)
+	}
+	invoiceId, err := StringToPgUUID(uuid.NewString())
+	if err != nil {
+		log.Fatal(err)
+	}
+	paidAt := time.Now().UTC()
+
+	paymentRequest := db.PaymentRequest{
+		ID:            *id,
+		Status:        db.PaymentRequestStatusTypePAID,
+		PaidInvoiceID: *invoiceId,
+		PaidAt:        pgtype.Timestamptz{Time: paidAt, Valid: true},
+	}
+	invoices := []db.Invoice{{ID: *invoiceId, Coin: db.CoinTypeBTC, Status: db.InvoiceStatusTypeCONFIRMED, PaymentRequestID: *id}}
+
+	pbPaymentRequest := DbPaymentRequestToPbPaymentRequest(&paymentRequest, invoices)
+	assert.Equal(t, PgUUIDToString(*id), pbPaymentRequest.Id)
+	assert.Equal(t, pb_v1.PaymentRequestStatusType_PAYMENT_REQUEST_PAID, pbPaymentRequest.Status)
+	assert.Equal(t, PgUUIDToString(*invoiceId), pbPaymentRequest.GetPaidInvoiceId())
+	assert.Equal(t, paidAt.Unix(), pbPaymentRequest.PaidAt.AsTime().Unix())
+	assert.Len(t, pbPaymentRequest.Invoices, 1)
+	assert.Equal(t, PgUUIDToString(*id), pbPaymentRequest.Invoices[0].GetPaymentRequestId())
+
+	t.Run("Should Return Error (invalid status)", func(t *testing.T) {
+		_, err := DbPaymentRequestStatusToPbPaymentRequestStatus(db.PaymentRequestStatusType(uuid.NewString()))
+		assert.ErrorIs(t, err, invalidDbStatusTypeErr)
+	})
+}
+
+func TestDbPaymentOutcomeToPbPaymentOutcome(t *testing.T) {
+	t.Run("Should Map Outcomes", func(t *testing.T) {
+		outcome, err := DbPaymentOutcomeToPbPaymentOutcome(db.PaymentOutcomeTypeOVERPAID)
+		assert.NoError(t, err)
+		assert.Equal(t, pb_v1.PaymentOutcomeType_OVERPAID, outcome)
+
+		outcome, err = DbPaymentOutcomeToPbPaymentOutcome(db.PaymentOutcomeTypeUNDERPAID)
+		assert.NoError(t, err)
+		assert.Equal(t, pb_v1.PaymentOutcomeType_UNDERPAID, outcome)
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := DbPaymentOutcomeToPbPaymentOutcome(db.PaymentOutcomeType(uuid.NewString()))
+		assert.ErrorIs(t, err, invalidDbPaymentOutcomeTypeErr)
+	})
+}
+
+func TestDbInvoiceEventTypeToPbInvoiceEventType(t *testing.T) {
+	t.Run("Should Map Event Types", func(t *testing.T) {
+		eventType, err := DbInvoiceEventTypeToPbInvoiceEventType(db.InvoiceEventTypeSTATUSCHANGED)
+		assert.NoError(t, err)
+		assert.Equal(t, pb_v1.InvoiceEventType_STATUS_CHANGED, eventType)
+
+		eventType, err = DbInvoiceEventTypeToPbInvoiceEventType(db.InvoiceEventTypeCONFIRMATIONPROGRESS)
+		assert.NoError(t, err)
+		assert.Equal(t, pb_v1.InvoiceEventType_CONFIRMATION_PROGRESS, eventType)
+
+		eventType, err = DbInvoiceEventTypeToPbInvoiceEventType(db.InvoiceEventTypeMEMPOOLDROPPED)
+		assert.NoError(t, err)
+		assert.Equal(t, pb_v1.InvoiceEventType_MEMPOOL_DROPPED, eventType)
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := DbInvoiceEventTypeToPbInvoiceEventType(db.InvoiceEventType(uuid.NewString()))
+		assert.ErrorIs(t, err, invalidDbInvoiceEventTypeErr)
+	})
+}
+
+func TestQrCodeMapping(t *testing.T) {
+	t.Run("Should Map Formats", func(t *testing.T) {
+		pbFormats := []pb_v1.QrCodeFormat{pb_v1.QrCodeFormat_PNG, pb_v1.QrCodeFormat_SVG}
+		formats := []qr.Format{qr.PNG, qr.SVG}
+
+		for i := 0; i < len(pbFormats); i++ {
+			format, err := PbQrCodeFormatToQrFormat(pbFormats[i])
+			assert.NoError(t, err)
+			assert.Equal(t, formats[i], format)
+		}
+	})
+
+	t.Run("Should Map Error Correction Levels", func(t *testing.T) {
+		pbLevels := []pb_v1.QrCodeErrorCorrection{pb_v1.QrCodeErrorCorrection_LOW, pb_v1.QrCodeErrorCorrection_MEDIUM, pb_v1.QrCodeErrorCorrection_QUARTILE, pb_v1.QrCodeErrorCorrection_HIGH}
+		levels := []qr.Level{qr.LowLevel, qr.MediumLevel, qr.QuartileLevel, qr.HighLevel}
+
+		for i := 0; i < len(pbLevels); i++ {
+			level, err := PbQrCodeErrorCorrectionToQrLevel(pbLevels[i])
+			assert.NoError(t, err)
+			assert.Equal(t, levels[i], level)
+		}
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := PbQrCodeFormatToQrFormat(math.MaxInt32)
+		assert.ErrorIs(t, err, invalidProtoBufQrCodeFormatErr)
+
+		_, err = PbQrCodeErrorCorrectionToQrLevel(math.MaxInt32)
+		assert.ErrorIs(t, err, invalidProtoBufQrCodeErrorCorrectionErr)
+	})
+}
+
+func TestWebhookDeliveryStatusMapping(t *testing.T) {
+	dbStatuses := []db.WebhookDeliveryStatusType{db.WebhookDeliveryStatusTypePENDING, db.WebhookDeliveryStatusTypeDELIVERED, db.WebhookDeliveryStatusTypeDEAD}
+	pbStatuses := []pb_v1.WebhookDeliveryStatusType{pb_v1.WebhookDeliveryStatusType_PENDING, pb_v1.WebhookDeliveryStatusType_DELIVERED, pb_v1.WebhookDeliveryStatusType_DEAD}
+
+	t.Run("Should Map Statuses Both Ways", func(t *testing.T) {
+		for i := 0; i < len(dbStatuses); i++ {
+			pbStatus, err := DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(dbStatuses[i])
+			assert.NoError(t, err)
+			assert.Equal(t, pbStatuses[i], pbStatus)
+
+			dbStatus, err := PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(pbStatuses[i])
+			assert.NoError(t, err)
+			assert.Equal(t, dbStatuses[i], dbStatus)
+		}
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		_, err := DbWebhookDeliveryStatusToPbWebhookDeliveryStatus(db.WebhookDeliveryStatusType(uuid.NewString()))
+		assert.ErrorIs(t, err, invalidDbStatusTypeErr)
+
+		_, err = PbWebhookDeliveryStatusToDbWebhookDeliveryStatus(math.MaxInt32)
+		assert.ErrorIs(t, err, invalidProtoBufStatusTypeErr)
+	})
+}
+
+func TestPgNumericToBigInt(t *testing.T) {
+	t.Run("Should Convert Integer Numeric", func(t *testing.T) {
+		expected, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+		res, err := PgNumericToBigInt(BigIntToPgNumeric(expected))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, expected.Cmp(res))
+
+		res, err = PgNumericToBigInt(pgtype.Numeric{Int: big.NewInt(15), Exp: 2, Valid: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1500), res.Int64())
+
+		res, err = PgNumericToBigInt(pgtype.Numeric{Int: big.NewInt(1500), Exp: -2, Valid: true})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(15), res.Int64())
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		invalidNumerics := []pgtype.Numeric{
+			{},
+			{NaN: true, Valid: true},
+			{Int: big.NewInt(15), Exp: -1, Valid: true},
+			{Int: big.NewInt(1), InfinityModifier: pgtype.Infinity, Valid: true},
+		}
+
+		for i := 0; i < len(invalidNumerics); i++ {
+			_, err := PgNumericToBigInt(invalidNumerics[i])
+			assert.ErrorIs(t, err, invalidNumericErr)
+		}
+	})
+}
+
+func TestStringToBigInt(t *testing.T) {
+	t.Run("Should Parse Amount", func(t *testing.T) {
+		res, err := StringToBigInt("100000000000000000000")
+		assert.NoError(t, err)
+		assert.Equal(t, "100000000000000000000", res.String())
+	})
+
+	t.Run("Should Return Error", func(t *testing.T) {
+		amounts := []string{"", "1.5", "1e18", "0x10", "abc"}
+		for i := 0; i < len(amounts); i++ {
+			_, err := StringToBigInt(amounts[i])
+			assert.ErrorIs(t, err, invalidAmountErr)
+		}
+	})
 }
@@ -0,0 +1,249 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/dto"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	SignatureHeader  string = "X-GoiPay-Signature"
+	TimestampHeader  string = "X-GoiPay-Timestamp"
+	DeliveryIdHeader string = "X-GoiPay-Delivery-Id"
+)
+
+const (
+	dispatch_interval   time.Duration = 5 * time.Second
+	dispatch_batch_size int32         = 50
+	request_timeout     time.Duration = 10 * time.Second
+	max_attempts        int32         = 10
+	base_backoff        time.Duration = 10 * time.Second
+	max_backoff         time.Duration = 6 * time.Hour
+	max_error_length    int           = 512
+	// max_concurrent_deliveries bounds the number of webhook requests in flight.
+	max_concurrent_deliveries int = 10
+	// claim_timeout is how long the claimed deliveries aren't picked up again, so the ones of a crashed dispatcher get retried.
+	// It's longer than a batch takes to deliver.
+	claim_timeout time.Duration = 5 * time.Minute
+)
+
+// NewPayload builds the JSON body of a webhook request. It has the same shape as InvoiceStatusStreamResponse.
+func NewPayload(event *dto.InvoiceEvent) ([]byte, error) {
+	return protojson.Marshal(util.DtoInvoiceEventToPbInvoiceStatusStreamResponse(event))
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func nextBackoff(attempts int32) time.Duration {
+	backoff := base_backoff
+	for i := int32(0); i < attempts; i++ {
+		backoff *= 2
+		if backoff >= max_backoff {
+			return max_backoff
+		}
+	}
+
+	return backoff
+}
+
+type Dispatcher struct {
+	ctx context.Context
+	log *zerolog.Logger
+
+	dbConnPool *pgxpool.Pool
+	client     *http.Client
+
+	dispatchInterval time.Duration
+}
+
+func (d *Dispatcher) deliver(delivery *db.ClaimDueWebhookDeliveriesRow) (int, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().UTC().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+	req.Header.Set(DeliveryIdHeader, util.PgUUIDToString(delivery.ID))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected status code %v", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// handleDeliveryResult records the result of the delivery attempt, which was already counted when the delivery got claimed.
+func (d *Dispatcher) handleDeliveryResult(delivery *db.ClaimDueWebhookDeliveriesRow, code int, deliveryErr error) error {
+	q, tx, err := util.InitDbQueriesWithTx(d.ctx, d.dbConnPool)
+	if err != nil {
+		d.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return err
+	}
+	defer tx.Rollback(d.ctx)
+
+	var responseCode pgtype.Int4
+	if code != 0 {
+		responseCode = pgtype.Int4{Int32: int32(code), Valid: true}
+	}
+
+	if deliveryErr == nil {
+		if _, err := q.MarkWebhookDeliveryDelivered(d.ctx, db.MarkWebhookDeliveryDeliveredParams{ID: delivery.ID, LastResponseCode: responseCode}); err != nil {
+			d.log.Err(err).Str("queryName", "MarkWebhookDeliveryDelivered").Msg(util.DefaultFailedSqlQueryMsg)
+			return err
+		}
+	} else {
+		status := db.WebhookDeliveryStatusTypePENDING
+		if delivery.Attempts >= max_attempts {
+			status = db.WebhookDeliveryStatusTypeDEAD
+		}
+
+		errMsg := deliveryErr.Error()
+		if len(errMsg) > max_error_length {
+			errMsg = errMsg[:max_error_length]
+		}
+
+		if _, err := q.MarkWebhookDeliveryFailed(d.ctx, db.MarkWebhookDeliveryFailedParams{
+			ID:               delivery.ID,
+			Status:           status,
+			NextAttemptAt:    pgtype.Timestamptz{Time: time.Now().UTC().Add(nextBackoff(delivery.Attempts - 1)), Valid: true},
+			LastResponseCode: responseCode,
+			LastError:        pgtype.Text{String: errMsg, Valid: true},
+		}); err != nil {
+			d.log.Err(err).Str("queryName", "MarkWebhookDeliveryFailed").Msg(util.DefaultFailedSqlQueryMsg)
+			return err
+		}
+	}
+
+	if err := tx.Commit(d.ctx); err != nil {
+		d.log.Err(err).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return err
+	}
+
+	return nil
+}
+
+// claimDueDeliveries counts an attempt for every due delivery and postpones it by claim_timeout, so no other dispatcher
+// picks it up while it's being delivered.
+func (d *Dispatcher) claimDueDeliveries() ([]db.ClaimDueWebhookDeliveriesRow, error) {
+	q, tx, err := util.InitDbQueriesWithTx(d.ctx, d.dbConnPool)
+	if err != nil {
+		d.log.Err(err).Msg(util.DefaultFailedSqlTxInitMsg)
+		return nil, err
+	}
+	defer tx.Rollback(d.ctx)
+
+	deliveries, err := q.ClaimDueWebhookDeliveries(d.ctx, db.ClaimDueWebhookDeliveriesParams{
+		ClaimedUntil: pgtype.Timestamptz{Time: time.Now().UTC().Add(claim_timeout), Valid: true},
+		Limit:        dispatch_batch_size,
+	})
+	if err != nil {
+		d.log.Err(err).Str("queryName", "ClaimDueWebhookDeliveries").Msg(util.DefaultFailedSqlQueryMsg)
+		return nil, err
+	}
+
+	if err := tx.Commit(d.ctx); err != nil {
+		d.log.Err(err).Msg(util.DefaultFailedSqlTxCommitMsg)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// dispatchBatch delivers the due webhooks and returns the number of processed deliveries. The requests are sent outside
+// of any db tx, at most max_concurrent_deliveries at a time.
+func (d *Dispatcher) dispatchBatch() (int, error) {
+	deliveries, err := d.claimDueDeliveries()
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max_concurrent_deliveries)
+	for i := 0; i < len(deliveries); i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(delivery *db.ClaimDueWebhookDeliveriesRow) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			code, err := d.deliver(delivery)
+			if err != nil {
+				d.log.Debug().Err(err).Str("deliveryId", util.PgUUIDToString(delivery.ID)).Msg("Webhook delivery failed")
+			}
+
+			// The delivery is retried once its claim expires.
+			d.handleDeliveryResult(delivery, code, err)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) dispatch() {
+	for {
+		n, err := d.dispatchBatch()
+		if err != nil || n < int(dispatch_batch_size) {
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) Start() {
+	go func() {
+		for {
+			select {
+			case <-time.After(d.dispatchInterval):
+				d.dispatch()
+			case <-d.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func NewDispatcher(ctx context.Context, dbConnPool *pgxpool.Pool, log *zerolog.Logger) *Dispatcher {
+	return &Dispatcher{
+		ctx:              ctx,
+		log:              log,
+		dbConnPool:       dbConnPool,
+		client:           &http.Client{Timeout: request_timeout},
+		dispatchInterval: dispatch_interval,
+	}
+}
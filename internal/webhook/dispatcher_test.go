@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	t.Run("Should Return Deterministic Signature", func(t *testing.T) {
+		body := []byte(`{"sequence":"1"}`)
+
+		assert.Equal(t, Sign("secret", 1700000000, body), Sign("secret", 1700000000, body))
+		assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("other", 1700000000, body))
+		assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("secret", 1700000001, body))
+		assert.Len(t, Sign("secret", 1700000000, body), 64)
+	})
+}
+
+func TestNextBackoff(t *testing.T) {
+	t.Run("Should Double Backoff", func(t *testing.T) {
+		assert.Equal(t, base_backoff, nextBackoff(0))
+		assert.Equal(t, 2*base_backoff, nextBackoff(1))
+		assert.Equal(t, 8*base_backoff, nextBackoff(3))
+	})
+
+	t.Run("Should Cap Backoff", func(t *testing.T) {
+		assert.Equal(t, max_backoff, nextBackoff(max_attempts*10))
+	})
+}
+
+func TestDeliver(t *testing.T) {
+	logger := zerolog.Nop()
+	d := NewDispatcher(context.Background(), nil, &logger)
+
+	deliveryId, err := util.StringToPgUUID(uuid.NewString())
+	if err != nil {
+		log.Fatal(err)
+	}
+	delivery := db.ClaimDueWebhookDeliveriesRow{ID: *deliveryId, Payload: []byte(`{"sequence":"1"}`), Secret: "secret"}
+
+	t.Run("Should Deliver Signed Payload", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+			assert.NoError(t, err)
+			assert.Equal(t, Sign(delivery.Secret, timestamp, body), r.Header.Get(SignatureHeader))
+			assert.Equal(t, util.PgUUIDToString(delivery.ID), r.Header.Get(DeliveryIdHeader))
+			assert.Equal(t, delivery.Payload, body)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		delivery.Url = server.URL
+		code, err := d.deliver(&delivery)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, code)
+	})
+
+	t.Run("Should Return Error (non-2xx response)", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		delivery.Url = server.URL
+		code, err := d.deliver(&delivery)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+}
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS invoice_events(
+    sequence BIGSERIAL PRIMARY KEY,
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    status invoice_status_type NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now())
+);
+
+CREATE INDEX invoice_events_invoice_id_idx ON invoice_events (invoice_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invoice_events CASCADE;
+-- +goose StatementEnd
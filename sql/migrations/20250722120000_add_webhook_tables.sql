@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE webhook_delivery_status_type AS ENUM (
+  'PENDING',
+  'DELIVERED',
+  'DEAD'
+);
+
+CREATE TABLE IF NOT EXISTS webhooks(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    user_id UUID NOT NULL REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    webhook_id UUID NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    payload JSONB NOT NULL,
+    status webhook_delivery_status_type NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    last_response_code INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX webhook_deliveries_status_next_attempt_at_idx ON webhook_deliveries (status, next_attempt_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries CASCADE;
+
+DROP TABLE webhooks CASCADE;
+
+DROP TYPE webhook_delivery_status_type CASCADE;
+-- +goose StatementEnd
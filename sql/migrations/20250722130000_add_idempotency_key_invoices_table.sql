@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices ADD COLUMN idempotency_key TEXT;
+ALTER TABLE invoices ADD COLUMN idempotency_fingerprint TEXT;
+ALTER TABLE invoices 
+ADD CONSTRAINT unique_user_id_idempotency_key UNIQUE (user_id, idempotency_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices DROP CONSTRAINT unique_user_id_idempotency_key;
+ALTER TABLE invoices DROP COLUMN idempotency_fingerprint;
+ALTER TABLE invoices DROP COLUMN idempotency_key;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices ADD COLUMN external_id TEXT;
+ALTER TABLE invoices ADD COLUMN description TEXT;
+ALTER TABLE invoices ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+CREATE INDEX IF NOT EXISTS invoices_user_id_external_id_idx ON invoices(user_id, external_id);
+CREATE INDEX IF NOT EXISTS invoices_metadata_idx ON invoices USING GIN (metadata);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS invoices_metadata_idx;
+DROP INDEX IF EXISTS invoices_user_id_external_id_idx;
+ALTER TABLE invoices DROP COLUMN metadata;
+ALTER TABLE invoices DROP COLUMN description;
+ALTER TABLE invoices DROP COLUMN external_id;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE invoices ADD COLUMN fiat_amount DOUBLE PRECISION;
+ALTER TABLE invoices ADD COLUMN fiat_currency TEXT;
+ALTER TABLE invoices ADD COLUMN exchange_rate DOUBLE PRECISION;
+ALTER TABLE invoices ADD COLUMN rate_source TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices DROP COLUMN rate_source;
+ALTER TABLE invoices DROP COLUMN exchange_rate;
+ALTER TABLE invoices DROP COLUMN fiat_currency;
+ALTER TABLE invoices DROP COLUMN fiat_amount;
+-- +goose StatementEnd
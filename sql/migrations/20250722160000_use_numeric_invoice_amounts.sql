@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE FUNCTION coin_decimals(coin coin_type) RETURNS INT AS $$
+    SELECT CASE coin
+        WHEN 'XMR' THEN 12
+        WHEN 'BTC' THEN 8
+        WHEN 'LTC' THEN 8
+        WHEN 'TON' THEN 9
+        WHEN 'USDT_ERC20' THEN 6
+        WHEN 'USDC_ERC20' THEN 6
+        WHEN 'ATOM_ERC20' THEN 6
+        WHEN 'WBTC_ERC20' THEN 8
+        WHEN 'WBTC_BEP20' THEN 8
+        WHEN 'DOGE_BEP20' THEN 8
+        WHEN 'TRX_BEP20' THEN 6
+        ELSE 18
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- Amounts are stored in atomic units (piconero, satoshi, wei, etc.)
+ALTER TABLE invoices ALTER COLUMN required_amount TYPE NUMERIC(78, 0) USING round(required_amount::numeric * power(10::numeric, coin_decimals(coin)));
+ALTER TABLE invoices ALTER COLUMN actual_amount TYPE NUMERIC(78, 0) USING round(actual_amount::numeric * power(10::numeric, coin_decimals(coin)));
+
+DROP FUNCTION coin_decimals(coin_type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE FUNCTION coin_decimals(coin coin_type) RETURNS INT AS $$
+    SELECT CASE coin
+        WHEN 'XMR' THEN 12
+        WHEN 'BTC' THEN 8
+        WHEN 'LTC' THEN 8
+        WHEN 'TON' THEN 9
+        WHEN 'USDT_ERC20' THEN 6
+        WHEN 'USDC_ERC20' THEN 6
+        WHEN 'ATOM_ERC20' THEN 6
+        WHEN 'WBTC_ERC20' THEN 8
+        WHEN 'WBTC_BEP20' THEN 8
+        WHEN 'DOGE_BEP20' THEN 8
+        WHEN 'TRX_BEP20' THEN 6
+        ELSE 18
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE invoices ALTER COLUMN required_amount TYPE DOUBLE PRECISION USING (required_amount / power(10::numeric, coin_decimals(coin)))::double precision;
+ALTER TABLE invoices ALTER COLUMN actual_amount TYPE DOUBLE PRECISION USING (actual_amount / power(10::numeric, coin_decimals(coin)))::double precision;
+
+DROP FUNCTION coin_decimals(coin_type);
+-- +goose StatementEnd
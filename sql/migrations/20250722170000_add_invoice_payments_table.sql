@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE invoice_status_type ADD VALUE 'PARTIALLY_PAID';
+
+CREATE TABLE IF NOT EXISTS invoice_payments(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices (id),
+    tx_id TEXT NOT NULL,
+    amount NUMERIC(78, 0) NOT NULL,
+    confirmations BIGINT NOT NULL DEFAULT 0,
+    block_height BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    CONSTRAINT unique_invoice_id_tx_id UNIQUE (invoice_id, tx_id)
+);
+
+CREATE INDEX invoice_payments_tx_id_idx ON invoice_payments (tx_id);
+
+-- Invoices paid before this migration are paid by a single tx.
+INSERT INTO invoice_payments(invoice_id, tx_id, amount, confirmations)
+SELECT id, tx_id, actual_amount, CASE WHEN status = 'CONFIRMED' THEN confirmations_required ELSE 0 END
+FROM invoices
+WHERE tx_id IS NOT NULL AND actual_amount IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE invoice_payments CASCADE;
+
+UPDATE invoices SET status = 'PENDING', actual_amount = NULL WHERE status = 'PARTIALLY_PAID';
+-- +goose StatementEnd
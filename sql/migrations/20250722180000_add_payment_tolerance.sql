@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE payment_outcome_type AS ENUM (
+  'OVERPAID',
+  'UNDERPAID'
+);
+
+-- Default tolerance for the user's invoices. Absolute amounts are coin specific, so only a percentage is supported here.
+ALTER TABLE users ADD COLUMN tolerance_percent DOUBLE PRECISION;
+
+ALTER TABLE invoices ADD COLUMN tolerance_percent DOUBLE PRECISION;
+ALTER TABLE invoices ADD COLUMN tolerance_amount NUMERIC(78, 0);
+ALTER TABLE invoices ADD COLUMN payment_outcome payment_outcome_type;
+ALTER TABLE invoices ADD COLUMN payment_outcome_amount NUMERIC(78, 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices DROP COLUMN payment_outcome_amount;
+ALTER TABLE invoices DROP COLUMN payment_outcome;
+ALTER TABLE invoices DROP COLUMN tolerance_amount;
+ALTER TABLE invoices DROP COLUMN tolerance_percent;
+
+ALTER TABLE users DROP COLUMN tolerance_percent;
+
+DROP TYPE payment_outcome_type CASCADE;
+-- +goose StatementEnd
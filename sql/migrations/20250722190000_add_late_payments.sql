@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE invoice_status_type ADD VALUE 'PAID_AFTER_EXPIRY';
+
+-- A released address isn't handed out again before available_at, so a late payment can't be credited to another invoice.
+ALTER TABLE crypto_addresses ADD COLUMN available_at TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE crypto_addresses DROP COLUMN available_at;
+
+UPDATE invoices SET status = 'EXPIRED' WHERE status = 'PAID_AFTER_EXPIRY';
+-- +goose StatementEnd
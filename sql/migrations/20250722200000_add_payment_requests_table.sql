@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE payment_request_status_type AS ENUM (
+  'PENDING',
+  'PAID',
+  'EXPIRED'
+);
+
+-- A payment request groups invoices in different coins for the same checkout. The first confirmed invoice pays it.
+CREATE TABLE IF NOT EXISTS payment_requests(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id),
+    status payment_request_status_type NOT NULL DEFAULT 'PENDING',
+    paid_invoice_id UUID REFERENCES invoices (id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT timezone('UTC', now()),
+    paid_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE invoices ADD COLUMN payment_request_id UUID REFERENCES payment_requests (id);
+
+CREATE INDEX invoices_payment_request_id_idx ON invoices (payment_request_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices DROP COLUMN payment_request_id;
+
+DROP TABLE payment_requests CASCADE;
+
+DROP TYPE payment_request_status_type CASCADE;
+-- +goose StatementEnd
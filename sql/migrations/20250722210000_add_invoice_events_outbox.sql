@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- invoice_events doubles as an outbox: events are written in the same tx as the status change and published afterwards.
+ALTER TABLE invoice_events ADD COLUMN published_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE invoice_events SET published_at = created_at;
+
+CREATE INDEX invoice_events_unpublished_idx ON invoice_events (sequence) WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX invoice_events_unpublished_idx;
+
+ALTER TABLE invoice_events DROP COLUMN published_at;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE invoice_event_type AS ENUM (
+  'STATUS_CHANGED',
+  'CONFIRMATION_PROGRESS'
+);
+
+ALTER TABLE invoice_events ADD COLUMN type invoice_event_type NOT NULL DEFAULT 'STATUS_CHANGED';
+
+-- The lowest confirmation count among the invoice payments.
+ALTER TABLE invoices ADD COLUMN confirmations INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoices DROP COLUMN confirmations;
+
+ALTER TABLE invoice_events DROP COLUMN type;
+
+DROP TYPE invoice_event_type CASCADE;
+-- +goose StatementEnd
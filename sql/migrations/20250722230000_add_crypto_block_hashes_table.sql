@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Hashes of the recently synced blocks, used to find the fork point of a chain reorganisation.
+CREATE TABLE IF NOT EXISTS crypto_block_hashes(
+    coin coin_type NOT NULL REFERENCES crypto_cache (coin),
+    height BIGINT NOT NULL,
+    hash VARCHAR(128) NOT NULL,
+    PRIMARY KEY (coin, height)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE crypto_block_hashes CASCADE;
+-- +goose StatementEnd
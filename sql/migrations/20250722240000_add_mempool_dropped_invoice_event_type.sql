@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TYPE invoice_event_type ADD VALUE 'MEMPOOL_DROPPED';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE invoice_events SET type = 'STATUS_CHANGED' WHERE type = 'MEMPOOL_DROPPED';
+-- +goose StatementEnd
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The invoices and the addresses may be in any coin of the token registry, so their coin isn't limited to coin_type anymore.
+ALTER TABLE invoices ALTER COLUMN coin TYPE VARCHAR(64) USING coin::text;
+ALTER TABLE crypto_addresses ALTER COLUMN coin TYPE VARCHAR(64) USING coin::text;
+
+-- The tokens accepted on the chain with the given id. The coin is the identifier used by the API and the invoices.
+CREATE TABLE IF NOT EXISTS tokens(
+    coin VARCHAR(64) NOT NULL,
+    symbol VARCHAR(32) NOT NULL,
+    chain coin_type NOT NULL,
+    chain_id BIGINT NOT NULL,
+    contract_address VARCHAR(42) NOT NULL,
+    decimals INT NOT NULL CHECK (decimals >= 0 AND decimals <= 77),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (chain_id, coin),
+    UNIQUE (chain_id, contract_address)
+);
+
+INSERT INTO tokens(coin, symbol, chain, chain_id, contract_address, decimals) VALUES
+    ('USDT_ERC20', 'USDT', 'ETH', 1, '0xdAC17F958D2ee523a2206206994597C13D831ec7', 6),
+    ('USDC_ERC20', 'USDC', 'ETH', 1, '0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48', 6),
+    ('DAI_ERC20', 'DAI', 'ETH', 1, '0x6B175474E89094C44Da98b954EedeAC495271d0F', 18),
+    ('WBTC_ERC20', 'WBTC', 'ETH', 1, '0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599', 8),
+    ('UNI_ERC20', 'UNI', 'ETH', 1, '0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984', 18),
+    ('LINK_ERC20', 'LINK', 'ETH', 1, '0x514910771AF9Ca656af840dff83E8264EcF986CA', 18),
+    ('AAVE_ERC20', 'AAVE', 'ETH', 1, '0x7Fc66500c84A76Ad7e9c93437bFc5Ac33E2DDaE9', 18),
+    ('CRV_ERC20', 'CRV', 'ETH', 1, '0xD533a949740bb3306d119CC777fa900bA034cd52', 18),
+    ('MATIC_ERC20', 'MATIC', 'ETH', 1, '0x7D1AfA7B718fb893dB30A3aBc0Cfc608AaCfeBB0', 18),
+    ('SHIB_ERC20', 'SHIB', 'ETH', 1, '0x95aD61b0a150d79219dCF64E1E6Cc01f0B64C4cE', 18),
+    ('BNB_ERC20', 'BNB', 'ETH', 1, '0xB8c77482e45F1F44dE1745F52C74426C631bDD52', 18),
+    ('ATOM_ERC20', 'ATOM', 'ETH', 1, '0x8D983cb9388EaC77af0474fA441C4815500Cb7BB', 6),
+    ('ARB_ERC20', 'ARB', 'ETH', 1, '0xB50721BCf8d664c30412Cfbc6cf7a15145234ad1', 18),
+    ('BSC-USD_BEP20', 'BSC-USD', 'BNB', 56, '0x55d398326f99059fF775485246999027B3197955', 18),
+    ('USDC_BEP20', 'USDC', 'BNB', 56, '0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d', 18),
+    ('DAI_BEP20', 'DAI', 'BNB', 56, '0x1AF3F329e8BE154074D8769D1FFa4eE058B1DBc3', 18),
+    ('WBTC_BEP20', 'WBTC', 'BNB', 56, '0x0555E30da8f98308EdB960aa94C0Db47230d2B9c', 8),
+    ('UNI_BEP20', 'UNI', 'BNB', 56, '0xBf5140A22578168FD562DCcF235E5D43A02ce9B1', 18),
+    ('LINK_BEP20', 'LINK', 'BNB', 56, '0xF8A0BF9cF54Bb92F17374d9e9A321E6a111a51bD', 18),
+    ('AAVE_BEP20', 'AAVE', 'BNB', 56, '0xfb6115445Bff7b52FeB98650C87f44907E58f802', 18),
+    ('MATIC_BEP20', 'MATIC', 'BNB', 56, '0xCC42724C6683B7E57334c4E856f4c9965ED682bD', 18),
+    ('SHIB_BEP20', 'SHIB', 'BNB', 56, '0x2859e4544C4bB03966803b044A93563Bd2D0DD4D', 18),
+    ('BUSD_BEP20', 'BUSD', 'BNB', 56, '0xe9e7CEA3DedcA5984780Bafc599bD69ADd087D56', 18),
+    ('ATOM_BEP20', 'ATOM', 'BNB', 56, '0x0Eb3a705fc54725037CC9e008bDede697f62F335', 18),
+    ('ARB_BEP20', 'ARB', 'BNB', 56, '0xa050FFb3eEb8200eEB7F61ce34FF644420FD3522', 18),
+    ('ETH_BEP20', 'ETH', 'BNB', 56, '0x2170Ed0880ac9A755fd29B2688956BD959F933F8', 18),
+    ('XRP_BEP20', 'XRP', 'BNB', 56, '0x1D2F0da169ceB9fC7B3144628dB156f3F6c60dBE', 18),
+    ('ADA_BEP20', 'ADA', 'BNB', 56, '0x3EE2200Efb3400fAbB9AacF31297cBdD1d435D47', 18),
+    ('TRX_BEP20', 'TRX', 'BNB', 56, '0xCE7de646e7208a4Ef112cb6ed5038FA6cC6b12e3', 6),
+    ('DOGE_BEP20', 'DOGE', 'BNB', 56, '0xbA2aE424d960c26247Dd6c32edC70B295c744C43', 8),
+    ('LTC_BEP20', 'LTC', 'BNB', 56, '0x4338665CBB7B2485A8855A139b75D5e34AB0DB94', 18),
+    ('BCH_BEP20', 'BCH', 'BNB', 56, '0x8fF795a6F4D97E7887C79beA79aba5cc76444aDf', 18),
+    ('TWT_BEP20', 'TWT', 'BNB', 56, '0x4B0F1812e5Df2A09796481Ff14017e6005508003', 18),
+    ('AVAX_BEP20', 'AVAX', 'BNB', 56, '0x1CE0c2827e2eF14D5C4f29a091d735A204794041', 18),
+    ('CAKE_BEP20', 'CAKE', 'BNB', 56, '0x0E09FaBB73Bd3Ade0a17ECC321fD13a19e81cE82', 18);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE tokens CASCADE;
+
+ALTER TABLE crypto_addresses ALTER COLUMN coin TYPE coin_type USING coin::coin_type;
+ALTER TABLE invoices ALTER COLUMN coin TYPE coin_type USING coin::coin_type;
+-- +goose StatementEnd
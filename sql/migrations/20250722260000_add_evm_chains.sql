@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The EVM chains are declared in the config, so the synced coins aren't limited to coin_type anymore.
+ALTER TABLE crypto_block_hashes DROP CONSTRAINT crypto_block_hashes_coin_fkey;
+ALTER TABLE crypto_cache ALTER COLUMN coin TYPE VARCHAR(64) USING coin::text;
+ALTER TABLE crypto_block_hashes ALTER COLUMN coin TYPE VARCHAR(64) USING coin::text;
+ALTER TABLE crypto_block_hashes ADD CONSTRAINT crypto_block_hashes_coin_fkey FOREIGN KEY (coin) REFERENCES crypto_cache (coin);
+
+ALTER TABLE tokens ALTER COLUMN chain TYPE VARCHAR(64) USING chain::text;
+
+-- The master public keys of the users for the EVM chains declared in the config.
+CREATE TABLE IF NOT EXISTS evm_crypto_data(
+    user_id UUID NOT NULL REFERENCES users (id),
+    chain VARCHAR(64) NOT NULL,
+    master_pub_key TEXT NOT NULL UNIQUE,
+    last_major_index INTEGER NOT NULL DEFAULT 0,
+    last_minor_index INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, chain)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE evm_crypto_data CASCADE;
+
+DELETE FROM tokens WHERE chain NOT IN ('ETH', 'BNB');
+ALTER TABLE tokens ALTER COLUMN chain TYPE coin_type USING chain::coin_type;
+
+DELETE FROM crypto_block_hashes WHERE coin NOT IN ('XMR', 'BTC', 'LTC', 'ETH', 'TON', 'BNB');
+DELETE FROM crypto_cache WHERE coin NOT IN ('XMR', 'BTC', 'LTC', 'ETH', 'TON', 'BNB');
+ALTER TABLE crypto_block_hashes DROP CONSTRAINT crypto_block_hashes_coin_fkey;
+ALTER TABLE crypto_cache ALTER COLUMN coin TYPE coin_type USING coin::coin_type;
+ALTER TABLE crypto_block_hashes ALTER COLUMN coin TYPE coin_type USING coin::coin_type;
+ALTER TABLE crypto_block_hashes ADD CONSTRAINT crypto_block_hashes_coin_fkey FOREIGN KEY (coin) REFERENCES crypto_cache (coin);
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- sequence is taken at insert time, so an event committed late can land below the cursor of a stream.
+-- published_sequence is taken by the outbox dispatcher, which publishes one batch at a time, so it follows the commit order.
+CREATE SEQUENCE invoice_events_published_sequence_seq;
+ALTER TABLE invoice_events ADD COLUMN published_sequence BIGINT UNIQUE;
+
+-- Keeps the cursors of the existing streams valid.
+UPDATE invoice_events SET published_sequence = sequence WHERE published_at IS NOT NULL;
+SELECT setval('invoice_events_published_sequence_seq', COALESCE((SELECT MAX(sequence) FROM invoice_events), 0) + 1, false);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE invoice_events DROP COLUMN published_sequence;
+DROP SEQUENCE invoice_events_published_sequence_seq;
+-- +goose StatementEnd
@@ -6,7 +6,8 @@ RETURNING *;
 UPDATE crypto_addresses SET is_occupied = true
 WHERE address = (
     SELECT address FROM crypto_addresses AS ca
-    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false 
+    WHERE ca.user_id = $1 AND ca.coin = $2 AND ca.is_occupied = false
+        AND (ca.available_at IS NULL OR ca.available_at <= timezone('UTC', now()))
     FOR UPDATE SKIP LOCKED
     LIMIT 1
 )
@@ -18,6 +19,24 @@ SET is_occupied = $2
 WHERE address = $1
 RETURNING *;
 
+-- name: OccupyCryptoAddressNotHeldByOtherInvoice :one
+UPDATE crypto_addresses
+SET is_occupied = true
+WHERE address = sqlc.arg('address')
+    AND NOT EXISTS (
+        SELECT 1 FROM invoices
+        WHERE crypto_address = sqlc.arg('address') AND id <> sqlc.arg('invoice_id')
+            AND status IN ('PENDING', 'PENDING_MEMPOOL', 'PARTIALLY_PAID')
+    )
+RETURNING *;
+
+-- name: ReleaseCryptoAddress :one
+UPDATE crypto_addresses
+SET is_occupied = false,
+    available_at = $2
+WHERE address = $1
+RETURNING *;
+
 -- name: DeleteAllCryptoAddressByUserIdAndCoin :many
 DELETE FROM crypto_addresses 
 WHERE user_id = $1 AND coin = $2
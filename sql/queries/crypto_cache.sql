@@ -3,9 +3,26 @@ SELECT * FROM crypto_cache
 WHERE coin = $1;
 
 
+-- name: CreateCryptoCacheIfNotExists :exec
+INSERT INTO crypto_cache(coin) VALUES ($1)
+ON CONFLICT (coin) DO NOTHING;
+
 -- name: UpdateCryptoCacheByCoin :one
 UPDATE crypto_cache 
 SET last_synced_block_height = $2,
     synced_timestamp = timezone('UTC', now())
 WHERE coin = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: FindBlockHashesByCoin :many
+SELECT * FROM crypto_block_hashes
+WHERE coin = $1
+ORDER BY height;
+
+-- name: DeleteBlockHashesByCoin :exec
+DELETE FROM crypto_block_hashes
+WHERE coin = $1;
+
+-- name: CreateBlockHashes :exec
+INSERT INTO crypto_block_hashes(coin, height, hash)
+SELECT sqlc.arg('coin')::varchar, unnest(sqlc.arg('heights')::bigint[]), unnest(sqlc.arg('hashes')::varchar[]);
@@ -175,4 +175,27 @@ SET last_minor_index = CASE
         ELSE last_major_index
     END
 WHERE id = $1
+RETURNING master_pub_key, last_major_index, last_minor_index;
+
+
+-- EVM
+-- name: UpsertKeysEVMCryptoData :one
+INSERT INTO evm_crypto_data(user_id, chain, master_pub_key) VALUES ($1, $2, $3)
+ON CONFLICT (user_id, chain) DO UPDATE
+SET master_pub_key = EXCLUDED.master_pub_key,
+    last_major_index = 0,
+    last_minor_index = 0
+RETURNING *;
+
+-- name: FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain :one
+UPDATE evm_crypto_data
+SET last_minor_index = CASE 
+        WHEN last_minor_index >= 2147483647 THEN 0
+        ELSE last_minor_index + 1
+    END,
+    last_major_index = CASE 
+        WHEN last_minor_index >= 2147483647 THEN last_major_index + 1
+        ELSE last_major_index
+    END
+WHERE user_id = $1 AND chain = $2
 RETURNING master_pub_key, last_major_index, last_minor_index;
\ No newline at end of file
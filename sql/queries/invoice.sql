@@ -5,14 +5,62 @@ INSERT INTO invoices(
     required_amount, 
     confirmations_required,
     expires_at,
-    user_id) 
-VALUES ($1, $2, $3, $4, $5, $6)
+    user_id,
+    idempotency_key,
+    idempotency_fingerprint,
+    external_id,
+    description,
+    metadata,
+    fiat_amount,
+    fiat_currency,
+    exchange_rate,
+    rate_source,
+    tolerance_percent,
+    tolerance_amount,
+    payment_request_id) 
+VALUES (
+    sqlc.arg('crypto_address'),
+    sqlc.arg('coin'),
+    sqlc.arg('required_amount'),
+    sqlc.arg('confirmations_required'),
+    sqlc.arg('expires_at'),
+    sqlc.arg('user_id'),
+    sqlc.narg('idempotency_key'),
+    sqlc.narg('idempotency_fingerprint'),
+    sqlc.narg('external_id'),
+    sqlc.narg('description'),
+    COALESCE(sqlc.narg('metadata')::jsonb, '{}'::jsonb),
+    sqlc.narg('fiat_amount'),
+    sqlc.narg('fiat_currency'),
+    sqlc.narg('exchange_rate'),
+    sqlc.narg('rate_source'),
+    sqlc.narg('tolerance_percent'),
+    sqlc.narg('tolerance_amount'),
+    sqlc.narg('payment_request_id'))
 RETURNING *;
 
+-- name: FindInvoicesByPaymentRequestId :many
+SELECT * FROM invoices
+WHERE payment_request_id = $1
+ORDER BY created_at, id;
+
+-- name: FindInvoiceByUserIdAndIdempotencyKey :one
+SELECT * FROM invoices
+WHERE user_id = $1 AND idempotency_key = $2;
+
 
 -- name: FindAllPendingInvoices :many
 SELECT * FROM invoices
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL');
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL');
+
+-- name: FindExpiredInvoicesSince :many
+SELECT * FROM invoices
+WHERE status IN ('EXPIRED', 'PAID_AFTER_EXPIRY') AND expires_at > $1;
+
+-- name: FindInvoiceByIdForUpdate :one
+SELECT * FROM invoices
+WHERE id = $1
+FOR UPDATE;
 
 
 -- name: ConfirmInvoiceById :one
@@ -22,22 +70,113 @@ SET status = 'CONFIRMED',
 WHERE id = $1
 RETURNING *;
 
+-- name: RevertConfirmedInvoiceById :one
+UPDATE invoices
+SET status = 'PENDING_MEMPOOL',
+    confirmations = 0,
+    confirmed_at = NULL,
+    expires_at = GREATEST(expires_at, sqlc.arg('expires_at')::timestamptz)
+WHERE id = sqlc.arg('id') AND status = 'CONFIRMED'
+RETURNING *;
+
 -- name: ConfirmInvoiceStatusMempoolById :one
 UPDATE invoices
 SET actual_amount = $2,
     status = 'PENDING_MEMPOOL',
-    tx_id = $3
+    tx_id = $3,
+    payment_outcome = $4,
+    payment_outcome_amount = $5
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID')
+RETURNING *;
+
+-- name: RevertInvoiceToPendingById :one
+UPDATE invoices
+SET actual_amount = sqlc.arg('actual_amount'),
+    status = sqlc.arg('status'),
+    tx_id = NULL,
+    payment_outcome = NULL,
+    payment_outcome_amount = NULL,
+    confirmations = 0
+WHERE id = sqlc.arg('id') AND status IN ('PENDING_MEMPOOL', 'PARTIALLY_PAID')
+RETURNING *;
+
+-- name: UpdateInvoiceStatusPartiallyPaidById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PARTIALLY_PAID'
+WHERE id = $1 AND status IN ('PENDING', 'PARTIALLY_PAID')
+RETURNING *;
+
+-- name: UpdateInvoiceConfirmationsById :one
+UPDATE invoices
+SET confirmations = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateInvoiceStatusPaidAfterExpiryById :one
+UPDATE invoices
+SET actual_amount = $2,
+    status = 'PAID_AFTER_EXPIRY',
+    payment_outcome = $3,
+    payment_outcome_amount = $4
+WHERE id = $1 AND status IN ('EXPIRED', 'PAID_AFTER_EXPIRY')
+RETURNING *;
+
 -- name: ExpireInvoiceById :one
 UPDATE invoices
-SET status = 'EXPIRED'
+SET status = 'EXPIRED',
+    payment_outcome = CASE WHEN status = 'PARTIALLY_PAID' THEN 'UNDERPAID' ELSE payment_outcome END,
+    payment_outcome_amount = CASE WHEN status = 'PARTIALLY_PAID' THEN required_amount - actual_amount ELSE payment_outcome_amount END
 WHERE id = $1
 RETURNING *;
 
+-- name: CancelInvoiceById :one
+UPDATE invoices
+SET status = 'CANCELLED'
+WHERE id = $1 AND status = 'PENDING'
+RETURNING *;
+
 -- name: ShiftExpiresAtForNonConfirmedInvoices :many
 UPDATE invoices
 SET expires_at = timezone('UTC', now()) + INTERVAL '5 minute'
-WHERE status IN ('PENDING', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
-RETURNING *;
\ No newline at end of file
+WHERE status IN ('PENDING', 'PARTIALLY_PAID', 'PENDING_MEMPOOL') AND (expires_at - timezone('UTC', now()) < INTERVAL '5 minutes')
+RETURNING *;
+
+-- name: FindInvoiceById :one
+SELECT * FROM invoices
+WHERE id = $1;
+
+-- name: FindInvoicesFiltered :many
+SELECT * FROM invoices
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+    AND (sqlc.narg('coin')::text IS NULL OR coin = sqlc.narg('coin'))
+    AND (sqlc.narg('status')::invoice_status_type IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at <= sqlc.narg('created_to'))
+    AND (sqlc.narg('expires_from')::timestamptz IS NULL OR expires_at >= sqlc.narg('expires_from'))
+    AND (sqlc.narg('expires_to')::timestamptz IS NULL OR expires_at <= sqlc.narg('expires_to'))
+    AND (sqlc.narg('tx_id')::text IS NULL OR tx_id = sqlc.narg('tx_id') OR EXISTS (
+        SELECT 1 FROM invoice_payments p WHERE p.invoice_id = invoices.id AND p.tx_id = sqlc.narg('tx_id')
+    ))
+    AND (sqlc.narg('external_id')::text IS NULL OR external_id = sqlc.narg('external_id'))
+    AND (sqlc.narg('metadata_key')::text IS NULL OR (
+        metadata ? sqlc.narg('metadata_key')
+        AND (sqlc.narg('metadata_value')::text IS NULL OR metadata ->> sqlc.narg('metadata_key') = sqlc.narg('metadata_value'))
+    ))
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg('limit')::int OFFSET sqlc.arg('offset')::int;
+
+
+-- name: FindInvoicesWithPaymentsAboveBlockHeightAndLock :many
+SELECT * FROM invoices
+WHERE coin = sqlc.arg('coin')
+    AND status IN ('PARTIALLY_PAID', 'PENDING_MEMPOOL', 'CONFIRMED')
+    AND id IN (SELECT invoice_id FROM invoice_payments WHERE block_height > sqlc.arg('block_height')::bigint)
+FOR UPDATE;
+
+-- name: FindInvoicesByPaymentTxIdAndLock :many
+SELECT * FROM invoices
+WHERE coin = sqlc.arg('coin')
+    AND status IN ('PENDING_MEMPOOL', 'PARTIALLY_PAID')
+    AND id IN (SELECT invoice_id FROM invoice_payments WHERE tx_id = sqlc.arg('tx_id')::text)
+FOR UPDATE;
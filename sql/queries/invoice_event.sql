@@ -0,0 +1,26 @@
+-- name: CreateInvoiceEvent :one
+INSERT INTO invoice_events(invoice_id, status, type, payload) VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: FindInvoiceEventsAfterSequence :many
+SELECT * FROM invoice_events
+WHERE published_sequence > sqlc.arg('sequence')::bigint
+ORDER BY published_sequence
+LIMIT sqlc.arg('limit');
+
+-- name: FindUnpublishedInvoiceEventsAndLock :many
+SELECT * FROM invoice_events
+WHERE published_at IS NULL
+ORDER BY sequence
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: LockInvoiceEventsPublishing :exec
+SELECT pg_advisory_xact_lock(hashtext('invoice_events_publishing'));
+
+-- name: PublishInvoiceEvent :one
+UPDATE invoice_events
+SET published_at = timezone('UTC', now()),
+    published_sequence = nextval('invoice_events_published_sequence_seq')
+WHERE sequence = $1
+RETURNING *;
@@ -0,0 +1,32 @@
+-- name: CreateInvoicePayment :one
+INSERT INTO invoice_payments(invoice_id, tx_id, amount, confirmations)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (invoice_id, tx_id) DO NOTHING
+RETURNING *;
+
+-- name: FindInvoicePaymentsByInvoiceId :many
+SELECT * FROM invoice_payments
+WHERE invoice_id = $1
+ORDER BY created_at, id;
+
+-- name: SumInvoicePaymentsByInvoiceId :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS total FROM invoice_payments
+WHERE invoice_id = $1;
+
+-- name: DeleteInvoicePaymentByInvoiceIdAndTxId :exec
+DELETE FROM invoice_payments
+WHERE invoice_id = $1 AND tx_id = $2;
+
+-- name: UpdateInvoicePaymentConfirmationsById :one
+UPDATE invoice_payments
+SET confirmations = $2,
+    block_height = $3
+WHERE id = $1
+RETURNING *;
+
+
+-- name: ResetInvoicePaymentsAboveBlockHeight :exec
+UPDATE invoice_payments
+SET confirmations = 0,
+    block_height = NULL
+WHERE block_height > sqlc.arg('block_height')::bigint AND invoice_id IN (SELECT id FROM invoices WHERE coin = sqlc.arg('coin'));
@@ -0,0 +1,32 @@
+-- name: CreatePaymentRequest :one
+INSERT INTO payment_requests(user_id)
+VALUES ($1)
+RETURNING *;
+
+-- name: FindPaymentRequestById :one
+SELECT * FROM payment_requests
+WHERE id = $1;
+
+-- name: MarkPaymentRequestPaidById :one
+UPDATE payment_requests
+SET status = 'PAID',
+    paid_invoice_id = $2,
+    paid_at = timezone('UTC', now())
+WHERE id = $1 AND status = 'PENDING'
+RETURNING *;
+
+-- name: ExpirePaymentRequestById :one
+UPDATE payment_requests
+SET status = 'EXPIRED'
+WHERE id = $1 AND status = 'PENDING' AND NOT EXISTS (
+    SELECT 1 FROM invoices
+    WHERE payment_request_id = $1 AND status IN ('PENDING', 'PENDING_MEMPOOL', 'PARTIALLY_PAID')
+)
+RETURNING *;
+
+-- name: RevertPaymentRequestPaidByInvoiceId :exec
+UPDATE payment_requests
+SET status = 'PENDING',
+    paid_invoice_id = NULL,
+    paid_at = NULL
+WHERE paid_invoice_id = $1 AND status = 'PAID';
@@ -0,0 +1,19 @@
+-- name: FindEnabledTokensByChainAndChainId :many
+SELECT * FROM tokens
+WHERE chain = $1 AND chain_id = $2 AND enabled = true
+ORDER BY coin;
+
+-- name: FindTokenByChainIdAndCoin :one
+SELECT * FROM tokens
+WHERE chain_id = $1 AND coin = $2;
+
+-- name: UpsertToken :one
+INSERT INTO tokens(coin, symbol, chain, chain_id, contract_address, decimals, enabled)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (chain_id, coin) DO UPDATE
+SET symbol = EXCLUDED.symbol,
+    chain = EXCLUDED.chain,
+    contract_address = EXCLUDED.contract_address,
+    decimals = EXCLUDED.decimals,
+    enabled = EXCLUDED.enabled
+RETURNING *;
@@ -1,14 +1,24 @@
 -- name: CreateUser :one
 INSERT INTO users DEFAULT VALUES
-RETURNING *;
+RETURNING id;
 
 -- name: CreateUserWithId :one
 INSERT INTO users(id) VALUES($1)
-RETURNING *;
+RETURNING id;
 
 -- name: UserExistsById :one
 SELECT EXISTS (
     SELECT 1
     FROM users
     WHERE id = $1
-) AS user_exists;
\ No newline at end of file
+) AS user_exists;
+
+-- name: FindTolerancePercentByUserId :one
+SELECT tolerance_percent FROM users
+WHERE id = $1;
+
+-- name: UpdateTolerancePercentByUserId :one
+UPDATE users
+SET tolerance_percent = $2
+WHERE id = $1
+RETURNING tolerance_percent;
@@ -0,0 +1,59 @@
+-- name: CreateWebhook :one
+INSERT INTO webhooks(url, secret, user_id) VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateWebhookDeliveriesByUserId :execrows
+INSERT INTO webhook_deliveries(webhook_id, invoice_id, payload)
+SELECT id, sqlc.arg('invoice_id')::uuid, sqlc.arg('payload')::jsonb FROM webhooks
+WHERE user_id = sqlc.arg('user_id');
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries as wd
+SET attempts = wd.attempts + 1,
+    next_attempt_at = sqlc.arg('claimed_until')
+FROM webhooks as w
+WHERE wd.webhook_id = w.id AND wd.id IN (
+    SELECT d.id FROM webhook_deliveries as d
+    WHERE d.status = 'PENDING' AND d.next_attempt_at <= timezone('UTC', now())
+    ORDER BY d.next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING wd.id, wd.attempts, wd.payload, w.url, w.secret;
+
+-- name: MarkWebhookDeliveryDelivered :one
+UPDATE webhook_deliveries
+SET status = 'DELIVERED',
+    last_response_code = $2,
+    last_error = NULL,
+    delivered_at = timezone('UTC', now())
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkWebhookDeliveryFailed :one
+UPDATE webhook_deliveries
+SET status = $2,
+    next_attempt_at = $3,
+    last_response_code = $4,
+    last_error = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: FindWebhookDeliveriesByUserId :many
+SELECT wd.id, wd.webhook_id, wd.invoice_id, wd.payload, wd.status, wd.attempts, wd.next_attempt_at, wd.last_response_code, wd.last_error, wd.created_at, wd.delivered_at FROM webhook_deliveries as wd
+JOIN webhooks as w ON wd.webhook_id = w.id
+WHERE w.user_id = sqlc.arg('user_id')
+    AND (sqlc.narg('status')::webhook_delivery_status_type IS NULL OR wd.status = sqlc.narg('status'))
+ORDER BY wd.created_at DESC, wd.id
+LIMIT sqlc.arg('limit')::int OFFSET sqlc.arg('offset')::int;
+
+-- name: RedeliverWebhookDeliveryByIdAndUserId :one
+UPDATE webhook_deliveries
+SET status = 'PENDING',
+    attempts = 0,
+    next_attempt_at = timezone('UTC', now())
+WHERE id = $1 AND webhook_id IN (
+    SELECT w.id FROM webhooks as w
+    WHERE w.user_id = $2
+)
+RETURNING *;
@@ -10,7 +10,7 @@ import (
 )
 
 const findCryptoAddressByAddress = `-- name: FindCryptoAddressByAddress :one
-SELECT id, address, coin, is_occupied, user_id FROM crypto_addresses
+SELECT id, address, coin, is_occupied, user_id, available_at FROM crypto_addresses
 WHERE address = $1
 `
 
@@ -23,6 +23,7 @@ func (q *Queries) FindCryptoAddressByAddress(ctx context.Context, address string
 		&i.Coin,
 		&i.IsOccupied,
 		&i.UserID,
+		&i.AvailableAt,
 	)
 	return i, err
 }
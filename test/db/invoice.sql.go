@@ -12,7 +12,7 @@ import (
 )
 
 const findAllInvoices = `-- name: FindAllInvoices :many
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
 `
 
 func (q *Queries) FindAllInvoices(ctx context.Context) ([]Invoice, error) {
@@ -37,6 +37,21 @@ func (q *Queries) FindAllInvoices(ctx context.Context) ([]Invoice, error) {
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
 		); err != nil {
 			return nil, err
 		}
@@ -49,7 +64,7 @@ func (q *Queries) FindAllInvoices(ctx context.Context) ([]Invoice, error) {
 }
 
 const findAllInvoicesByIds = `-- name: FindAllInvoicesByIds :many
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
 WHERE id = ANY($1::uuid[])
 `
 
@@ -75,6 +90,21 @@ func (q *Queries) FindAllInvoicesByIds(ctx context.Context, dollar_1 []pgtype.UU
 			&i.ExpiresAt,
 			&i.TxID,
 			&i.UserID,
+			&i.IdempotencyKey,
+			&i.IdempotencyFingerprint,
+			&i.ExternalID,
+			&i.Description,
+			&i.Metadata,
+			&i.FiatAmount,
+			&i.FiatCurrency,
+			&i.ExchangeRate,
+			&i.RateSource,
+			&i.TolerancePercent,
+			&i.ToleranceAmount,
+			&i.PaymentOutcome,
+			&i.PaymentOutcomeAmount,
+			&i.PaymentRequestID,
+			&i.Confirmations,
 		); err != nil {
 			return nil, err
 		}
@@ -87,7 +117,7 @@ func (q *Queries) FindAllInvoicesByIds(ctx context.Context, dollar_1 []pgtype.UU
 }
 
 const findInvoiceById = `-- name: FindInvoiceById :one
-SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id FROM invoices
+SELECT id, crypto_address, coin, required_amount, actual_amount, confirmations_required, created_at, confirmed_at, status, expires_at, tx_id, user_id, idempotency_key, idempotency_fingerprint, external_id, description, metadata, fiat_amount, fiat_currency, exchange_rate, rate_source, tolerance_percent, tolerance_amount, payment_outcome, payment_outcome_amount, payment_request_id, confirmations FROM invoices
 WHERE id = $1
 `
 
@@ -107,6 +137,21 @@ func (q *Queries) FindInvoiceById(ctx context.Context, id pgtype.UUID) (Invoice,
 		&i.ExpiresAt,
 		&i.TxID,
 		&i.UserID,
+		&i.IdempotencyKey,
+		&i.IdempotencyFingerprint,
+		&i.ExternalID,
+		&i.Description,
+		&i.Metadata,
+		&i.FiatAmount,
+		&i.FiatCurrency,
+		&i.ExchangeRate,
+		&i.RateSource,
+		&i.TolerancePercent,
+		&i.ToleranceAmount,
+		&i.PaymentOutcome,
+		&i.PaymentOutcomeAmount,
+		&i.PaymentRequestID,
+		&i.Confirmations,
 	)
 	return i, err
 }
@@ -3,7 +3,9 @@ package db_test
 import (
 	"context"
 	"log"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/test"
@@ -167,6 +169,117 @@ func TestUpdateIsOccupiedByCryptoAddress(t *testing.T) {
 	})
 }
 
+func TestReleaseCryptoAddress(t *testing.T) {
+	t.Run("Should Not Return The Address Until The Cooldown Is Over", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			createdAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: true, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			addr, err := q.ReleaseCryptoAddress(ctx, db.ReleaseCryptoAddressParams{Address: createdAddr.Address, AvailableAt: pgtype.Timestamptz{Time: time.Now().UTC().Add(1 * time.Hour), Valid: true}})
+			assert.NoError(t, err)
+			assert.False(t, addr.IsOccupied)
+
+			_, err = q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeBTC})
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+
+	t.Run("Should Return The Address After The Cooldown", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			createdAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: true, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := q.ReleaseCryptoAddress(ctx, db.ReleaseCryptoAddressParams{Address: createdAddr.Address, AvailableAt: pgtype.Timestamptz{Time: time.Now().UTC().Add(-1 * time.Minute), Valid: true}}); err != nil {
+				log.Fatal(err)
+			}
+
+			addr, err := q.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoin(ctx, db.FindNonOccupiedCryptoAddressAndLockByUserIdAndCoinParams{UserID: userId, Coin: db.CoinTypeBTC})
+			assert.NoError(t, err)
+			assert.Equal(t, createdAddr.Address, addr.Address)
+		})
+	})
+}
+
+func TestOccupyCryptoAddressNotHeldByOtherInvoice(t *testing.T) {
+	createInvoice := func(ctx context.Context, q *db.Queries, addr *db.CryptoAddress) db.Invoice {
+		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:         addr.Address,
+			Coin:                  addr.Coin,
+			RequiredAmount:        pgtype.Numeric{Int: big.NewInt(1), Valid: true},
+			ConfirmationsRequired: 1,
+			ExpiresAt:             pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+			UserID:                addr.UserID,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		return invoice
+	}
+
+	t.Run("Should Occupy The Address", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+			createdAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: false, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+			invoice := createInvoice(ctx, q, &createdAddr)
+
+			addr, err := q.OccupyCryptoAddressNotHeldByOtherInvoice(ctx, db.OccupyCryptoAddressNotHeldByOtherInvoiceParams{Address: createdAddr.Address, InvoiceID: invoice.ID})
+			assert.NoError(t, err)
+			assert.True(t, addr.IsOccupied)
+		})
+	})
+
+	t.Run("Should Return SQL Error (no rows (held by another invoice))", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+			createdAddr, err := q.CreateCryptoAddress(ctx, db.CreateCryptoAddressParams{Address: uuid.NewString(), Coin: db.CoinTypeBTC, IsOccupied: true, UserID: userId})
+			if err != nil {
+				log.Fatal(err)
+			}
+			invoice := createInvoice(ctx, q, &createdAddr)
+			createInvoice(ctx, q, &createdAddr)
+
+			_, err = q.OccupyCryptoAddressNotHeldByOtherInvoice(ctx, db.OccupyCryptoAddressNotHeldByOtherInvoiceParams{Address: createdAddr.Address, InvoiceID: invoice.ID})
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
+
 func TestDeleteAllCryptoAddressByUserIdAndCoin(t *testing.T) {
 	gen := func(ctx context.Context, q *db.Queries) ([]pgtype.UUID, []db.CryptoAddress) {
 		userId1, err := q.CreateUser(ctx)
@@ -9,7 +9,6 @@ import (
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/test"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,13 +32,26 @@ func TestFindCryptoCacheByCoin(t *testing.T) {
 			q := db.New(tx)
 
 			_, err := q.FindCryptoCacheByCoin(ctx, "test")
-			var pgErr *pgconn.PgError
-			assert.ErrorAs(t, err, &pgErr)
-			assert.Equal(t, "22P02", pgErr.Code)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
 		})
 	})
 }
 
+func TestCreateCryptoCacheIfNotExists(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		coin := db.CoinType("POLYGON")
+
+		assert.NoError(t, q.CreateCryptoCacheIfNotExists(ctx, coin))
+		assert.NoError(t, q.CreateCryptoCacheIfNotExists(ctx, coin))
+
+		_, err := q.FindCryptoCacheByCoin(ctx, coin)
+		assert.NoError(t, err)
+	})
+}
+
 func TestUpdateCryptoCacheByCoin(t *testing.T) {
 	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
 		ctx := context.Background()
@@ -59,3 +71,25 @@ func TestUpdateCryptoCacheByCoin(t *testing.T) {
 		})
 	})
 }
+
+func TestBlockHashes(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		coin := db.CoinTypeBTC
+
+		err := q.CreateBlockHashes(ctx, db.CreateBlockHashesParams{Coin: string(coin), Heights: []int64{11, 10}, Hashes: []string{"hash11", "hash10"}})
+		assert.NoError(t, err)
+
+		hashes, err := q.FindBlockHashesByCoin(ctx, coin)
+		assert.NoError(t, err)
+		assert.Equal(t, []db.CryptoBlockHash{{Coin: coin, Height: 10, Hash: "hash10"}, {Coin: coin, Height: 11, Hash: "hash11"}}, hashes)
+
+		assert.NoError(t, q.DeleteBlockHashesByCoin(ctx, coin))
+
+		hashes, err = q.FindBlockHashesByCoin(ctx, coin)
+		assert.NoError(t, err)
+		assert.Len(t, hashes, 0)
+	})
+}
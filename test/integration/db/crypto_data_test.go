@@ -397,3 +397,95 @@ func TestSetXMRCryptoDataByUserId(t *testing.T) {
 	})
 
 }
+
+func TestUpsertKeysEVMCryptoData(t *testing.T) {
+	t.Run("Should Reset Indices On Keys Update", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.UpsertKeysEVMCryptoData(ctx, db.UpsertKeysEVMCryptoDataParams{UserID: userId, Chain: "POLYGON", MasterPubKey: uuid.NewString()})
+			if err != nil {
+				log.Fatal(err)
+			}
+			_, err = q.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain(ctx, db.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainParams{UserID: userId, Chain: "POLYGON"})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			masterPubKey := uuid.NewString()
+			evm, err := q.UpsertKeysEVMCryptoData(ctx, db.UpsertKeysEVMCryptoDataParams{UserID: userId, Chain: "POLYGON", MasterPubKey: masterPubKey})
+			assert.NoError(t, err)
+			assert.Equal(t, masterPubKey, evm.MasterPubKey)
+			assert.Equal(t, int32(0), evm.LastMajorIndex)
+			assert.Equal(t, int32(0), evm.LastMinorIndex)
+		})
+	})
+
+	t.Run("Should Return SQL Error (non unique master public key)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			evm, err := q.UpsertKeysEVMCryptoData(ctx, db.UpsertKeysEVMCryptoDataParams{UserID: userId, Chain: "POLYGON", MasterPubKey: uuid.NewString()})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.UpsertKeysEVMCryptoData(ctx, db.UpsertKeysEVMCryptoDataParams{UserID: userId, Chain: "ARBITRUM", MasterPubKey: evm.MasterPubKey})
+			var pgErr *pgconn.PgError
+			assert.ErrorAs(t, err, &pgErr)
+			assert.Equal(t, "23505", pgErr.Code)
+		})
+	})
+}
+
+func TestFindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain(t *testing.T) {
+	t.Run("Should Return Properly Incremented Indices", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			evm, err := q.UpsertKeysEVMCryptoData(ctx, db.UpsertKeysEVMCryptoDataParams{UserID: userId, Chain: "POLYGON", MasterPubKey: uuid.NewString()})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			keysAndIndices, err := q.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain(ctx, db.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainParams{UserID: userId, Chain: "POLYGON"})
+			assert.NoError(t, err)
+			assert.Equal(t, evm.MasterPubKey, keysAndIndices.MasterPubKey)
+			assert.Equal(t, int32(0), keysAndIndices.LastMajorIndex)
+			assert.Equal(t, int32(1), keysAndIndices.LastMinorIndex)
+		})
+	})
+
+	t.Run("Should Return SQL Error (no rows)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChain(ctx, db.FindKeysAndIncrementedIndicesEVMCryptoDataByUserIdAndChainParams{UserID: userId, Chain: "POLYGON"})
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
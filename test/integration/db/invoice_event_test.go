@@ -0,0 +1,146 @@
+package db_test
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestInvoiceEvent(ctx context.Context, q *db.Queries, invoice *db.Invoice) (db.InvoiceEvent, error) {
+	payload, err := json.Marshal(invoice)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return q.CreateInvoiceEvent(ctx, db.CreateInvoiceEventParams{InvoiceID: invoice.ID, Status: invoice.Status, Type: db.InvoiceEventTypeSTATUSCHANGED, Payload: payload})
+}
+
+func TestCreateInvoiceEvent(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		firstEvent, err := createTestInvoiceEvent(ctx, q, &inv)
+		assert.NoError(t, err)
+		assert.Equal(t, inv.ID, firstEvent.InvoiceID)
+		assert.Equal(t, inv.Status, firstEvent.Status)
+
+		var payloadInvoice db.Invoice
+		assert.NoError(t, json.Unmarshal(firstEvent.Payload, &payloadInvoice))
+		assert.Equal(t, inv.ID, payloadInvoice.ID)
+
+		secondEvent, err := createTestInvoiceEvent(ctx, q, &inv)
+		assert.NoError(t, err)
+		assert.Greater(t, secondEvent.Sequence, firstEvent.Sequence)
+	})
+}
+
+func TestFindInvoiceEventsAfterSequence(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expectedEvents [4]db.InvoiceEvent
+		for i := 0; i < len(expectedEvents); i++ {
+			event, err := createTestInvoiceEvent(ctx, q, &inv)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			expectedEvents[i] = event
+		}
+
+		// Published in reverse, as if the later events were committed first.
+		for i := len(expectedEvents) - 1; i >= 0; i-- {
+			event, err := q.PublishInvoiceEvent(ctx, expectedEvents[i].Sequence)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			expectedEvents[i] = event
+		}
+
+		events, err := q.FindInvoiceEventsAfterSequence(ctx, db.FindInvoiceEventsAfterSequenceParams{Sequence: expectedEvents[3].PublishedSequence.Int64, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(events))
+		assert.Equal(t, expectedEvents[2].Sequence, events[0].Sequence)
+		assert.Equal(t, expectedEvents[1].Sequence, events[1].Sequence)
+
+		events, err = q.FindInvoiceEventsAfterSequence(ctx, db.FindInvoiceEventsAfterSequenceParams{Sequence: expectedEvents[0].PublishedSequence.Int64, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(events))
+	})
+}
+
+func TestInvoiceEventsOutbox(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expectedEvents [3]db.InvoiceEvent
+		for i := 0; i < len(expectedEvents); i++ {
+			event, err := createTestInvoiceEvent(ctx, q, &inv)
+			if err != nil {
+				log.Fatal(err)
+			}
+			assert.False(t, event.PublishedAt.Valid)
+
+			expectedEvents[i] = event
+		}
+
+		events, err := q.FindUnpublishedInvoiceEventsAndLock(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(events))
+		assert.Equal(t, expectedEvents[0].Sequence, events[0].Sequence)
+		assert.Equal(t, expectedEvents[1].Sequence, events[1].Sequence)
+
+		assert.NoError(t, q.LockInvoiceEventsPublishing(ctx))
+		for i := 0; i < len(events); i++ {
+			published, err := q.PublishInvoiceEvent(ctx, events[i].Sequence)
+			assert.NoError(t, err)
+			assert.True(t, published.PublishedAt.Valid)
+			assert.True(t, published.PublishedSequence.Valid)
+		}
+
+		events, err = q.FindUnpublishedInvoiceEventsAndLock(ctx, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(events))
+		assert.Equal(t, expectedEvents[2].Sequence, events[0].Sequence)
+	})
+}
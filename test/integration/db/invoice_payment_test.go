@@ -0,0 +1,140 @@
+package db_test
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"testing"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/internal/util"
+	"github.com/chekist32/goipay/test"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvoicePayments(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		total, err := q.SumInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "0", total.Int.String())
+
+		txIds := []string{uuid.NewString(), uuid.NewString()}
+		for i := 0; i < len(txIds); i++ {
+			payment, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{
+				InvoiceID: inv.ID,
+				TxID:      txIds[i],
+				Amount:    pgtype.Numeric{Int: big.NewInt(int64(i + 1)), Valid: true},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, txIds[i], payment.TxID)
+		}
+
+		_, err = q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{
+			InvoiceID: inv.ID,
+			TxID:      txIds[0],
+			Amount:    pgtype.Numeric{Int: big.NewInt(1), Valid: true},
+		})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		total, err = q.SumInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "3", total.Int.String())
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 2)
+
+		payment, err := q.UpdateInvoicePaymentConfirmationsById(ctx, db.UpdateInvoicePaymentConfirmationsByIdParams{
+			ID:            payments[0].ID,
+			Confirmations: 3,
+			BlockHeight:   pgtype.Int8{Int64: 100, Valid: true},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), payment.Confirmations)
+		assert.Equal(t, int64(100), payment.BlockHeight.Int64)
+
+		partiallyPaidInv, err := q.UpdateInvoiceStatusPartiallyPaidById(ctx, db.UpdateInvoiceStatusPartiallyPaidByIdParams{ID: inv.ID, ActualAmount: total})
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypePARTIALLYPAID, partiallyPaidInv.Status)
+
+		expiredInv, err := q.ExpireInvoiceById(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, db.NullPaymentOutcomeType{PaymentOutcomeType: db.PaymentOutcomeTypeUNDERPAID, Valid: true}, expiredInv.PaymentOutcome)
+		requiredAmount, err := util.PgNumericToBigInt(inv.RequiredAmount)
+		if err != nil {
+			log.Fatal(err)
+		}
+		assert.Equal(t, requiredAmount.Sub(requiredAmount, big.NewInt(3)).String(), util.PgNumericToString(expiredInv.PaymentOutcomeAmount))
+
+		invoices, err := q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{TxID: pgtype.Text{String: txIds[1], Valid: true}, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 1)
+		assert.Equal(t, inv.ID, invoices[0].ID)
+	})
+}
+
+func TestDroppedInvoicePayment(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		txId := uuid.NewString()
+		amount := pgtype.Numeric{Int: big.NewInt(1), Valid: true}
+		if _, err := q.CreateInvoicePayment(ctx, db.CreateInvoicePaymentParams{InvoiceID: inv.ID, TxID: txId, Amount: amount}); err != nil {
+			log.Fatal(err)
+		}
+		if _, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: inv.ID, ActualAmount: amount, TxID: pgtype.Text{String: txId, Valid: true}}); err != nil {
+			log.Fatal(err)
+		}
+
+		invoices, err := q.FindInvoicesByPaymentTxIdAndLock(ctx, db.FindInvoicesByPaymentTxIdAndLockParams{Coin: inv.Coin, TxID: txId})
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 1)
+		assert.Equal(t, inv.ID, invoices[0].ID)
+
+		assert.NoError(t, q.DeleteInvoicePaymentByInvoiceIdAndTxId(ctx, db.DeleteInvoicePaymentByInvoiceIdAndTxIdParams{InvoiceID: inv.ID, TxID: txId}))
+
+		payments, err := q.FindInvoicePaymentsByInvoiceId(ctx, inv.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 0)
+
+		revertedInv, err := q.RevertInvoiceToPendingById(ctx, db.RevertInvoiceToPendingByIdParams{ID: inv.ID, Status: db.InvoiceStatusTypePENDING, ActualAmount: pgtype.Numeric{Int: big.NewInt(0), Valid: true}})
+		assert.NoError(t, err)
+		assert.Equal(t, db.InvoiceStatusTypePENDING, revertedInv.Status)
+		assert.False(t, revertedInv.TxID.Valid)
+		assert.False(t, revertedInv.PaymentOutcome.Valid)
+
+		_, err = q.RevertInvoiceToPendingById(ctx, db.RevertInvoiceToPendingByIdParams{ID: inv.ID, Status: db.InvoiceStatusTypePENDING, ActualAmount: pgtype.Numeric{Int: big.NewInt(0), Valid: true}})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		invoices, err = q.FindInvoicesByPaymentTxIdAndLock(ctx, db.FindInvoicesByPaymentTxIdAndLockParams{Coin: inv.Coin, TxID: txId})
+		assert.NoError(t, err)
+		assert.Len(t, invoices, 0)
+	})
+}
@@ -3,6 +3,7 @@ package db_test
 import (
 	"context"
 	"log"
+	"math/big"
 	"math/rand"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func createRandTestInvoice(ctx context.Context, q *db.Queries, userId pgtype.UUI
 	return q.CreateInvoice(ctx, db.CreateInvoiceParams{
 		CryptoAddress:         uuid.NewString(),
 		Coin:                  dbCoinTypes[rand.Intn(len(dbCoinTypes))],
-		RequiredAmount:        rand.Float64(),
+		RequiredAmount:        pgtype.Numeric{Int: big.NewInt(rand.Int63()), Valid: true},
 		ConfirmationsRequired: int16(rand.Int()),
 		ExpiresAt:             expiresAt,
 		UserID:                userId,
@@ -116,6 +117,53 @@ func TestExpireInvoiceById(t *testing.T) {
 	})
 }
 
+func TestCancelInvoiceById(t *testing.T) {
+	t.Run("Should Cancel PENDING Invoice", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			cancelledInv, err := q.CancelInvoiceById(ctx, inv.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, db.InvoiceStatusTypeCANCELLED, cancelledInv.Status)
+		})
+	})
+
+	t.Run("Should Return pgx.ErrNoRows (invoice is not PENDING)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			inv, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := q.ConfirmInvoiceById(ctx, inv.ID); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err = q.CancelInvoiceById(ctx, inv.ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
+
 func TestConfirmInvoiceStatusMempoolById(t *testing.T) {
 	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
 		ctx := context.Background()
@@ -131,10 +179,10 @@ func TestConfirmInvoiceStatusMempoolById(t *testing.T) {
 			log.Fatal(err)
 		}
 
-		expectedActualAmount := 1.2
+		expectedActualAmount := "12"
 		expectedTxId := "txid"
 
-		var actualAmount pgtype.Float8
+		var actualAmount pgtype.Numeric
 		if err := actualAmount.Scan(expectedActualAmount); err != nil {
 			log.Fatal(err)
 		}
@@ -146,7 +194,7 @@ func TestConfirmInvoiceStatusMempoolById(t *testing.T) {
 		confirmedInv, err := q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: inv.ID, ActualAmount: actualAmount, TxID: txId})
 		assert.NoError(t, err)
 		assert.Equal(t, db.InvoiceStatusTypePENDINGMEMPOOL, confirmedInv.Status)
-		assert.Equal(t, expectedActualAmount, confirmedInv.ActualAmount.Float64)
+		assert.Equal(t, expectedActualAmount, confirmedInv.ActualAmount.Int.String())
 		assert.Equal(t, expectedTxId, confirmedInv.TxID.String)
 	})
 }
@@ -172,8 +220,8 @@ func TestFindAllPendingInvoices(t *testing.T) {
 				expectedInvoices[i] = inv
 			}
 
-			var actualAmount pgtype.Float8
-			if err := actualAmount.Scan(1.5); err != nil {
+			var actualAmount pgtype.Numeric
+			if err := actualAmount.Scan("15"); err != nil {
 				log.Fatal(err)
 			}
 			var txId pgtype.Text
@@ -217,8 +265,8 @@ func TestFindAllPendingInvoices(t *testing.T) {
 				expectedInvoices[i] = inv
 			}
 
-			var actualAmount pgtype.Float8
-			if err := actualAmount.Scan(1.5); err != nil {
+			var actualAmount pgtype.Numeric
+			if err := actualAmount.Scan("15"); err != nil {
 				log.Fatal(err)
 			}
 			var txId pgtype.Text
@@ -323,3 +371,260 @@ func TestShiftExpiresAtForNonConfirmedInvoices(t *testing.T) {
 		}
 	})
 }
+
+func TestFindInvoiceById(t *testing.T) {
+	t.Run("Should Return Invoice", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			expectedInvoice, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			invoice, err := q.FindInvoiceById(ctx, expectedInvoice.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedInvoice, invoice)
+		})
+	})
+
+	t.Run("Should Return pgx.ErrNoRows (no such id)", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			var id pgtype.UUID
+			if err := id.Scan(uuid.NewString()); err != nil {
+				log.Fatal(err)
+			}
+
+			_, err := q.FindInvoiceById(ctx, id)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+}
+
+func TestFindInvoicesFiltered(t *testing.T) {
+	t.Run("Should Filter Invoices By UserId And Status", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId1, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+			userId2, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var expectedInvoices [3]db.Invoice
+			for i := 0; i < len(expectedInvoices); i++ {
+				inv, err := createRandTestInvoice(ctx, q, userId1)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				expectedInvoices[i] = inv
+			}
+			if _, err := createRandTestInvoice(ctx, q, userId2); err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := q.ExpireInvoiceById(ctx, expectedInvoices[0].ID); err != nil {
+				log.Fatal(err)
+			}
+
+			invoices, err := q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{UserID: userId1, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, 3, len(invoices))
+
+			invoices, err = q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{
+				UserID: userId1,
+				Status: db.NullInvoiceStatusType{InvoiceStatusType: db.InvoiceStatusTypePENDING, Valid: true},
+				Limit:  10,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(invoices))
+			for i := 0; i < len(invoices); i++ {
+				assert.Equal(t, db.InvoiceStatusTypePENDING, invoices[i].Status)
+				assert.Equal(t, userId1, invoices[i].UserID)
+			}
+		})
+	})
+
+	t.Run("Should Filter Invoices By ExternalId And Metadata", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var expiresAt pgtype.Timestamptz
+			if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+				log.Fatal(err)
+			}
+
+			externalId := pgtype.Text{String: uuid.NewString(), Valid: true}
+			expectedInvoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+				CryptoAddress: uuid.NewString(),
+				Coin:          db.CoinTypeXMR,
+				ExpiresAt:     expiresAt,
+				UserID:        userId,
+				ExternalID:    externalId,
+				Metadata:      []byte(`{"customer":"42"}`),
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			otherInvoice, err := createRandTestInvoice(ctx, q, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+			assert.JSONEq(t, `{}`, string(otherInvoice.Metadata))
+
+			invoices, err := q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{UserID: userId, ExternalID: externalId, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(invoices))
+			assert.Equal(t, expectedInvoice.ID, invoices[0].ID)
+
+			invoices, err = q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{UserID: userId, MetadataKey: pgtype.Text{String: "customer", Valid: true}, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(invoices))
+			assert.Equal(t, expectedInvoice.ID, invoices[0].ID)
+
+			invoices, err = q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{
+				UserID:        userId,
+				MetadataKey:   pgtype.Text{String: "customer", Valid: true},
+				MetadataValue: pgtype.Text{String: "43", Valid: true},
+				Limit:         10,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 0, len(invoices))
+		})
+	})
+
+	t.Run("Should Filter Invoices By TxId", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			var expectedInvoices [2]db.Invoice
+			for i := 0; i < len(expectedInvoices); i++ {
+				inv, err := createRandTestInvoice(ctx, q, userId)
+				if err != nil {
+					log.Fatal(err)
+				}
+
+				expectedInvoices[i] = inv
+			}
+
+			var actualAmount pgtype.Numeric
+			if err := actualAmount.Scan("15"); err != nil {
+				log.Fatal(err)
+			}
+			var txId pgtype.Text
+			if err := txId.Scan(uuid.NewString()); err != nil {
+				log.Fatal(err)
+			}
+			_, err = q.ConfirmInvoiceStatusMempoolById(ctx, db.ConfirmInvoiceStatusMempoolByIdParams{ID: expectedInvoices[1].ID, ActualAmount: actualAmount, TxID: txId})
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			invoices, err := q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{TxID: txId, Limit: 10})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(invoices))
+			assert.Equal(t, expectedInvoices[1].ID, invoices[0].ID)
+		})
+	})
+
+	t.Run("Should Paginate Invoices", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			for i := 0; i < 5; i++ {
+				if _, err := createRandTestInvoice(ctx, q, userId); err != nil {
+					log.Fatal(err)
+				}
+			}
+
+			firstPage, err := q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{UserID: userId, Limit: 3, Offset: 0})
+			assert.NoError(t, err)
+			assert.Equal(t, 3, len(firstPage))
+
+			secondPage, err := q.FindInvoicesFiltered(ctx, db.FindInvoicesFilteredParams{UserID: userId, Limit: 3, Offset: 3})
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(secondPage))
+
+			for i := 0; i < len(firstPage); i++ {
+				for j := 0; j < len(secondPage); j++ {
+					assert.NotEqual(t, firstPage[i].ID, secondPage[j].ID)
+				}
+			}
+		})
+	})
+}
+
+func TestFindInvoiceByUserIdAndIdempotencyKey(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if err := expiresAt.Scan(time.Now().UTC()); err != nil {
+			log.Fatal(err)
+		}
+
+		params := db.CreateInvoiceParams{
+			CryptoAddress:          uuid.NewString(),
+			Coin:                   db.CoinTypeXMR,
+			RequiredAmount:         pgtype.Numeric{Int: big.NewInt(1), Valid: true},
+			ExpiresAt:              expiresAt,
+			UserID:                 userId,
+			IdempotencyKey:         pgtype.Text{String: uuid.NewString(), Valid: true},
+			IdempotencyFingerprint: pgtype.Text{String: uuid.NewString(), Valid: true},
+		}
+		inv, err := q.CreateInvoice(ctx, params)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		foundInv, err := q.FindInvoiceByUserIdAndIdempotencyKey(ctx, db.FindInvoiceByUserIdAndIdempotencyKeyParams{UserID: userId, IdempotencyKey: params.IdempotencyKey})
+		assert.NoError(t, err)
+		assert.Equal(t, inv, foundInv)
+
+		_, err = q.FindInvoiceByUserIdAndIdempotencyKey(ctx, db.FindInvoiceByUserIdAndIdempotencyKeyParams{UserID: userId, IdempotencyKey: pgtype.Text{String: uuid.NewString(), Valid: true}})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		params.CryptoAddress = uuid.NewString()
+		_, err = q.CreateInvoice(ctx, params)
+		assert.Error(t, err)
+	})
+}
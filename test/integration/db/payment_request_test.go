@@ -0,0 +1,138 @@
+package db_test
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPaymentRequestInvoices(ctx context.Context, q *db.Queries, userId pgtype.UUID, paymentRequestId pgtype.UUID) []db.Invoice {
+	coins := []db.CoinType{db.CoinTypeBTC, db.CoinTypeXMR}
+	invoices := make([]db.Invoice, 0, len(coins))
+
+	for i := 0; i < len(coins); i++ {
+		invoice, err := q.CreateInvoice(ctx, db.CreateInvoiceParams{
+			CryptoAddress:    uuid.NewString(),
+			Coin:             coins[i],
+			RequiredAmount:   pgtype.Numeric{Int: big.NewInt(1), Valid: true},
+			ExpiresAt:        pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+			UserID:           userId,
+			PaymentRequestID: paymentRequestId,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		invoices = append(invoices, invoice)
+	}
+
+	return invoices
+}
+
+func TestPaymentRequests(t *testing.T) {
+	t.Run("Should Be Paid Only Once", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			paymentRequest, err := q.CreatePaymentRequest(ctx, userId)
+			assert.NoError(t, err)
+			assert.Equal(t, db.PaymentRequestStatusTypePENDING, paymentRequest.Status)
+
+			invoices := createTestPaymentRequestInvoices(ctx, q, userId, paymentRequest.ID)
+
+			foundInvoices, err := q.FindInvoicesByPaymentRequestId(ctx, paymentRequest.ID)
+			assert.NoError(t, err)
+			assert.Len(t, foundInvoices, len(invoices))
+
+			paidRequest, err := q.MarkPaymentRequestPaidById(ctx, db.MarkPaymentRequestPaidByIdParams{ID: paymentRequest.ID, PaidInvoiceID: invoices[0].ID})
+			assert.NoError(t, err)
+			assert.Equal(t, db.PaymentRequestStatusTypePAID, paidRequest.Status)
+			assert.Equal(t, invoices[0].ID, paidRequest.PaidInvoiceID)
+			assert.True(t, paidRequest.PaidAt.Valid)
+
+			_, err = q.MarkPaymentRequestPaidById(ctx, db.MarkPaymentRequestPaidByIdParams{ID: paymentRequest.ID, PaidInvoiceID: invoices[1].ID})
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+
+	t.Run("Should Be Pending Again After The Paying Invoice Is Reverted", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			paymentRequest, err := q.CreatePaymentRequest(ctx, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			invoices := createTestPaymentRequestInvoices(ctx, q, userId, paymentRequest.ID)
+
+			if _, err := q.MarkPaymentRequestPaidById(ctx, db.MarkPaymentRequestPaidByIdParams{ID: paymentRequest.ID, PaidInvoiceID: invoices[0].ID}); err != nil {
+				log.Fatal(err)
+			}
+
+			assert.NoError(t, q.RevertPaymentRequestPaidByInvoiceId(ctx, invoices[0].ID))
+
+			revertedRequest, err := q.FindPaymentRequestById(ctx, paymentRequest.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, db.PaymentRequestStatusTypePENDING, revertedRequest.Status)
+			assert.False(t, revertedRequest.PaidInvoiceID.Valid)
+
+			paidRequest, err := q.MarkPaymentRequestPaidById(ctx, db.MarkPaymentRequestPaidByIdParams{ID: paymentRequest.ID, PaidInvoiceID: invoices[1].ID})
+			assert.NoError(t, err)
+			assert.Equal(t, invoices[1].ID, paidRequest.PaidInvoiceID)
+		})
+	})
+
+	t.Run("Should Expire Only Without Payable Invoices", func(t *testing.T) {
+		test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+			ctx := context.Background()
+			q := db.New(tx)
+
+			userId, err := q.CreateUser(ctx)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			paymentRequest, err := q.CreatePaymentRequest(ctx, userId)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			invoices := createTestPaymentRequestInvoices(ctx, q, userId, paymentRequest.ID)
+
+			if _, err := q.ExpireInvoiceById(ctx, invoices[0].ID); err != nil {
+				log.Fatal(err)
+			}
+			_, err = q.ExpirePaymentRequestById(ctx, paymentRequest.ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+			if _, err := q.CancelInvoiceById(ctx, invoices[1].ID); err != nil {
+				log.Fatal(err)
+			}
+			expiredRequest, err := q.ExpirePaymentRequestById(ctx, paymentRequest.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, db.PaymentRequestStatusTypeEXPIRED, expiredRequest.Status)
+		})
+	})
+}
@@ -2,11 +2,13 @@ package db_test
 
 import (
 	"context"
+	"log"
 	"testing"
 
 	"github.com/chekist32/goipay/internal/db"
 	"github.com/chekist32/goipay/test"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,3 +22,27 @@ func TestCreateUser(t *testing.T) {
 		assert.True(t, user.Valid)
 	})
 }
+
+func TestUpdateTolerancePercentByUserId(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tolerancePercent, err := q.FindTolerancePercentByUserId(ctx, userId)
+		assert.NoError(t, err)
+		assert.False(t, tolerancePercent.Valid)
+
+		tolerancePercent, err = q.UpdateTolerancePercentByUserId(ctx, db.UpdateTolerancePercentByUserIdParams{ID: userId, TolerancePercent: pgtype.Float8{Float64: 0.5, Valid: true}})
+		assert.NoError(t, err)
+		assert.Equal(t, 0.5, tolerancePercent.Float64)
+
+		tolerancePercent, err = q.FindTolerancePercentByUserId(ctx, userId)
+		assert.NoError(t, err)
+		assert.Equal(t, pgtype.Float8{Float64: 0.5, Valid: true}, tolerancePercent)
+	})
+}
@@ -0,0 +1,91 @@
+package db_test
+
+import (
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/chekist32/goipay/internal/db"
+	"github.com/chekist32/goipay/test"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	test.RunInTransaction(t, dbConnPool, func(t *testing.T, tx pgx.Tx) {
+		ctx := context.Background()
+		q := db.New(tx)
+
+		userId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		webhook, err := q.CreateWebhook(ctx, db.CreateWebhookParams{Url: "http://localhost/hook", Secret: "secret", UserID: userId})
+		assert.NoError(t, err)
+		assert.Equal(t, userId, webhook.UserID)
+
+		inv, err := createRandTestInvoice(ctx, q, userId)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		n, err := q.CreateWebhookDeliveriesByUserId(ctx, db.CreateWebhookDeliveriesByUserIdParams{InvoiceID: inv.ID, Payload: []byte(`{}`), UserID: userId})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+
+		claimedUntil := pgtype.Timestamptz{Time: time.Now().UTC().Add(time.Minute), Valid: true}
+		due, err := q.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{ClaimedUntil: claimedUntil, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, due, 1)
+		assert.Equal(t, webhook.Url, due[0].Url)
+		assert.Equal(t, webhook.Secret, due[0].Secret)
+		assert.Equal(t, int32(1), due[0].Attempts)
+
+		// The claimed delivery isn't due until the claim expires.
+		claimed, err := q.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{ClaimedUntil: claimedUntil, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, claimed, 0)
+
+		failed, err := q.MarkWebhookDeliveryFailed(ctx, db.MarkWebhookDeliveryFailedParams{
+			ID:            due[0].ID,
+			Status:        db.WebhookDeliveryStatusTypeDEAD,
+			NextAttemptAt: pgtype.Timestamptz{Time: time.Now().UTC().Add(time.Hour), Valid: true},
+			LastError:     pgtype.Text{String: "error", Valid: true},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypeDEAD, failed.Status)
+		assert.Equal(t, int32(1), failed.Attempts)
+
+		due, err = q.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{ClaimedUntil: claimedUntil, Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, due, 0)
+
+		deliveries, err := q.FindWebhookDeliveriesByUserId(ctx, db.FindWebhookDeliveriesByUserIdParams{
+			UserID: userId,
+			Status: db.NullWebhookDeliveryStatusType{WebhookDeliveryStatusType: db.WebhookDeliveryStatusTypeDEAD, Valid: true},
+			Limit:  10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, deliveries, 1)
+
+		otherUserId, err := q.CreateUser(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		_, err = q.RedeliverWebhookDeliveryByIdAndUserId(ctx, db.RedeliverWebhookDeliveryByIdAndUserIdParams{ID: failed.ID, UserID: otherUserId})
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		redelivered, err := q.RedeliverWebhookDeliveryByIdAndUserId(ctx, db.RedeliverWebhookDeliveryByIdAndUserIdParams{ID: failed.ID, UserID: userId})
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypePENDING, redelivered.Status)
+		assert.Equal(t, int32(0), redelivered.Attempts)
+
+		delivered, err := q.MarkWebhookDeliveryDelivered(ctx, db.MarkWebhookDeliveryDeliveredParams{ID: failed.ID, LastResponseCode: pgtype.Int4{Int32: 200, Valid: true}})
+		assert.NoError(t, err)
+		assert.Equal(t, db.WebhookDeliveryStatusTypeDELIVERED, delivered.Status)
+		assert.True(t, delivered.DeliveredAt.Valid)
+	})
+}
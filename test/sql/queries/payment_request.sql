@@ -0,0 +1,3 @@
+-- name: FindPaymentRequestsByUserId :many
+SELECT * FROM payment_requests
+WHERE user_id = $1;